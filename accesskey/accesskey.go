@@ -0,0 +1,137 @@
+// Package accesskey manages the API access keys and webhook signing keys of
+// the account, e.g. to rotate credentials on a schedule.
+package accesskey
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// The modes of an AccessKey.
+const (
+	ModeLive = "live"
+	ModeTest = "test"
+)
+
+// AccessKey is an API access key of the account, used to authenticate
+// requests with messagebird.New.
+type AccessKey struct {
+	ID          string
+	Mode        string // ModeLive or ModeTest.
+	Description string
+
+	// Key is only returned when the access key is created. Store it, as it
+	// can not be retrieved afterwards.
+	Key string
+
+	CreatedDatetime *time.Time
+}
+
+// AccessKeyList represents a list of access keys.
+type AccessKeyList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []AccessKey
+}
+
+// SigningKey is a key used to sign the webhooks of the account; see the
+// signature package.
+type SigningKey struct {
+	ID              string
+	Key             string
+	CreatedDatetime *time.Time
+}
+
+// SigningKeyList represents a list of signing keys.
+type SigningKeyList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []SigningKey
+}
+
+// Params holds the settings of a new access key.
+type Params struct {
+	Mode        string `json:"mode"`
+	Description string `json:"description,omitempty"`
+}
+
+const (
+	// path represents the path to the AccessKey resource.
+	path = "access-keys"
+
+	// signingKeyPath represents the path to the SigningKey resource.
+	signingKeyPath = "signing-keys"
+)
+
+// List retrieves the access keys of the account. The keys themselves are not
+// included.
+func List(c *messagebird.Client) (*AccessKeyList, error) {
+	keyList := &AccessKeyList{}
+	if err := c.Request(keyList, http.MethodGet, path, nil); err != nil {
+		return nil, err
+	}
+
+	return keyList, nil
+}
+
+// Create creates an access key. The returned access key includes its Key.
+func Create(c *messagebird.Client, params *Params) (*AccessKey, error) {
+	if params == nil || (params.Mode != ModeLive && params.Mode != ModeTest) {
+		return nil, errors.New("mode must be live or test")
+	}
+
+	key := &AccessKey{}
+	if err := c.Request(key, http.MethodPost, path, params); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Revoke revokes the access key with the given ID. Requests authenticated
+// with it are rejected afterwards, including those of c if it uses the key.
+func Revoke(c *messagebird.Client, id string) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, path+"/"+url.PathEscape(id), nil)
+}
+
+// Rotate creates an access key with params and then revokes the access key
+// with the given ID. If revoking fails, the new access key is returned with
+// the error, so it is not lost and the revocation can be retried.
+func Rotate(c *messagebird.Client, id string, params *Params) (*AccessKey, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	key, err := Create(c, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := Revoke(c, id); err != nil {
+		return key, err
+	}
+
+	return key, nil
+}
+
+// ListSigningKeys retrieves the keys used to sign the webhooks of the
+// account.
+func ListSigningKeys(c *messagebird.Client) (*SigningKeyList, error) {
+	keyList := &SigningKeyList{}
+	if err := c.Request(keyList, http.MethodGet, signingKeyPath, nil); err != nil {
+		return nil, err
+	}
+
+	return keyList, nil
+}
@@ -0,0 +1,129 @@
+package accesskey
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "accessKeyList.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	keyList, err := List(client)
+	if err != nil {
+		t.Fatalf("unexpected error listing access keys: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/access-keys")
+
+	if keyList.TotalCount != 2 || keyList.Items[1].Mode != ModeLive || keyList.Items[1].Key != "" {
+		t.Errorf("got access keys %+v, expected a test and a live key without keys", keyList.Items)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "accessKeyObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	key, err := Create(client, &Params{Mode: ModeLive, Description: "Production"})
+	if err != nil {
+		t.Fatalf("unexpected error creating access key: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/access-keys")
+	mbtest.AssertTestdata(t, "accessKeyRequest.json", mbtest.Request.Body)
+
+	if key.ID != "ODQ1MjA0NjAz" || key.Key != "live_3ZzEkliHrxxQ4Ag5hJmSjRmD3" {
+		t.Errorf("got access key %+v, expected ODQ1MjA0NjAz with its key", key)
+	}
+}
+
+func TestCreateInvalidMode(t *testing.T) {
+	client := mbtest.Client(t)
+
+	for _, params := range []*Params{nil, {}, {Mode: "staging"}} {
+		if _, err := Create(client, params); err == nil {
+			t.Errorf("expected error creating access key with params %+v, got nil", params)
+		}
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	if err := Revoke(client, "ODQ1MjA0NjAx"); err != nil {
+		t.Fatalf("unexpected error revoking access key: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/access-keys/ODQ1MjA0NjAx")
+
+	if err := Revoke(client, ""); err == nil {
+		t.Error("expected error revoking access key without ID, got nil")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	var cases = []struct {
+		name         string
+		revokeStatus int
+		e            bool
+	}{
+		{name: "Revoked", revokeStatus: http.StatusNoContent},
+		{name: "Revoke failed", revokeStatus: http.StatusNotFound, e: true},
+	}
+
+	for _, tt := range cases {
+		var calls []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+				w.Write(mbtest.Testdata(t, "accessKeyObject.json"))
+				return
+			}
+			w.WriteHeader(tt.revokeStatus)
+			if tt.revokeStatus != http.StatusNoContent {
+				w.Write([]byte(`{"errors":[{"code":20,"description":"access key not found"}]}`))
+			}
+		}))
+		c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+
+		key, err := Rotate(c, "ODQ1MjA0NjAx", &Params{Mode: ModeLive})
+		ts.Close()
+
+		if (err != nil) != tt.e {
+			t.Errorf("got error %v, expected error %t, test case: %s", err, tt.e, tt.name)
+		}
+		if key == nil || key.Key != "live_3ZzEkliHrxxQ4Ag5hJmSjRmD3" {
+			t.Errorf("got access key %+v, expected the new key, test case: %s", key, tt.name)
+		}
+		if len(calls) != 2 || calls[0] != "POST /access-keys" || calls[1] != "DELETE /access-keys/ODQ1MjA0NjAx" {
+			t.Errorf("got calls %v, expected create and revoke, test case: %s", calls, tt.name)
+		}
+	}
+}
+
+func TestListSigningKeys(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "signingKeyList.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	keyList, err := ListSigningKeys(client)
+	if err != nil {
+		t.Fatalf("unexpected error listing signing keys: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/signing-keys")
+
+	if keyList.Count != 1 || keyList.Items[0].Key != "TOh6dSS4Bo8CpmUKfJy2Ewd2xn9gvIaR" {
+		t.Errorf("got signing keys %+v, expected TOh6dSS4Bo8CpmUKfJy2Ewd2xn9gvIaR", keyList.Items)
+	}
+}
@@ -8,15 +8,21 @@ import (
 
 // Balance describes your balance information.
 type Balance struct {
-	Payment string
-	Type    string
-	Amount  float32
+	Payment string // PaymentPrepaid or PaymentPostpaid.
+	Type    string // The unit of Amount, e.g. "credits" or "euros".
+	Amount  messagebird.Decimal
 }
 
+// The payment methods of an account.
+const (
+	PaymentPrepaid  = "prepaid"
+	PaymentPostpaid = "postpaid"
+)
+
 const path = "balance"
 
 // Read returns the balance information for the account that is associated with
-// the access key.
+// the access key. Use Client.WithContext to bound the request with a context.
 func Read(c *messagebird.Client) (*Balance, error) {
 	balance := &Balance{}
 	if err := c.Request(balance, http.MethodGet, path, nil); err != nil {
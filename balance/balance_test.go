@@ -8,12 +8,6 @@ import (
 	"github.com/messagebird/go-rest-api/internal/mbtest"
 )
 
-const Epsilon float32 = 0.001
-
-func cmpFloat32(a, b float32) bool {
-	return (a-b) < Epsilon && (b-a) < Epsilon
-}
-
 func TestMain(m *testing.M) {
 	mbtest.EnableServer(m)
 }
@@ -27,7 +21,7 @@ func TestRead(t *testing.T) {
 		t.Fatalf("Didn't expect error while fetching the balance: %s", err)
 	}
 
-	if balance.Payment != "prepaid" {
+	if balance.Payment != PaymentPrepaid {
 		t.Errorf("Unexpected balance payment: %s", balance.Payment)
 	}
 
@@ -35,8 +29,8 @@ func TestRead(t *testing.T) {
 		t.Errorf("Unexpected balance type: %s", balance.Type)
 	}
 
-	if !cmpFloat32(balance.Amount, 9.2) {
-		t.Errorf("Unexpected balance amount: %.2f", balance.Amount)
+	if balance.Amount.String() != "9.2" {
+		t.Errorf("Unexpected balance amount: %s", balance.Amount)
 	}
 }
 
@@ -0,0 +1,22 @@
+package balance
+
+import messagebird "github.com/messagebird/go-rest-api"
+
+// Service is the balance API. It is implemented by NewService, and by
+// fake.Balance for unit tests of code using the API.
+type Service interface {
+	Read() (*Balance, error)
+}
+
+// NewService returns a Service sending its requests with c.
+func NewService(c *messagebird.Client) Service {
+	return service{c: c}
+}
+
+type service struct {
+	c *messagebird.Client
+}
+
+func (s service) Read() (*Balance, error) {
+	return Read(s.c)
+}
@@ -0,0 +1,204 @@
+package messagebird
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerOpenDuration     = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without sending the request while the client's
+// CircuitBreaker is open.
+var ErrCircuitOpen = errors.New("messagebird: circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen fails all requests with ErrCircuitOpen.
+	CircuitOpen
+
+	// CircuitHalfOpen lets a limited number of probe requests through to
+	// find out whether the API recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return ""
+}
+
+// CircuitBreaker makes a Client fail fast during API outages. After
+// FailureThreshold consecutive failed attempts, i.e. 5xx responses and
+// transport errors other than context cancellation, the circuit opens and
+// requests fail with ErrCircuitOpen. After OpenDuration the circuit is
+// half-open and up to HalfOpenProbes requests are let through: the circuit
+// closes when one succeeds and opens again when one fails.
+//
+// A CircuitBreaker must not be copied after first use. Share it between
+// clients to protect them with a single circuit:
+//
+//	client.CircuitBreaker = &messagebird.CircuitBreaker{
+//	    OnStateChange: func(from, to messagebird.CircuitState) {
+//	        log.Printf("MessageBird circuit %s", to)
+//	    },
+//	}
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit. It defaults to 5.
+	FailureThreshold int
+
+	// OpenDuration is how long the circuit stays open before probing the
+	// API. It defaults to 30s.
+	OpenDuration time.Duration
+
+	// HalfOpenProbes is the number of concurrent requests let through while
+	// the circuit is half-open. It defaults to 1.
+	HalfOpenProbes int
+
+	// OnStateChange is called when the circuit changes state.
+	OnStateChange func(from, to CircuitState)
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	probes   int
+	openedAt time.Time
+	now      func() time.Time
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && b.elapsed() {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow reports ErrCircuitOpen if a request must not be sent.
+func (b *CircuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	from := b.state
+	if b.state == CircuitOpen && b.elapsed() {
+		b.state, b.probes = CircuitHalfOpen, 0
+	}
+	var err error
+	switch b.state {
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probes >= defaultInt(b.HalfOpenProbes, 1) {
+			err = ErrCircuitOpen
+		} else {
+			b.probes++
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+	return err
+}
+
+// record records the outcome of an attempt that was allowed.
+func (b *CircuitBreaker) record(resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+	failed := err != nil && !errors.Is(err, context.Canceled) || err == nil && resp.StatusCode >= 500
+	if err != nil && !failed {
+		// A canceled request tells nothing about the API, but frees up its
+		// probe.
+		b.release()
+		return
+	}
+
+	b.mu.Lock()
+	from := b.state
+	switch {
+	case b.state == CircuitHalfOpen && failed:
+		b.open()
+	case b.state == CircuitHalfOpen:
+		b.state, b.failures = CircuitClosed, 0
+	case b.state == CircuitClosed && failed:
+		b.failures++
+		if b.failures >= defaultInt(b.FailureThreshold, defaultBreakerFailureThreshold) {
+			b.open()
+		}
+	case b.state == CircuitClosed:
+		b.failures = 0
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+}
+
+// release frees up the probe of an allowed attempt that was not sent.
+func (b *CircuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	if b.state == CircuitHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+	b.mu.Unlock()
+}
+
+// open opens the circuit. b.mu must be held.
+func (b *CircuitBreaker) open() {
+	b.state, b.failures = CircuitOpen, 0
+	b.openedAt = b.clock()
+}
+
+// elapsed reports whether the open duration has passed. b.mu must be held.
+func (b *CircuitBreaker) elapsed() bool {
+	d := b.OpenDuration
+	if d <= 0 {
+		d = defaultBreakerOpenDuration
+	}
+	return b.clock().Sub(b.openedAt) >= d
+}
+
+func (b *CircuitBreaker) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+func (b *CircuitBreaker) changed(from, to CircuitState) {
+	if from != to && b.OnStateChange != nil {
+		b.OnStateChange(from, to)
+	}
+}
+
+// defaultInt returns n, or def if n is not positive.
+func defaultInt(n, def int) int {
+	if n <= 0 {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,127 @@
+package messagebird
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	now := time.Unix(1544544948, 0)
+	var changes []string
+	b := &CircuitBreaker{
+		FailureThreshold: 2,
+		OpenDuration:     time.Minute,
+		OnStateChange: func(from, to CircuitState) {
+			changes = append(changes, from.String()+" -> "+to.String())
+		},
+		now: func() time.Time { return now },
+	}
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.CircuitBreaker = b
+
+	for i := 0; i < 2; i++ {
+		if err := c.Request(nil, http.MethodGet, ts.URL, nil); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("unexpected open circuit after %d failures", i)
+		}
+	}
+	if err := c.Request(nil, http.MethodGet, ts.URL, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, expected %v", err, ErrCircuitOpen)
+	}
+	if requests != 2 || b.State() != CircuitOpen {
+		t.Fatalf("got %d requests in state %s, expected 2 in state open", requests, b.State())
+	}
+
+	now = now.Add(time.Minute)
+	if b.State() != CircuitHalfOpen {
+		t.Fatalf("got state %s, expected half-open", b.State())
+	}
+	status = http.StatusNoContent
+	if err := c.Request(nil, http.MethodGet, ts.URL, nil); err != nil {
+		t.Fatalf("unexpected error probing: %s", err)
+	}
+	if b.State() != CircuitClosed {
+		t.Errorf("got state %s, expected closed", b.State())
+	}
+
+	expected := []string{"closed -> open", "open -> half-open", "half-open -> closed"}
+	if len(changes) != len(expected) {
+		t.Fatalf("got state changes %q, expected %q", changes, expected)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("got state changes %q, expected %q", changes, expected)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailure(t *testing.T) {
+	now := time.Unix(1544544948, 0)
+	b := &CircuitBreaker{FailureThreshold: 1, now: func() time.Time { return now }}
+	failure := &http.Response{StatusCode: http.StatusBadGateway}
+
+	b.record(failure, nil)
+	now = now.Add(defaultBreakerOpenDuration)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("unexpected error allowing probe: %s", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("got %v allowing a second probe, expected %v", err, ErrCircuitOpen)
+	}
+
+	b.record(failure, nil)
+	if b.State() != CircuitOpen {
+		t.Errorf("got state %s after failed probe, expected open", b.State())
+	}
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	b := &CircuitBreaker{FailureThreshold: 1}
+	b.record(&http.Response{StatusCode: http.StatusNotFound}, nil)
+	if b.State() != CircuitClosed {
+		t.Errorf("got state %s after 404, expected closed", b.State())
+	}
+}
+
+func TestCircuitBreakerOpenSkipsRateLimiter(t *testing.T) {
+	now := time.Unix(1544544948, 0)
+	b := &CircuitBreaker{FailureThreshold: 1, now: func() time.Time { return now }}
+	l := &RateLimiter{Limit: Limit{Rate: 0.001}}
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.CircuitBreaker = b
+	c.RateLimiter = l
+
+	b.record(&http.Response{StatusCode: http.StatusBadGateway}, nil)
+	for i := 0; i < 3; i++ {
+		if err := c.Request(nil, http.MethodGet, "balance", nil); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("got %v, expected %v", err, ErrCircuitOpen)
+		}
+	}
+	if l.buckets != nil {
+		t.Errorf("got rate limit buckets %v, expected no tokens taken while open", l.buckets)
+	}
+
+	// A probe that times out waiting for a token must not hold up others.
+	now = now.Add(defaultBreakerOpenDuration)
+	l.reserve("", l.Limit)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.RequestContext(ctx, nil, http.MethodGet, "balance", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if err := b.allow(); err != nil {
+		t.Errorf("unexpected error allowing probe after a rate limited one: %s", err)
+	}
+}
@@ -0,0 +1,228 @@
+package messagebird
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultCacheMaxEntries = 1000
+
+// ResponseCache caches the responses of GET requests that carry an ETag or
+// Last-Modified header, and revalidates them with If-None-Match or
+// If-Modified-Since. When the API responds 304 Not Modified, the cached body
+// is decoded instead, which saves transferring and rate limiting resources
+// that are read often but rarely change, such as channels, call flows and
+// contact groups:
+//
+//	client.ResponseCache = &messagebird.ResponseCache{MaxEntries: 500}
+//
+// Responses are cached per access key, so a ResponseCache may be shared
+// between clients. A successful POST, PUT, PATCH or DELETE removes the
+// cached responses of the resource, its sub-resources and the collection
+// containing it. Responses with "Cache-Control: no-store" are not cached. A
+// ResponseCache must not be copied after first use.
+type ResponseCache struct {
+	// MaxEntries is the number of responses kept. The least recently used
+	// response is removed when it is exceeded. It defaults to 1000.
+	MaxEntries int
+
+	// MaxAge is how long cached responses are used without revalidating
+	// them. It defaults to 0, which revalidates every request.
+	MaxAge time.Duration
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+// cachedResponse is a response stored in a ResponseCache.
+type cachedResponse struct {
+	key      string
+	path     string
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+}
+
+// Len returns the number of cached responses.
+func (rc *ResponseCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.lru == nil {
+		return 0
+	}
+	return rc.lru.Len()
+}
+
+// Purge removes all cached responses.
+func (rc *ResponseCache) Purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.lru, rc.entries = nil, nil
+}
+
+// before prepares req: it returns the cached response of req, if any, and
+// whether it is fresh enough to be used without sending req. Otherwise the
+// validators of the cached response are set on req.
+func (rc *ResponseCache) before(req *http.Request) (*cachedResponse, bool) {
+	if rc == nil || req.Method != http.MethodGet {
+		return nil, false
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	el, ok := rc.entries[cacheKey(req)]
+	if !ok {
+		return nil, false
+	}
+	rc.lru.MoveToFront(el)
+	cr := el.Value.(*cachedResponse)
+
+	if rc.MaxAge > 0 && rc.clock().Sub(cr.storedAt) < rc.MaxAge {
+		return cr, true
+	}
+	if etag := cr.header.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	} else {
+		req.Header.Set("If-Modified-Since", cr.header.Get("Last-Modified"))
+	}
+	return cr, false
+}
+
+// after updates the cache with the response to req, and returns the response
+// and body to decode, which are the cached ones if the API responded 304.
+func (rc *ResponseCache) after(req *http.Request, cr *cachedResponse, resp *http.Response, body []byte) (*http.Response, []byte) {
+	if rc == nil || resp == nil {
+		return resp, body
+	}
+	if req.Method != http.MethodGet {
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			rc.invalidate(req)
+		}
+		return resp, body
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cr != nil:
+		rc.mu.Lock()
+		cr.storedAt = rc.clock()
+		rc.mu.Unlock()
+		return cr.response(req, resp), cr.body
+	case resp.StatusCode == http.StatusOK && cacheable(resp):
+		rc.store(&cachedResponse{
+			key:      cacheKey(req),
+			path:     req.URL.Host + req.URL.Path,
+			header:   resp.Header.Clone(),
+			body:     body,
+			storedAt: rc.clock(),
+		})
+	}
+	return resp, body
+}
+
+func (rc *ResponseCache) store(cr *cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.entries == nil {
+		rc.lru, rc.entries = list.New(), make(map[string]*list.Element)
+	}
+	if el, ok := rc.entries[cr.key]; ok {
+		el.Value = cr
+		rc.lru.MoveToFront(el)
+		return
+	}
+	rc.entries[cr.key] = rc.lru.PushFront(cr)
+
+	max := rc.MaxEntries
+	if max <= 0 {
+		max = defaultCacheMaxEntries
+	}
+	for rc.lru.Len() > max {
+		rc.remove(rc.lru.Back())
+	}
+}
+
+// invalidate removes the cached responses of the resource changed by req,
+// its sub-resources and the collection containing it.
+func (rc *ResponseCache) invalidate(req *http.Request) {
+	path := strings.TrimSuffix(req.URL.Host+req.URL.Path, "/")
+	parent := path[:strings.LastIndex(path, "/")+1]
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for el := rc.lruFront(); el != nil; {
+		next := el.Next()
+		p := strings.TrimSuffix(el.Value.(*cachedResponse).path, "/")
+		if p == path || strings.HasPrefix(p, path+"/") || p+"/" == parent {
+			rc.remove(el)
+		}
+		el = next
+	}
+}
+
+func (rc *ResponseCache) lruFront() *list.Element {
+	if rc.lru == nil {
+		return nil
+	}
+	return rc.lru.Front()
+}
+
+func (rc *ResponseCache) remove(el *list.Element) {
+	rc.lru.Remove(el)
+	delete(rc.entries, el.Value.(*cachedResponse).key)
+}
+
+func (rc *ResponseCache) clock() time.Time {
+	if rc.now != nil {
+		return rc.now()
+	}
+	return time.Now()
+}
+
+// response gets a 200 response to req with the cached headers. The request
+// ID of the cached response belongs to an earlier request, so it is replaced
+// by the one of the 304 response revalidating it, if any.
+func (cr *cachedResponse) response(req *http.Request, revalidated *http.Response) *http.Response {
+	header := cr.header.Clone()
+	for _, key := range requestIDHeaders {
+		header.Del(key)
+		if revalidated != nil && revalidated.Header.Get(key) != "" {
+			header.Set(key, revalidated.Header.Get(key))
+		}
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+		Request:    req,
+	}
+}
+
+// cacheable reports whether resp has a validator and may be stored.
+func cacheable(resp *http.Response) bool {
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return false
+	}
+	for _, directive := range strings.Split(resp.Header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheKey gets the key of the response to req, which includes a hash of its
+// credentials so responses are never served to other accounts.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:8]) + " " + req.URL.String()
+}
@@ -0,0 +1,224 @@
+package messagebird
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type cacheTestResource struct {
+	Name string
+}
+
+func TestResponseCacheRevalidate(t *testing.T) {
+	name := "first"
+	var requests, notModified int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodGet {
+			name = "second"
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		etag := `"` + name + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(`{"name":"` + name + `"}`))
+	}))
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.ResponseCache = &ResponseCache{}
+
+	var cases = []struct {
+		name        string
+		method      string
+		path        string
+		e           string
+		notModified int
+	}{
+		{name: "Miss", method: http.MethodGet, path: "/call-flows/abc", e: "first"},
+		{name: "Revalidated", method: http.MethodGet, path: "/call-flows/abc", e: "first", notModified: 1},
+		{name: "Revalidated again", method: http.MethodGet, path: "/call-flows/abc", e: "first", notModified: 2},
+		{name: "Update", method: http.MethodPut, path: "/call-flows/abc", notModified: 2},
+		{name: "Invalidated", method: http.MethodGet, path: "/call-flows/abc", e: "second", notModified: 2},
+	}
+
+	for _, tt := range cases {
+		var res cacheTestResource
+		if err := c.Request(&res, tt.method, ts.URL+tt.path, nil); err != nil {
+			t.Fatalf("unexpected error: %s, test case: %s", err, tt.name)
+		}
+		if res.Name != tt.e {
+			t.Errorf("got name %q, expected %q, test case: %s", res.Name, tt.e, tt.name)
+		}
+		if notModified != tt.notModified {
+			t.Errorf("got %d 304 responses, expected %d, test case: %s", notModified, tt.notModified, tt.name)
+		}
+	}
+	if requests != len(cases) {
+		t.Errorf("got %d requests, expected %d", requests, len(cases))
+	}
+}
+
+func TestResponseCacheLastModified(t *testing.T) {
+	modified := time.Date(2020, 3, 8, 12, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+	var conditional string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if conditional = r.Header.Get("If-Modified-Since"); conditional == modified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", modified)
+		w.Write([]byte(`{"name":"groups"}`))
+	}))
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.ResponseCache = &ResponseCache{}
+
+	for i := 0; i < 2; i++ {
+		var res cacheTestResource
+		if err := c.Request(&res, http.MethodGet, ts.URL+"/groups", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if res.Name != "groups" {
+			t.Errorf("got name %q, expected groups", res.Name)
+		}
+	}
+	if conditional != modified {
+		t.Errorf("got If-Modified-Since %q, expected %q", conditional, modified)
+	}
+}
+
+func TestResponseCacheMaxAge(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"channel"}`))
+	}))
+	defer ts.Close()
+
+	now := time.Unix(1544544948, 0)
+	rc := &ResponseCache{MaxAge: time.Minute, now: func() time.Time { return now }}
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.ResponseCache = rc
+
+	var cases = []struct {
+		name     string
+		elapsed  time.Duration
+		requests int
+	}{
+		{name: "Miss", requests: 1},
+		{name: "Fresh", elapsed: 30 * time.Second, requests: 1},
+		{name: "Stale", elapsed: 30 * time.Second, requests: 2},
+	}
+
+	for _, tt := range cases {
+		now = now.Add(tt.elapsed)
+		var res cacheTestResource
+		if err := c.Request(&res, http.MethodGet, ts.URL+"/channels/abc", nil); err != nil {
+			t.Fatalf("unexpected error: %s, test case: %s", err, tt.name)
+		}
+		if res.Name != "channel" || requests != tt.requests {
+			t.Errorf("got name %q after %d requests, expected channel after %d, test case: %s", res.Name, requests, tt.requests, tt.name)
+		}
+	}
+}
+
+func TestResponseCacheKeys(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		cacheControl := r.URL.Query().Get("cache-control")
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+		w.Write([]byte(`{"name":"` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer ts.Close()
+
+	rc := &ResponseCache{MaxEntries: 2}
+	a := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	a.ResponseCache = rc
+	b := New("test_ot4V7Ho4Fzrr3hLQyF5Zv1fXQ")
+	b.ResponseCache = rc
+
+	for _, c := range []*Client{a, b, b} {
+		var res cacheTestResource
+		if err := c.Request(&res, http.MethodGet, ts.URL+"/channels", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if res.Name != "AccessKey "+c.AccessKey {
+			t.Errorf("got name %q, expected the response for %s", res.Name, c.AccessKey)
+		}
+	}
+	if rc.Len() != 2 {
+		t.Errorf("got %d cached responses, expected 2", rc.Len())
+	}
+
+	if err := a.Request(nil, http.MethodGet, ts.URL+"/balance?cache-control=private,no-store", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := a.Request(nil, http.MethodGet, ts.URL+"/channels/abc", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rc.Len() != 2 {
+		t.Errorf("got %d cached responses, expected at most 2", rc.Len())
+	}
+
+	rc.Purge()
+	if rc.Len() != 0 {
+		t.Errorf("got %d cached responses after purging, expected 0", rc.Len())
+	}
+}
+
+func TestResponseCacheRequestID(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Request-Id", "req-"+strconv.Itoa(requests))
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"channel"}`))
+	}))
+	defer ts.Close()
+
+	now := time.Unix(1544544948, 0)
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.ResponseCache = &ResponseCache{MaxAge: time.Minute, now: func() time.Time { return now }}
+
+	var cases = []struct {
+		name    string
+		elapsed time.Duration
+		e       string
+	}{
+		{name: "Miss", e: "req-1"},
+		{name: "Revalidated", elapsed: 2 * time.Minute, e: "req-2"},
+		{name: "Fresh", e: ""},
+	}
+
+	for _, tt := range cases {
+		now = now.Add(tt.elapsed)
+		var resp Response
+		if err := c.WithResponse(&resp).Request(nil, http.MethodGet, ts.URL+"/channels/abc", nil); err != nil {
+			t.Fatalf("unexpected error: %s, test case: %s", err, tt.name)
+		}
+		if resp.RequestID != tt.e {
+			t.Errorf("got request ID %q, expected %q, test case: %s", resp.RequestID, tt.e, tt.name)
+		}
+	}
+}
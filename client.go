@@ -11,21 +11,19 @@ package messagebird
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
-	"net/url"
 	"runtime"
-	"strings"
 	"time"
 )
 
 const (
 	// ClientVersion is used in User-Agent request header to provide server with API level.
-	ClientVersion = "5.3.0"
+	ClientVersion = "6.0.0"
 
 	// Endpoint points you to MessageBird REST API.
 	Endpoint = "https://rest.messagebird.com"
@@ -35,7 +33,8 @@ const (
 )
 
 var (
-	// ErrUnexpectedResponse is used when there was an internal server error and nothing can be done at this point.
+	// ErrUnexpectedResponse is matched by the StatusError returned when there
+	// was an internal server error and nothing can be done at this point.
 	ErrUnexpectedResponse = errors.New("The MessageBird API is currently unavailable")
 )
 
@@ -45,6 +44,80 @@ type Client struct {
 	AccessKey  string       // The API access key
 	HTTPClient *http.Client // The HTTP client to send requests on
 	DebugLog   *log.Logger  // Optional logger for debugging purposes
+
+	// TokenSource authenticates requests with OAuth2 bearer tokens instead
+	// of AccessKey when set. See WithTokenSource.
+	TokenSource TokenSource
+
+	// Endpoints overrides the base URLs of the APIs when set.
+	Endpoints Endpoints
+
+	// RetryPolicy enables retrying failed requests when set.
+	RetryPolicy *RetryPolicy
+
+	// CircuitBreaker makes requests fail fast during API outages when set.
+	CircuitBreaker *CircuitBreaker
+
+	// RateLimiter limits the rate of outgoing requests when set.
+	RateLimiter *RateLimiter
+
+	// ResponseCache caches and revalidates the responses of GET requests
+	// when set.
+	ResponseCache *ResponseCache
+
+	// OnRateLimit is called with the rate limiting information of every
+	// response that carries it, so callers can throttle before requests are
+	// rejected.
+	OnRateLimit func(RateLimit)
+
+	// LogRequest is called after each attempt to send a request, with the
+	// access key and other secrets redacted. See WithSlog for logging
+	// requests with log/slog.
+	LogRequest func(context.Context, RequestLog)
+
+	// ObserveRequest is called after each attempt to send a request, to
+	// record metrics.
+	ObserveRequest func(context.Context, RequestMetrics)
+
+	// TraceRequest is called when an API call starts, with its method and
+	// endpoint as described for RequestMetrics. The returned context is used
+	// for all attempts of the call, and end is called with the final status
+	// code and error when it is done. With OpenTelemetry, for example:
+	//
+	//	client.TraceRequest = func(ctx context.Context, method, endpoint string) (context.Context, func(int, error)) {
+	//	    ctx, span := tracer.Start(ctx, method+" "+endpoint, trace.WithSpanKind(trace.SpanKindClient))
+	//	    return ctx, func(status int, err error) {
+	//	        span.SetAttributes(attribute.Int("http.status_code", status))
+	//	        if err != nil {
+	//	            span.SetStatus(codes.Error, err.Error())
+	//	            span.SetAttributes(attribute.String("messagebird.request_id", messagebird.RequestID(err)))
+	//	        }
+	//	        span.End()
+	//	    }
+	//	}
+	//
+	// Spans for each attempt can be added by wrapping the transport with
+	// otelhttp.NewTransport, which also propagates the trace to the API.
+	TraceRequest func(ctx context.Context, method, endpoint string) (spanCtx context.Context, end func(status int, err error))
+
+	// AutoIdempotencyKeys makes the client generate an idempotency key for
+	// each create request that has none set by WithIdempotencyKey, so those
+	// requests can be retried safely.
+	AutoIdempotencyKeys bool
+
+	ctx            context.Context
+	idempotencyKey string
+	middleware     []Middleware
+	timeout        time.Duration
+	response       *Response
+	appInfo        string
+	headers        http.Header
+
+	compressMinSize  int
+	identityEncoding bool
+
+	maxResponseSize int64
+	maxJSONDepth    int
 }
 
 type contentType string
@@ -56,21 +129,100 @@ const (
 )
 
 // New creates a new MessageBird client object.
-func New(accessKey string) *Client {
-	return &Client{
-		AccessKey: accessKey,
-		HTTPClient: &http.Client{
-			Timeout: httpClientTimeout,
-		},
+func New(accessKey string, opts ...Option) *Client {
+	c := &Client{
+		AccessKey:  accessKey,
+		HTTPClient: newHTTPClient(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithContext returns a shallow copy of the client that sends its requests
+// with ctx, so they are canceled when ctx is done:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+//	defer cancel()
+//	msg, err := sms.Read(client.WithContext(ctx), id)
+//
+// The copy shares the HTTP client and other settings with the original.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("messagebird: nil context")
+	}
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Context returns the client's context, which defaults to
+// context.Background. Use WithContext to change it.
+func (c *Client) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
+// WithTimeout returns a shallow copy of the client whose requests time out
+// after d, including any retries, e.g. for latency sensitive calls:
+//
+//	v, err := verify.VerifyToken(client.WithTimeout(2*time.Second), id, token)
+//
+// The timeout applies in addition to the deadline of the client's context and
+// the timeout of its HTTP client.
+func (c *Client) WithTimeout(d time.Duration) *Client {
+	c2 := *c
+	c2.timeout = d
+	return &c2
+}
+
+// SetHeaders sets the default headers of the client's requests on req,
+// including authorization. It is for internal use only and unstable.
+func (c *Client) SetHeaders(req *http.Request) error {
+	for k, v := range c.headers {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	req.Header.Set("User-Agent", c.userAgent())
+
+	if c.TokenSource == nil {
+		req.Header.Set("Authorization", "AccessKey "+c.AccessKey)
+		return nil
+	}
+	token, _, err := c.TokenSource.Token(req.Context())
+	if err != nil {
+		return fmt.Errorf("could not get access token: %w", err)
 	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// userAgent returns the User-Agent header of the client's requests.
+func (c *Client) userAgent() string {
+	ua := "MessageBird/ApiClient/" + ClientVersion + " Go/" + runtime.Version()
+	if c.appInfo != "" {
+		ua += " " + c.appInfo
+	}
+	return ua
 }
 
 // Request is for internal use only and unstable.
 func (c *Client) Request(v interface{}, method, path string, data interface{}) error {
-	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
-		path = fmt.Sprintf("%s/%s", Endpoint, path)
+	return c.RequestContext(c.Context(), v, method, path, data)
+}
+
+// RequestContext is like Request, but sends the request with ctx instead of
+// the client's context. It is for internal use only and unstable.
+func (c *Client) RequestContext(ctx context.Context, v interface{}, method, path string, data interface{}) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
 	}
-	uri, err := url.Parse(path)
+
+	uri, err := c.parseURL(path)
 	if err != nil {
 		return err
 	}
@@ -80,48 +232,91 @@ func (c *Client) Request(v interface{}, method, path string, data interface{}) e
 		return err
 	}
 
-	request, err := http.NewRequest(method, uri.String(), bytes.NewBuffer(body))
+	request, err := http.NewRequestWithContext(ctx, method, uri.String(), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
 
+	if err := c.SetHeaders(request); err != nil {
+		return err
+	}
 	request.Header.Set("Accept", "application/json")
-	request.Header.Set("Authorization", "AccessKey "+c.AccessKey)
-	request.Header.Set("User-Agent", "MessageBird/ApiClient/"+ClientVersion+" Go/"+runtime.Version())
 	if contentType != contentTypeEmpty {
 		request.Header.Set("Content-Type", string(contentType))
 	}
+	if err := c.setIdempotencyKey(request); err != nil {
+		return err
+	}
+	if err := c.compressBody(request, body); err != nil {
+		return err
+	}
 
 	if c.DebugLog != nil {
+		// Bodies are redacted as for LogRequest, so access and signing keys
+		// are not written to the log.
 		if data != nil {
-			c.DebugLog.Printf("HTTP REQUEST: %s %s %s", method, uri.String(), body)
+			c.DebugLog.Printf("HTTP REQUEST: %s %s %s", method, c.redact(uri.String()), c.redact(string(body)))
 		} else {
-			c.DebugLog.Printf("HTTP REQUEST: %s %s", method, uri.String())
+			c.DebugLog.Printf("HTTP REQUEST: %s %s", method, c.redact(uri.String()))
 		}
 	}
 
-	response, err := c.HTTPClient.Do(request)
-	if err != nil {
-		return err
+	var end func(int, error)
+	if c.TraceRequest != nil {
+		var traceCtx context.Context
+		traceCtx, end = c.TraceRequest(request.Context(), method, endpoint(request))
+		request = request.WithContext(traceCtx)
 	}
 
-	defer response.Body.Close()
-
-	responseBody, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return err
+	var (
+		response     *http.Response
+		responseBody []byte
+	)
+	cached, fresh := c.ResponseCache.before(request)
+	if fresh {
+		response, responseBody = cached.response(request, nil), cached.body
+	} else {
+		response, responseBody, err = c.do(request)
+		if err == nil {
+			response, responseBody = c.ResponseCache.after(request, cached, response, responseBody)
+		}
+	}
+	if response != nil && c.response != nil {
+		*c.response = Response{StatusCode: response.StatusCode, Header: response.Header, RequestID: requestID(response.Header)}
+	}
+	if err == nil {
+		if c.DebugLog != nil {
+			if id := requestID(response.Header); id != "" {
+				c.DebugLog.Printf("HTTP RESPONSE (request ID %s): %s", id, c.redact(string(responseBody)))
+			} else {
+				c.DebugLog.Printf("HTTP RESPONSE: %s", c.redact(string(responseBody)))
+			}
+		}
+		if err = c.checkJSONDepth(responseBody); err == nil {
+			err = decodeResponse(v, response, responseBody)
+		}
 	}
 
-	if c.DebugLog != nil {
-		c.DebugLog.Printf("HTTP RESPONSE: %s", string(responseBody))
+	if end != nil {
+		status := 0
+		if response != nil {
+			status = response.StatusCode
+		}
+		end(status, err)
 	}
+	return err
+}
 
+// decodeResponse decodes the body of a successful response into v, or
+// returns the error described by an unsuccessful one.
+func decodeResponse(v interface{}, response *http.Response, responseBody []byte) error {
 	switch response.StatusCode {
-	case http.StatusOK, http.StatusCreated:
-		// Status codes 200 and 201 are indicative of being able to convert the
-		// response body to the struct that was specified.
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted:
+		// Status codes 200, 201 and 202 are indicative of being able to convert
+		// the response body to the struct that was specified. 202 is returned
+		// for requests that are processed asynchronously.
 		if err := json.Unmarshal(responseBody, &v); err != nil {
-			return fmt.Errorf("could not decode response JSON, %s: %v", string(responseBody), err)
+			return fmt.Errorf("could not decode response JSON, %s: %w", string(responseBody), err)
 		}
 
 		return nil
@@ -129,18 +324,8 @@ func (c *Client) Request(v interface{}, method, path string, data interface{}) e
 		// Status code 204 is returned for successful DELETE requests. Don't try to
 		// unmarshal the body: that would return errors.
 		return nil
-	case http.StatusInternalServerError:
-		// Status code 500 is a server error and means nothing can be done at this
-		// point.
-		return ErrUnexpectedResponse
 	default:
-		// Anything else than a 200/201/204/500 should be a JSON error.
-		var errorResponse ErrorResponse
-		if err := json.Unmarshal(responseBody, &errorResponse); err != nil {
-			return err
-		}
-
-		return errorResponse
+		return ResponseError(response, responseBody)
 	}
 }
 
@@ -0,0 +1,57 @@
+package messagebird
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestWithContext(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	err := c.WithContext(ctx).Request(nil, http.MethodGet, ts.URL, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	if c.Context() != context.Background() {
+		t.Errorf("expected WithContext not to modify the original client")
+	}
+}
+
+func TestRequestWithTimeout(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	err := c.WithTimeout(10*time.Millisecond).Request(nil, http.MethodGet, ts.URL, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	if c.timeout != 0 {
+		t.Errorf("expected WithTimeout not to modify the original client")
+	}
+}
@@ -0,0 +1,181 @@
+// Command messagebird calls the MessageBird API from the command line, e.g. to
+// smoke-test an access key or a route to a destination:
+//
+//	export MESSAGEBIRD_ACCESS_KEY=...
+//	messagebird balance
+//	messagebird send-sms -reference smoke-test TestSender 31612345678 "Hello"
+//
+// The client is configured by the environment variables read by
+// messagebird.NewFromEnv. Results are written to stdout as JSON.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/balance"
+	"github.com/messagebird/go-rest-api/hlr"
+	"github.com/messagebird/go-rest-api/lookup"
+	"github.com/messagebird/go-rest-api/number"
+	"github.com/messagebird/go-rest-api/sms"
+	"github.com/messagebird/go-rest-api/verify"
+)
+
+const usage = `Usage: messagebird <command> [flags] [arguments]
+
+Commands:
+  send-sms [-reference ref] [-report-url url] <originator> <recipients> <body>
+  balance
+  verify create [-originator name] [-type sms|tts|flash] <recipient>
+  verify check <id> <token>
+  lookup [-country-code cc] <phone number>
+  hlr create [-reference ref] <msisdn>
+  hlr read <id>
+  number search [-limit n] [-number digits] [-features sms,voice] <country code>
+  number purchase [-billing-interval months] <country code> <number>
+
+Recipients are separated by commas. Run a command with -h for its flags.
+`
+
+// errUsage is returned for invalid command lines.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr, func() (*messagebird.Client, error) {
+		return messagebird.NewFromEnv()
+	})
+	switch {
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "messagebird:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command in args, writing its result to stdout. The client is
+// only created once the command line is valid.
+func run(args []string, stdout, stderr io.Writer, newClient func() (*messagebird.Client, error)) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	cmd, args := args[0], args[1:]
+	if cmd == "verify" || cmd == "hlr" || cmd == "number" {
+		if len(args) == 0 {
+			fmt.Fprint(stderr, usage)
+			return errUsage
+		}
+		cmd, args = cmd+" "+args[0], args[1:]
+	}
+
+	fn, ok := commands[cmd]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", cmd, usage)
+		return errUsage
+	}
+
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	call, nargs := fn(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != nargs {
+		fmt.Fprintf(stderr, "%s expects %d argument(s), got %d\n\n%s", cmd, nargs, fs.NArg(), usage)
+		return errUsage
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	res, err := call(c, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+// commandFunc defines the flags of a command in fs and returns the function
+// calling the API with the positional arguments, and their number.
+type commandFunc func(fs *flag.FlagSet) (func(c *messagebird.Client, args []string) (interface{}, error), int)
+
+var commands = map[string]commandFunc{
+	"send-sms": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		params := &sms.Params{}
+		fs.StringVar(&params.Reference, "reference", "", "client reference of the message")
+		fs.StringVar(&params.ReportURL, "report-url", "", "URL status reports are sent to")
+		return func(c *messagebird.Client, args []string) (interface{}, error) {
+			return sms.Create(c, args[0], strings.Split(args[1], ","), args[2], params)
+		}, 3
+	},
+	"balance": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		return func(c *messagebird.Client, _ []string) (interface{}, error) {
+			return balance.Read(c)
+		}, 0
+	},
+	"verify create": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		params := &verify.Params{}
+		fs.StringVar(&params.Originator, "originator", "", "sender of the token")
+		fs.StringVar(&params.Type, "type", "", "type of the message: sms, tts or flash")
+		return func(c *messagebird.Client, args []string) (interface{}, error) {
+			return verify.Create(c, args[0], params)
+		}, 1
+	},
+	"verify check": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		return func(c *messagebird.Client, args []string) (interface{}, error) {
+			return verify.VerifyToken(c, args[0], args[1])
+		}, 2
+	},
+	"lookup": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		params := &lookup.Params{}
+		fs.StringVar(&params.CountryCode, "country-code", "", "country used to parse numbers in national format, e.g. NL")
+		return func(c *messagebird.Client, args []string) (interface{}, error) {
+			return lookup.Read(c, args[0], params)
+		}, 1
+	},
+	"hlr create": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		reference := fs.String("reference", "", "client reference of the HLR")
+		return func(c *messagebird.Client, args []string) (interface{}, error) {
+			return hlr.Create(c, args[0], *reference)
+		}, 1
+	},
+	"hlr read": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		return func(c *messagebird.Client, args []string) (interface{}, error) {
+			return hlr.Read(c, args[0])
+		}, 1
+	},
+	"number search": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		params := &number.SearchParams{}
+		fs.IntVar(&params.Limit, "limit", 0, "maximum number of results")
+		fs.StringVar(&params.Number, "number", "", "digits the numbers start with")
+		features := fs.String("features", "", "comma separated features the numbers must support, e.g. sms,voice")
+		return func(c *messagebird.Client, args []string) (interface{}, error) {
+			if *features != "" {
+				params.Features = strings.Split(*features, ",")
+			}
+			return number.Search(c, args[0], params)
+		}, 1
+	},
+	"number purchase": func(fs *flag.FlagSet) (func(*messagebird.Client, []string) (interface{}, error), int) {
+		interval := fs.Int("billing-interval", number.BillingMonthly, "billing interval in months: 1, 3 or 12")
+		return func(c *messagebird.Client, args []string) (interface{}, error) {
+			return number.Purchase(c, &number.PurchaseRequest{
+				CountryCode:           args[0],
+				Number:                args[1],
+				BillingIntervalMonths: *interval,
+			})
+		}, 2
+	},
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	err := run(args, &stdout, &stderr, func() (*messagebird.Client, error) {
+		return mbtest.Client(t), nil
+	})
+	return stdout.String(), err
+}
+
+func TestBalance(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"payment":"prepaid","type":"credits","amount":9.2}`), http.StatusOK)
+
+	out, err := runCommand(t, "balance")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/balance")
+	var b struct{ Amount float64 }
+	if err := json.Unmarshal([]byte(out), &b); err != nil || b.Amount != 9.2 {
+		t.Errorf("got output %q, expected the balance as JSON", out)
+	}
+}
+
+func TestSendSMS(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"6fe65f90454aa61536e6a88b88972670","body":"Hello"}`), http.StatusCreated)
+
+	if _, err := runCommand(t, "send-sms", "-reference", "smoke-test", "TestSender", "31612345678,31687654321", "Hello"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/messages")
+	var req struct {
+		Originator string
+		Recipients []string
+		Reference  string
+	}
+	if err := json.Unmarshal(mbtest.Request.Body, &req); err != nil {
+		t.Fatalf("unexpected error decoding request: %s", err)
+	}
+	if req.Originator != "TestSender" || len(req.Recipients) != 2 || req.Reference != "smoke-test" {
+		t.Errorf("got request %+v, expected originator, two recipients and reference", req)
+	}
+}
+
+func TestVerifyCheck(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"a3f2edb23592d68163f7812q4312475","status":"verified"}`), http.StatusOK)
+
+	out, err := runCommand(t, "verify", "check", "a3f2edb23592d68163f7812q4312475", "123456")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/verify/a3f2edb23592d68163f7812q4312475")
+	if mbtest.Request.URL.RawQuery != "token=123456" {
+		t.Errorf("got query %q, expected token=123456", mbtest.Request.URL.RawQuery)
+	}
+	if !strings.Contains(out, `"verified"`) {
+		t.Errorf("got output %q, expected the verified status", out)
+	}
+}
+
+func TestNumberSearch(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"items":[{"number":"3197010260188","country":"NL"}],"limit":1,"count":1}`), http.StatusOK)
+
+	if _, err := runCommand(t, "number", "search", "-limit", "1", "-features", "sms,voice", "NL"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/available-phone-numbers/NL")
+	if q := mbtest.Request.URL.Query(); q.Get("limit") != "1" || len(q["features"]) != 2 {
+		t.Errorf("got query %q, expected limit and two features", mbtest.Request.URL.RawQuery)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var cases = []struct {
+		name string
+		args []string
+		e    error
+	}{
+		{name: "No command", args: nil, e: errUsage},
+		{name: "Unknown command", args: []string{"send-mms"}, e: errUsage},
+		{name: "Missing subcommand", args: []string{"verify"}, e: errUsage},
+		{name: "Missing arguments", args: []string{"send-sms", "TestSender"}, e: errUsage},
+		{name: "Client error", args: []string{"balance"}, e: errNoKey},
+	}
+
+	for _, tt := range cases {
+		var stdout, stderr bytes.Buffer
+		err := run(tt.args, &stdout, &stderr, func() (*messagebird.Client, error) {
+			return nil, errNoKey
+		})
+		if !errors.Is(err, tt.e) {
+			t.Errorf("got %v, expected %v, test case: %s", err, tt.e, tt.name)
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("got output %q, expected none, test case: %s", stdout.String(), tt.name)
+		}
+	}
+}
+
+var errNoKey = errors.New(messagebird.EnvAccessKey + " is not set")
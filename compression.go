@@ -0,0 +1,71 @@
+package messagebird
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// WithCompression makes the client gzip request bodies of at least minSize
+// bytes, e.g. messages with thousands of recipients. Responses are
+// compressed by default, see WithoutResponseCompression.
+func WithCompression(minSize int) Option {
+	return func(c *Client) {
+		c.compressMinSize = minSize
+	}
+}
+
+// WithoutResponseCompression makes the client ask for uncompressed
+// responses, e.g. when a proxy inspecting traffic can not handle gzip.
+func WithoutResponseCompression() Option {
+	return func(c *Client) {
+		c.identityEncoding = true
+	}
+}
+
+// compressBody gzips req's body if the client compresses bodies of its size.
+func (c *Client) compressBody(req *http.Request, body []byte) error {
+	if c.identityEncoding {
+		// A request for identity encoding disables transparent
+		// decompression by net/http, which is fine as none is done.
+		req.Header.Set("Accept-Encoding", "identity")
+	}
+	if c.compressMinSize <= 0 || len(body) < c.compressMinSize {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	b := buf.Bytes()
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	req.ContentLength = int64(len(b))
+	req.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
+
+// decompressed returns the uncompressed request body b.
+func decompressed(req *http.Request, b []byte) []byte {
+	if req.Header.Get("Content-Encoding") != "gzip" {
+		return b
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return b
+	}
+	if d, err := ioutil.ReadAll(zr); err == nil {
+		return d
+	}
+	return b
+}
@@ -0,0 +1,78 @@
+package messagebird
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithCompression(t *testing.T) {
+	var encodings, bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		var b []byte
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("unexpected error reading gzip body: %s", err)
+				return
+			}
+			b, _ = ioutil.ReadAll(zr)
+		} else {
+			b, _ = ioutil.ReadAll(r.Body)
+		}
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	var logged string
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithCompression(100))
+	c.LogRequest = func(ctx context.Context, rl RequestLog) { logged = rl.RequestBody }
+
+	large := strings.Repeat("31612345678,", 20)
+	for _, body := range []string{"small", large} {
+		if err := c.Request(nil, http.MethodPost, ts.URL, body); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if encodings[0] != "" || encodings[1] != "gzip" {
+		t.Errorf("got content encodings %q, expected only the large body to be compressed", encodings)
+	}
+	if bodies[0] != "small" || bodies[1] != large {
+		t.Errorf("got bodies %q, expected them to arrive intact", bodies)
+	}
+	if logged != large {
+		t.Errorf("got logged body %q, expected the uncompressed body", logged)
+	}
+}
+
+func TestWithoutResponseCompression(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Accept-Encoding")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	if err := c.Request(nil, http.MethodGet, ts.URL, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "gzip" {
+		t.Errorf("got Accept-Encoding %q, expected gzip by default", got)
+	}
+
+	c = New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithoutResponseCompression())
+	if err := c.Request(nil, http.MethodGet, ts.URL, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "identity" {
+		t.Errorf("got Accept-Encoding %q, expected identity", got)
+	}
+}
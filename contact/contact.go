@@ -1,6 +1,7 @@
 package contact
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -68,6 +69,7 @@ var DefaultListOptions = &ListOptions{
 	Offset: 0,
 }
 
+// Create creates a new contact. The MSISDN of contactRequest is required.
 func Create(c *messagebird.Client, contactRequest *Request) (*Contact, error) {
 	if err := validateCreate(contactRequest); err != nil {
 		return nil, err
@@ -100,8 +102,11 @@ func Delete(c *messagebird.Client, id string) error {
 }
 
 // List retrieves a paginated list of contacts, based on the options provided.
-// It's worth noting DefaultListOptions.
+// DefaultListOptions is used when options is nil.
 func List(c *messagebird.Client, options *ListOptions) (*ContactList, error) {
+	if options == nil {
+		options = DefaultListOptions
+	}
 	query, err := listQuery(options)
 	if err != nil {
 		return nil, err
@@ -115,6 +120,42 @@ func List(c *messagebird.Client, options *ListOptions) (*ContactList, error) {
 	return contactList, nil
 }
 
+// Iterate returns an iterator over all contacts, fetching them in pages of
+// options.Limit contacts. DefaultListOptions is used when options is nil.
+func Iterate(c *messagebird.Client, options *ListOptions) *messagebird.Iterator[Contact] {
+	if options == nil {
+		options = DefaultListOptions
+	}
+	opts := *options
+	return messagebird.NewOffsetIterator(opts.Offset, func(ctx context.Context, offset int) ([]Contact, int, error) {
+		opts.Offset = offset
+		list, err := List(c.WithContext(ctx), &opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
+// IterateConcurrent returns an iterator over all contacts like Iterate,
+// which fetches up to concurrency pages at the same time. Use it to export
+// large numbers of contacts.
+func IterateConcurrent(c *messagebird.Client, options *ListOptions, concurrency int) *messagebird.Iterator[Contact] {
+	if options == nil {
+		options = DefaultListOptions
+	}
+	opts := *options
+	return messagebird.NewConcurrentOffsetIterator(opts.Offset, concurrency, func(ctx context.Context, offset int) ([]Contact, int, error) {
+		o := opts
+		o.Offset = offset
+		list, err := List(c.WithContext(ctx), &o)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
 func listQuery(options *ListOptions) (string, error) {
 	if options.Limit < 10 {
 		return "", fmt.Errorf("minimum limit is 10, got %d", options.Limit)
@@ -1,6 +1,7 @@
 package contact
 
 import (
+	"context"
 	"net/http"
 	"testing"
 	"time"
@@ -126,6 +127,26 @@ func TestList(t *testing.T) {
 	mbtest.AssertEndpointCalled(t, http.MethodGet, "/contacts")
 }
 
+func TestIterate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "contactListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	var ids []string
+	it := Iterate(client, nil)
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error iterating contacts: %s", err)
+	}
+
+	if len(ids) != 2 || ids[0] != "first-id" || ids[1] != "second-id" {
+		t.Fatalf("expected [first-id second-id], got %v", ids)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/contacts")
+}
+
 func TestListPagination(t *testing.T) {
 	client := mbtest.Client(t)
 
@@ -134,6 +155,7 @@ func TestListPagination(t *testing.T) {
 		options  *ListOptions
 	}{
 		{"limit=20&offset=0", DefaultListOptions},
+		{"limit=20&offset=0", nil},
 		{"limit=10&offset=25", &ListOptions{10, 25}},
 		{"limit=50&offset=10", &ListOptions{50, 10}},
 	}
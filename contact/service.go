@@ -0,0 +1,42 @@
+package contact
+
+import messagebird "github.com/messagebird/go-rest-api"
+
+// Service is the contacts API. It is implemented by NewService, and by
+// fake.Contacts for unit tests of code using the API.
+type Service interface {
+	Create(contactRequest *Request) (*Contact, error)
+	Delete(id string) error
+	List(options *ListOptions) (*ContactList, error)
+	Read(id string) (*Contact, error)
+	Update(id string, contactRequest *Request) (*Contact, error)
+}
+
+// NewService returns a Service sending its requests with c.
+func NewService(c *messagebird.Client) Service {
+	return service{c: c}
+}
+
+type service struct {
+	c *messagebird.Client
+}
+
+func (s service) Create(contactRequest *Request) (*Contact, error) {
+	return Create(s.c, contactRequest)
+}
+
+func (s service) Delete(id string) error {
+	return Delete(s.c, id)
+}
+
+func (s service) List(options *ListOptions) (*ContactList, error) {
+	return List(s.c, options)
+}
+
+func (s service) Read(id string) (*Contact, error) {
+	return Read(s.c, id)
+}
+
+func (s service) Update(id string, contactRequest *Request) (*Contact, error) {
+	return Update(s.c, id, contactRequest)
+}
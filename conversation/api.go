@@ -14,7 +14,7 @@ const (
 	// apiRoot is the absolute URL of the Converstations API. All paths are
 	// relative to apiRoot (e.g.
 	// https://conversations.messagebird.com/v1/webhooks).
-	apiRoot = "https://conversations.messagebird.com/v1"
+	apiRoot = messagebird.ConversationsEndpoint + "/v1"
 
 	// path is the path for the Conversation resource, relative to apiRoot.
 	path = "conversations"
@@ -25,6 +25,9 @@ const (
 
 	// webhooksPath is the path for the Webhook resource, relative to apiRoot.
 	webhooksPath = "webhooks"
+
+	// channelsPath is the path for the Channel resource, relative to apiRoot.
+	channelsPath = "channels"
 )
 
 type ConversationList struct {
@@ -59,6 +62,14 @@ type Contact struct {
 	UpdatedAt     *time.Time
 }
 
+type ChannelList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*Channel
+}
+
 type Channel struct {
 	ID              string
 	Name            string
@@ -68,6 +79,24 @@ type Channel struct {
 	UpdatedDatetime *time.Time
 }
 
+// The platforms a Channel can be on, as set in Channel.PlatformID.
+const (
+	PlatformSMS       = "sms"
+	PlatformWhatsApp  = "whatsapp"
+	PlatformMessenger = "facebook"
+	PlatformTelegram  = "telegram"
+	PlatformLine      = "line"
+	PlatformWeChat    = "wechat"
+	PlatformEmail     = "email"
+)
+
+// The statuses of a Channel. Only active channels can send messages.
+const (
+	ChannelStatusActive   = "active"
+	ChannelStatusInactive = "inactive"
+	ChannelStatusPending  = "pending"
+)
+
 type MessagesCount struct {
 	HRef       string
 	TotalCount int
@@ -99,6 +128,9 @@ type Message struct {
 	ID              string
 	ConversationID  string
 	ChannelID       string
+	Platform        string
+	To              string
+	From            string
 	Direction       MessageDirection
 	Status          MessageStatus
 	Type            MessageType
@@ -138,13 +170,14 @@ const (
 type MessageType string
 
 const (
-	MessageTypeAudio    MessageType = "audio"
-	MessageTypeFile     MessageType = "file"
-	MessageTypeHSM      MessageType = "hsm"
-	MessageTypeImage    MessageType = "image"
-	MessageTypeLocation MessageType = "location"
-	MessageTypeText     MessageType = "text"
-	MessageTypeVideo    MessageType = "video"
+	MessageTypeAudio       MessageType = "audio"
+	MessageTypeFile        MessageType = "file"
+	MessageTypeHSM         MessageType = "hsm"
+	MessageTypeImage       MessageType = "image"
+	MessageTypeInteractive MessageType = "interactive"
+	MessageTypeLocation    MessageType = "location"
+	MessageTypeText        MessageType = "text"
+	MessageTypeVideo       MessageType = "video"
 )
 
 // MessageContent holds a message's actual content. Only one field can be set
@@ -157,11 +190,41 @@ type MessageContent struct {
 	Video    *Video    `json:"video,omitempty"`
 	Text     string    `json:"text,omitempty"`
 
+	// Interactive is a WhatsApp message with buttons or a list, or the reply
+	// to one.
+	Interactive *Interactive `json:"interactive,omitempty"`
+
 	// HSM is a highly structured message for WhatsApp. Its definition lives in
 	// hsm.go.
 	HSM *HSM `json:"hsm,omitempty"`
 }
 
+// hasType reports whether the content set is the one expected for messages of
+// type t. Only the field for that type may be set.
+func (mc *MessageContent) hasType(t MessageType) bool {
+	set := map[MessageType]bool{
+		MessageTypeAudio:       mc.Audio != nil,
+		MessageTypeFile:        mc.File != nil,
+		MessageTypeHSM:         mc.HSM != nil,
+		MessageTypeImage:       mc.Image != nil,
+		MessageTypeInteractive: mc.Interactive != nil,
+		MessageTypeLocation:    mc.Location != nil,
+		MessageTypeText:        mc.Text != "",
+		MessageTypeVideo:       mc.Video != nil,
+	}
+
+	if !set[t] {
+		return false
+	}
+	for typ, ok := range set {
+		if ok && typ != t {
+			return false
+		}
+	}
+
+	return true
+}
+
 type Media struct {
 	URL string `json:"url"`
 }
@@ -171,6 +234,82 @@ type File Media
 type Image Media
 type Video Media
 
+// InteractiveType indicates what kind of interactive message an Interactive
+// is.
+type InteractiveType string
+
+const (
+	InteractiveTypeButton      InteractiveType = "button"
+	InteractiveTypeList        InteractiveType = "list"
+	InteractiveTypeCTAURL      InteractiveType = "cta_url"
+	InteractiveTypeButtonReply InteractiveType = "button_reply"
+	InteractiveTypeListReply   InteractiveType = "list_reply"
+)
+
+// Interactive is a WhatsApp message with reply buttons, a list or a
+// call-to-action URL, or the reply of a contact to one. Messages are built
+// with NewButtons, NewList and NewCTAURL, which live in interactive.go.
+type Interactive struct {
+	Type   InteractiveType    `json:"type"`
+	Header *InteractiveHeader `json:"header,omitempty"`
+	Body   *InteractiveText   `json:"body,omitempty"`
+	Footer *InteractiveText   `json:"footer,omitempty"`
+	Action *InteractiveAction `json:"action,omitempty"`
+	Reply  *InteractiveReply  `json:"reply,omitempty"` // Replies only.
+}
+
+type InteractiveHeader struct {
+	Type  string `json:"type"` // E.g. "text" or "image".
+	Text  string `json:"text,omitempty"`
+	Image *Media `json:"image,omitempty"`
+}
+
+type InteractiveText struct {
+	Text string `json:"text"`
+}
+
+// InteractiveAction holds the buttons of button messages, the sections of
+// list messages, or the URL of call-to-action messages.
+type InteractiveAction struct {
+	Button   string                `json:"button,omitempty"` // Opens the list.
+	Buttons  []*InteractiveButton  `json:"buttons,omitempty"`
+	Sections []*InteractiveSection `json:"sections,omitempty"`
+
+	Name       string             `json:"name,omitempty"` // "cta_url" for call-to-action messages.
+	Parameters *InteractiveCTAURL `json:"parameters,omitempty"`
+}
+
+// InteractiveCTAURL is the button of a call-to-action message, which opens
+// URL.
+type InteractiveCTAURL struct {
+	DisplayText string `json:"display_text"`
+	URL         string `json:"url"`
+}
+
+type InteractiveButton struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"` // E.g. "reply".
+	Title string `json:"title"`
+}
+
+type InteractiveSection struct {
+	Title string            `json:"title,omitempty"`
+	Rows  []*InteractiveRow `json:"rows"`
+}
+
+type InteractiveRow struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// InteractiveReply identifies the button or row the contact chose.
+type InteractiveReply struct {
+	ID          string `json:"id"`
+	Text        string `json:"text"`
+	Description string `json:"description,omitempty"`
+}
+
 type Location struct {
 	Latitude  float32 `json:"latitude"`
 	Longitude float32 `json:"longitude"`
@@ -190,10 +329,31 @@ type Webhook struct {
 	Events          []WebhookEvent
 	URL             string
 	Status          WebhookStatus
+	Settings        *WebhookSettings
 	CreatedDatetime *time.Time
 	UpdatedDatetime *time.Time
 }
 
+// WebhookSettings configures how a webhook is delivered. Zero values use the
+// API's defaults.
+type WebhookSettings struct {
+	// ExpectedHTTPSuccessCodes are the status codes that acknowledge the
+	// webhook. Any 2xx status is accepted by default.
+	ExpectedHTTPSuccessCodes []int `json:"expected_http_success_codes,omitempty"`
+
+	// Headers and QueryParams are added to each webhook request, e.g. to
+	// authenticate it. QueryParams is an encoded query string.
+	Headers     map[string]string `json:"headers,omitempty"`
+	QueryParams string            `json:"query_params,omitempty"`
+
+	// Username and Password set basic authentication.
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+
+	Retry   int `json:"retry,omitempty"`   // Attempts, at most 10.
+	Timeout int `json:"timeout,omitempty"` // In seconds, at most 10.
+}
+
 type WebhookEvent string
 
 const (
@@ -222,7 +382,12 @@ func request(c *messagebird.Client, v interface{}, method, path string, data int
 }
 
 // paginationQuery builds the query string for paginated endpoints.
+// DefaultListOptions is used when options is nil.
 func paginationQuery(options *ListOptions) string {
+	if options == nil {
+		options = DefaultListOptions
+	}
+
 	query := url.Values{}
 	query.Set("limit", strconv.Itoa(options.Limit))
 	query.Set("offset", strconv.Itoa(options.Offset))
@@ -0,0 +1,48 @@
+package conversation
+
+import (
+	"context"
+	"net/http"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// ListChannels gets a collection of the channels configured for the account.
+// Pagination can be set in options.
+func ListChannels(c *messagebird.Client, options *ListOptions) (*ChannelList, error) {
+	query := paginationQuery(options)
+
+	channelList := &ChannelList{}
+	if err := request(c, channelList, http.MethodGet, channelsPath+"?"+query, nil); err != nil {
+		return nil, err
+	}
+
+	return channelList, nil
+}
+
+// IterateChannels returns an iterator over all channels. DefaultListOptions is
+// used when options is nil.
+func IterateChannels(c *messagebird.Client, options *ListOptions) *messagebird.Iterator[*Channel] {
+	if options == nil {
+		options = DefaultListOptions
+	}
+	opts := *options
+	return messagebird.NewOffsetIterator(opts.Offset, func(ctx context.Context, offset int) ([]*Channel, int, error) {
+		opts.Offset = offset
+		list, err := ListChannels(c.WithContext(ctx), &opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
+// ReadChannel gets a single channel based on its ID.
+func ReadChannel(c *messagebird.Client, id string) (*Channel, error) {
+	channel := &Channel{}
+	if err := request(c, channel, http.MethodGet, channelsPath+"/"+id, nil); err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
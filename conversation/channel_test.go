@@ -0,0 +1,48 @@
+package conversation
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestListChannels(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "channelListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	channelList, err := ListChannels(client, DefaultListOptions)
+	if err != nil {
+		t.Fatalf("unexpected error listing Channels: %s", err)
+	}
+
+	if channelList.TotalCount != 1 {
+		t.Fatalf("got %d, expected 1", channelList.TotalCount)
+	}
+
+	if channelList.Items[0].PlatformID != PlatformWhatsApp {
+		t.Fatalf("got %s, expected whatsapp", channelList.Items[0].PlatformID)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/channels")
+
+	if query := mbtest.Request.URL.RawQuery; query != "limit=10&offset=0" {
+		t.Fatalf("got %s, expected limit=10&offset=0", query)
+	}
+}
+
+func TestReadChannel(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "channelObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	channel, err := ReadChannel(client, "chid")
+	if err != nil {
+		t.Fatalf("unexpected error reading Channel: %s", err)
+	}
+
+	if channel.Status != ChannelStatusActive {
+		t.Fatalf("got %s, expected active", channel.Status)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/channels/chid")
+}
@@ -1,7 +1,9 @@
 package conversation
 
 import (
+	"context"
 	"net/http"
+	"net/url"
 
 	messagebird "github.com/messagebird/go-rest-api"
 )
@@ -17,6 +19,7 @@ type StartRequest struct {
 	Content   *MessageContent `json:"content"`
 	To        string          `json:"to"`
 	Type      MessageType     `json:"type"`
+	Fallback  *Fallback       `json:"fallback,omitempty"`
 }
 
 // UpdateRequest contains the request data for the Update endpoint.
@@ -39,6 +42,36 @@ func List(c *messagebird.Client, options *ListOptions) (*ConversationList, error
 	return convList, nil
 }
 
+// ListWithStatus is like List, but only gets the Conversations with the given
+// status.
+func ListWithStatus(c *messagebird.Client, status ConversationStatus, options *ListOptions) (*ConversationList, error) {
+	query := paginationQuery(options) + "&status=" + url.QueryEscape(string(status))
+
+	convList := &ConversationList{}
+	if err := request(c, convList, http.MethodGet, path+"?"+query, nil); err != nil {
+		return nil, err
+	}
+
+	return convList, nil
+}
+
+// Iterate returns an iterator over all Conversations. DefaultListOptions is
+// used when options is nil.
+func Iterate(c *messagebird.Client, options *ListOptions) *messagebird.Iterator[*Conversation] {
+	if options == nil {
+		options = DefaultListOptions
+	}
+	opts := *options
+	return messagebird.NewOffsetIterator(opts.Offset, func(ctx context.Context, offset int) ([]*Conversation, int, error) {
+		opts.Offset = offset
+		list, err := List(c.WithContext(ctx), &opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
 // Read fetches a single Conversation based on its ID.
 func Read(c *messagebird.Client, id string) (*Conversation, error) {
 	conv := &Conversation{}
@@ -52,6 +85,15 @@ func Read(c *messagebird.Client, id string) (*Conversation, error) {
 // Start creates a conversation by sending an initial message. If an active
 // conversation exists for the recipient, it is resumed.
 func Start(c *messagebird.Client, req *StartRequest) (*Conversation, error) {
+	if err := validateInteractive(req.Content); err != nil {
+		return nil, err
+	}
+	if req.Fallback != nil {
+		if err := req.Fallback.validate(req.ChannelID); err != nil {
+			return nil, err
+		}
+	}
+
 	conv := &Conversation{}
 	if err := request(c, conv, http.MethodPost, path+"/start", req); err != nil {
 		return nil, err
@@ -70,3 +112,14 @@ func Update(c *messagebird.Client, id string, req *UpdateRequest) (*Conversation
 
 	return conv, nil
 }
+
+// Archive archives the conversation. A new conversation is started when the
+// contact sends a message afterwards.
+func Archive(c *messagebird.Client, id string) (*Conversation, error) {
+	return Update(c, id, &UpdateRequest{Status: ConversationStatusArchived})
+}
+
+// Unarchive makes an archived conversation active again.
+func Unarchive(c *messagebird.Client, id string) (*Conversation, error) {
+	return Update(c, id, &UpdateRequest{Status: ConversationStatusActive})
+}
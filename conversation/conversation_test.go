@@ -204,3 +204,30 @@ func TestUpdate(t *testing.T) {
 	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/v1/conversations/id")
 	mbtest.AssertTestdata(t, "conversationUpdateRequest.json", mbtest.Request.Body)
 }
+
+func TestListWithStatus(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "conversationListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := ListWithStatus(client, ConversationStatusArchived, nil); err != nil {
+		t.Fatalf("unexpected error listing Conversations: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/conversations")
+
+	if query := mbtest.Request.URL.RawQuery; query != "limit=10&offset=0&status=archived" {
+		t.Fatalf("got %s, expected limit=10&offset=0&status=archived", query)
+	}
+}
+
+func TestArchive(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "conversationUpdatedObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := Archive(client, "id"); err != nil {
+		t.Fatalf("unexpected error archiving Conversation: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/v1/conversations/id")
+	mbtest.AssertTestdata(t, "conversationUpdateRequest.json", mbtest.Request.Body)
+}
@@ -1,6 +1,9 @@
 package conversation
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // HSM is a pre-approved, reusable message template required when messaging
 // over WhatsApp. It allows you to just send the required parameter values
@@ -12,6 +15,11 @@ type HSM struct {
 	TemplateName          string                     `json:"templateName"`
 	Language              *HSMLanguage               `json:"language"`
 	LocalizableParameters []*HSMLocalizableParameter `json:"params"`
+
+	// Components holds the parameters of templates with a header, body or
+	// buttons, e.g. those with media headers. Templates use either these or
+	// LocalizableParameters.
+	Components []*HSMComponent `json:"components,omitempty"`
 }
 
 // HSMLanguage is used to set the message's locale.
@@ -56,6 +64,121 @@ type HSMLocalizableParameterCurrency struct {
 	Amount int64 `json:"amount"`
 }
 
+// HSMComponentType is the part of the template an HSMComponent fills in.
+type HSMComponentType string
+
+const (
+	HSMComponentTypeHeader HSMComponentType = "header"
+	HSMComponentTypeBody   HSMComponentType = "body"
+	HSMComponentTypeButton HSMComponentType = "button"
+)
+
+// HSMButtonSubType is the kind of button an HSMComponent of type button fills
+// in.
+type HSMButtonSubType string
+
+const (
+	HSMButtonSubTypeQuickReply HSMButtonSubType = "quick_reply"
+	HSMButtonSubTypeURL        HSMButtonSubType = "url"
+)
+
+// HSMComponentParameterType indicates which field of an
+// HSMComponentParameter is set.
+type HSMComponentParameterType string
+
+const (
+	HSMComponentParameterTypeText     HSMComponentParameterType = "text"
+	HSMComponentParameterTypeCurrency HSMComponentParameterType = "currency"
+	HSMComponentParameterTypeDateTime HSMComponentParameterType = "date_time"
+	HSMComponentParameterTypeImage    HSMComponentParameterType = "image"
+	HSMComponentParameterTypeDocument HSMComponentParameterType = "document"
+	HSMComponentParameterTypeVideo    HSMComponentParameterType = "video"
+	HSMComponentParameterTypePayload  HSMComponentParameterType = "payload"
+)
+
+// HSMComponent holds the parameters for a part of the template. SubType and
+// Index are only used for buttons, where Index is the position of the button
+// in the template, starting at 0.
+type HSMComponent struct {
+	Type       HSMComponentType         `json:"type"`
+	SubType    HSMButtonSubType         `json:"sub_type,omitempty"`
+	Index      *int                     `json:"index,omitempty"`
+	Parameters []*HSMComponentParameter `json:"parameters,omitempty"`
+}
+
+// HSMComponentParameter replaces a placeholder in a component of the
+// template. Only the field matching Type may be set.
+type HSMComponentParameter struct {
+	Type     HSMComponentParameterType        `json:"type"`
+	Text     string                           `json:"text,omitempty"`
+	Payload  string                           `json:"payload,omitempty"`
+	Currency *HSMLocalizableParameterCurrency `json:"currency,omitempty"`
+	DateTime *time.Time                       `json:"dateTime,omitempty"`
+	Image    *Media                           `json:"image,omitempty"`
+	Document *Media                           `json:"document,omitempty"`
+	Video    *Media                           `json:"video,omitempty"`
+}
+
+// HeaderHSMComponent gets a header component with the given parameters, e.g.
+// a MediaHSMParameter for templates with a media header.
+func HeaderHSMComponent(params ...*HSMComponentParameter) *HSMComponent {
+	return &HSMComponent{Type: HSMComponentTypeHeader, Parameters: params}
+}
+
+// BodyHSMComponent gets a body component with the given parameters.
+func BodyHSMComponent(params ...*HSMComponentParameter) *HSMComponent {
+	return &HSMComponent{Type: HSMComponentTypeBody, Parameters: params}
+}
+
+// QuickReplyHSMComponent gets a component that sets the payload returned when
+// the quick reply button at index is pressed.
+func QuickReplyHSMComponent(index int, payload string) *HSMComponent {
+	return &HSMComponent{
+		Type:    HSMComponentTypeButton,
+		SubType: HSMButtonSubTypeQuickReply,
+		Index:   &index,
+		Parameters: []*HSMComponentParameter{
+			{Type: HSMComponentParameterTypePayload, Payload: payload},
+		},
+	}
+}
+
+// URLButtonHSMComponent gets a component that sets the dynamic suffix of the
+// URL of the button at index.
+func URLButtonHSMComponent(index int, suffix string) *HSMComponent {
+	return &HSMComponent{
+		Type:    HSMComponentTypeButton,
+		SubType: HSMButtonSubTypeURL,
+		Index:   &index,
+		Parameters: []*HSMComponentParameter{
+			{Type: HSMComponentParameterTypeText, Text: suffix},
+		},
+	}
+}
+
+// TextHSMParameter gets a parameter that replaces a placeholder with text.
+func TextHSMParameter(text string) *HSMComponentParameter {
+	return &HSMComponentParameter{Type: HSMComponentParameterTypeText, Text: text}
+}
+
+// MediaHSMParameter gets a parameter for a media header. Type must be
+// HSMComponentParameterTypeImage, HSMComponentParameterTypeDocument or
+// HSMComponentParameterTypeVideo.
+func MediaHSMParameter(typ HSMComponentParameterType, url string) (*HSMComponentParameter, error) {
+	p := &HSMComponentParameter{Type: typ}
+	switch typ {
+	case HSMComponentParameterTypeImage:
+		p.Image = &Media{URL: url}
+	case HSMComponentParameterTypeDocument:
+		p.Document = &Media{URL: url}
+	case HSMComponentParameterTypeVideo:
+		p.Video = &Media{URL: url}
+	default:
+		return nil, errors.New("type must be image, document or video")
+	}
+	return p, nil
+}
+
 // DefaultLocalizableHSMParameter gets a simple parameter with a default value
 // that will do a simple string replacement.
 func DefaultLocalizableHSMParameter(d string) *HSMLocalizableParameter {
@@ -1,9 +1,12 @@
 package conversation
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
 )
 
 func TestLocalizableParameter(t *testing.T) {
@@ -50,3 +53,38 @@ func TestLocalizableParameter(t *testing.T) {
 		})
 	}
 }
+
+func TestHSMComponentsJSON(t *testing.T) {
+	header, err := MediaHSMParameter(HSMComponentParameterTypeImage, "https://example.com/package.jpg")
+	if err != nil {
+		t.Fatalf("unexpected error creating media parameter: %s", err)
+	}
+	hsm := &HSM{
+		Namespace:    "ns",
+		TemplateName: "order_shipped",
+		Language: &HSMLanguage{
+			Policy: HSMLanguagePolicyDeterministic,
+			Code:   "en",
+		},
+		Components: []*HSMComponent{
+			HeaderHSMComponent(header),
+			BodyHSMComponent(TextHSMParameter("Jane")),
+			QuickReplyHSMComponent(0, "track"),
+		},
+	}
+
+	b, err := json.Marshal(hsm)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling HSM: %s", err)
+	}
+
+	mbtest.AssertTestdata(t, "hsmComponentsRequest.json", b)
+}
+
+func TestMediaHSMParameterInvalidType(t *testing.T) {
+	for _, typ := range []HSMComponentParameterType{HSMComponentParameterTypeText, ""} {
+		if p, err := MediaHSMParameter(typ, "https://example.com/package.jpg"); err == nil {
+			t.Errorf("got parameter %+v for type %q, expected an error", p, typ)
+		}
+	}
+}
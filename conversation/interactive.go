@@ -0,0 +1,275 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+)
+
+// InteractiveButtonTypeReply is the type of reply buttons, the only buttons
+// of button messages.
+const InteractiveButtonTypeReply = "reply"
+
+// The limits of WhatsApp on interactive messages, in characters.
+const (
+	maxInteractiveBody        = 1024
+	maxInteractiveHeader      = 60
+	maxInteractiveFooter      = 60
+	maxInteractiveButtons     = 3
+	maxInteractiveButtonTitle = 20
+	maxInteractiveButtonID    = 256
+	maxInteractiveRows        = 10
+	maxInteractiveSections    = 10
+	maxInteractiveRowTitle    = 24
+	maxInteractiveRowDesc     = 72
+	maxInteractiveRowID       = 200
+)
+
+// NewButtons gets a message with body and up to 3 reply buttons:
+//
+//	content := &conversation.MessageContent{Interactive: conversation.NewButtons(
+//		"Was your delivery on time?",
+//		conversation.ReplyButton("yes", "Yes"),
+//		conversation.ReplyButton("no", "No"),
+//	)}
+//
+// The contact's reply is a message of type InteractiveTypeButtonReply with
+// the ID of the button; see Message.InteractiveReply.
+func NewButtons(body string, buttons ...*InteractiveButton) *Interactive {
+	return &Interactive{
+		Type:   InteractiveTypeButton,
+		Body:   &InteractiveText{Text: body},
+		Action: &InteractiveAction{Buttons: buttons},
+	}
+}
+
+// ReplyButton gets a button with the title shown to the contact, and the ID
+// returned when it is pressed.
+func ReplyButton(id, title string) *InteractiveButton {
+	return &InteractiveButton{ID: id, Type: InteractiveButtonTypeReply, Title: title}
+}
+
+// NewList gets a message with body and a button, which opens a list of the
+// rows in sections. There are at most 10 rows in total.
+func NewList(body, button string, sections ...*InteractiveSection) *Interactive {
+	return &Interactive{
+		Type:   InteractiveTypeList,
+		Body:   &InteractiveText{Text: body},
+		Action: &InteractiveAction{Button: button, Sections: sections},
+	}
+}
+
+// ListSection gets a section of a list. The title is required when the list
+// has more than one section.
+func ListSection(title string, rows ...*InteractiveRow) *InteractiveSection {
+	return &InteractiveSection{Title: title, Rows: rows}
+}
+
+// ListRow gets a row of a list, with the ID returned when it is chosen. The
+// description is optional.
+func ListRow(id, title, description string) *InteractiveRow {
+	return &InteractiveRow{ID: id, Title: title, Description: description}
+}
+
+// NewCTAURL gets a message with body and a button with displayText, which
+// opens rawURL.
+func NewCTAURL(body, displayText, rawURL string) *Interactive {
+	return &Interactive{
+		Type: InteractiveTypeCTAURL,
+		Body: &InteractiveText{Text: body},
+		Action: &InteractiveAction{
+			Name:       string(InteractiveTypeCTAURL),
+			Parameters: &InteractiveCTAURL{DisplayText: displayText, URL: rawURL},
+		},
+	}
+}
+
+// WithTextHeader sets a header with text above the body.
+func (i *Interactive) WithTextHeader(text string) *Interactive {
+	i.Header = &InteractiveHeader{Type: "text", Text: text}
+	return i
+}
+
+// WithImageHeader sets a header with the image at rawURL above the body.
+// List messages only support text headers.
+func (i *Interactive) WithImageHeader(rawURL string) *Interactive {
+	i.Header = &InteractiveHeader{Type: "image", Image: &Media{URL: rawURL}}
+	return i
+}
+
+// WithFooter sets text below the body.
+func (i *Interactive) WithFooter(text string) *Interactive {
+	i.Footer = &InteractiveText{Text: text}
+	return i
+}
+
+// Validate checks the message against the limits of WhatsApp, so it is not
+// rejected after it was accepted by the API. Replies are not validated.
+func (i *Interactive) Validate() error {
+	switch i.Type {
+	case InteractiveTypeButtonReply, InteractiveTypeListReply:
+		return nil
+	case InteractiveTypeButton, InteractiveTypeList, InteractiveTypeCTAURL:
+	default:
+		return fmt.Errorf("unknown interactive type %q", i.Type)
+	}
+
+	if i.Body == nil || i.Body.Text == "" {
+		return errors.New("interactive body is required")
+	}
+	if err := maxLength("body", i.Body.Text, maxInteractiveBody); err != nil {
+		return err
+	}
+	if i.Footer != nil {
+		if err := maxLength("footer", i.Footer.Text, maxInteractiveFooter); err != nil {
+			return err
+		}
+	}
+	if h := i.Header; h != nil {
+		switch {
+		case h.Type == "text":
+			if err := maxLength("header", h.Text, maxInteractiveHeader); err != nil {
+				return err
+			}
+		case i.Type == InteractiveTypeList:
+			return errors.New("list messages only support text headers")
+		case h.Type == "image":
+			if h.Image == nil || h.Image.URL == "" {
+				return errors.New("header image URL is required")
+			}
+		default:
+			return fmt.Errorf("unknown header type %q", h.Type)
+		}
+	}
+	if i.Action == nil {
+		return errors.New("interactive action is required")
+	}
+
+	switch i.Type {
+	case InteractiveTypeButton:
+		return validateButtons(i.Action.Buttons)
+	case InteractiveTypeList:
+		return validateList(i.Action)
+	default:
+		p := i.Action.Parameters
+		if i.Action.Name != string(InteractiveTypeCTAURL) || p == nil {
+			return errors.New("call-to-action URL is required")
+		}
+		if p.DisplayText == "" {
+			return errors.New("call-to-action display text is required")
+		}
+		if err := maxLength("display text", p.DisplayText, maxInteractiveButtonTitle); err != nil {
+			return err
+		}
+		if u, err := url.Parse(p.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("call-to-action URL must be an HTTP(S) URL, got %q", p.URL)
+		}
+		return nil
+	}
+}
+
+func validateButtons(buttons []*InteractiveButton) error {
+	if len(buttons) == 0 || len(buttons) > maxInteractiveButtons {
+		return fmt.Errorf("button messages have 1 to %d buttons, got %d", maxInteractiveButtons, len(buttons))
+	}
+
+	ids := make(map[string]bool)
+	titles := make(map[string]bool)
+	for _, b := range buttons {
+		if b.ID == "" || b.Title == "" {
+			return errors.New("button ID and title are required")
+		}
+		if b.Type != InteractiveButtonTypeReply {
+			return fmt.Errorf("button %q: unknown type %q", b.ID, b.Type)
+		}
+		if err := maxLength("button ID", b.ID, maxInteractiveButtonID); err != nil {
+			return err
+		}
+		if err := maxLength("button title", b.Title, maxInteractiveButtonTitle); err != nil {
+			return err
+		}
+		if ids[b.ID] || titles[b.Title] {
+			return fmt.Errorf("button %q: IDs and titles must be unique", b.ID)
+		}
+		ids[b.ID], titles[b.Title] = true, true
+	}
+	return nil
+}
+
+func validateList(action *InteractiveAction) error {
+	if action.Button == "" {
+		return errors.New("list button is required")
+	}
+	if err := maxLength("list button", action.Button, maxInteractiveButtonTitle); err != nil {
+		return err
+	}
+	if len(action.Sections) == 0 || len(action.Sections) > maxInteractiveSections {
+		return fmt.Errorf("lists have 1 to %d sections, got %d", maxInteractiveSections, len(action.Sections))
+	}
+
+	rows := 0
+	ids := make(map[string]bool)
+	for _, s := range action.Sections {
+		if len(action.Sections) > 1 && s.Title == "" {
+			return errors.New("section titles are required in lists with more than one section")
+		}
+		if err := maxLength("section title", s.Title, maxInteractiveRowTitle); err != nil {
+			return err
+		}
+		if len(s.Rows) == 0 {
+			return fmt.Errorf("section %q has no rows", s.Title)
+		}
+		for _, r := range s.Rows {
+			if r.ID == "" || r.Title == "" {
+				return errors.New("row ID and title are required")
+			}
+			if err := maxLength("row ID", r.ID, maxInteractiveRowID); err != nil {
+				return err
+			}
+			if err := maxLength("row title", r.Title, maxInteractiveRowTitle); err != nil {
+				return err
+			}
+			if err := maxLength("row description", r.Description, maxInteractiveRowDesc); err != nil {
+				return err
+			}
+			if ids[r.ID] {
+				return fmt.Errorf("row %q: IDs must be unique", r.ID)
+			}
+			ids[r.ID] = true
+			rows++
+		}
+	}
+	if rows > maxInteractiveRows {
+		return fmt.Errorf("lists have at most %d rows, got %d", maxInteractiveRows, rows)
+	}
+	return nil
+}
+
+// validateInteractive validates the interactive message of content, if any.
+func validateInteractive(content *MessageContent) error {
+	if content == nil || content.Interactive == nil {
+		return nil
+	}
+	return content.Interactive.Validate()
+}
+
+func maxLength(name, s string, max int) error {
+	if n := utf8.RuneCountInString(s); n > max {
+		return fmt.Errorf("%s has %d characters, at most %d are allowed", name, n, max)
+	}
+	return nil
+}
+
+// InteractiveReply returns the button or row the contact chose, if the
+// message is the reply to an interactive message.
+func (m *Message) InteractiveReply() (*InteractiveReply, bool) {
+	i := m.Content.Interactive
+	if i == nil || i.Reply == nil {
+		return nil, false
+	}
+	if i.Type != InteractiveTypeButtonReply && i.Type != InteractiveTypeListReply {
+		return nil, false
+	}
+	return i.Reply, true
+}
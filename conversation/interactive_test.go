@@ -0,0 +1,111 @@
+package conversation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInteractiveJSON(t *testing.T) {
+	var cases = []struct {
+		name        string
+		interactive *Interactive
+		expected    string
+	}{
+		{
+			name:        "Buttons",
+			interactive: NewButtons("On time?", ReplyButton("yes", "Yes"), ReplyButton("no", "No")).WithFooter("Acme"),
+			expected:    `{"type":"button","body":{"text":"On time?"},"footer":{"text":"Acme"},"action":{"buttons":[{"id":"yes","type":"reply","title":"Yes"},{"id":"no","type":"reply","title":"No"}]}}`,
+		},
+		{
+			name:        "List",
+			interactive: NewList("Pick a slot", "Slots", ListSection("Monday", ListRow("mon-9", "09:00", "Morning"))).WithTextHeader("Delivery"),
+			expected:    `{"type":"list","header":{"type":"text","text":"Delivery"},"body":{"text":"Pick a slot"},"action":{"button":"Slots","sections":[{"title":"Monday","rows":[{"id":"mon-9","title":"09:00","description":"Morning"}]}]}}`,
+		},
+		{
+			name:        "CTA URL",
+			interactive: NewCTAURL("Track your parcel", "Track", "https://example.com/track").WithImageHeader("https://example.com/parcel.png"),
+			expected:    `{"type":"cta_url","header":{"type":"image","image":{"url":"https://example.com/parcel.png"}},"body":{"text":"Track your parcel"},"action":{"name":"cta_url","parameters":{"display_text":"Track","url":"https://example.com/track"}}}`,
+		},
+	}
+
+	for _, tt := range cases {
+		if err := tt.interactive.Validate(); err != nil {
+			t.Errorf("unexpected error: %s, test case: %s", err, tt.name)
+		}
+		b, err := json.Marshal(tt.interactive)
+		if err != nil {
+			t.Fatalf("unexpected error: %s, test case: %s", err, tt.name)
+		}
+		if string(b) != tt.expected {
+			t.Errorf("got %s, expected %s, test case: %s", b, tt.expected, tt.name)
+		}
+	}
+}
+
+func TestInteractiveValidate(t *testing.T) {
+	rows := make([]*InteractiveRow, 11)
+	for i := range rows {
+		rows[i] = ListRow(strings.Repeat("r", i+1), "Row", "")
+	}
+
+	var cases = []struct {
+		name        string
+		interactive *Interactive
+		e           string
+	}{
+		{"Unknown type", &Interactive{Type: "carousel"}, "unknown interactive type"},
+		{"No body", NewButtons("", ReplyButton("a", "A")), "body is required"},
+		{"Long body", NewButtons(strings.Repeat("é", 1025), ReplyButton("a", "A")), "body has 1025 characters"},
+		{"No buttons", NewButtons("Body"), "1 to 3 buttons, got 0"},
+		{"Too many buttons", NewButtons("Body", ReplyButton("a", "A"), ReplyButton("b", "B"), ReplyButton("c", "C"), ReplyButton("d", "D")), "got 4"},
+		{"Long button title", NewButtons("Body", ReplyButton("a", strings.Repeat("x", 21))), "button title has 21 characters"},
+		{"Duplicate button", NewButtons("Body", ReplyButton("a", "A"), ReplyButton("a", "B")), "unique"},
+		{"Long footer", NewButtons("Body", ReplyButton("a", "A")).WithFooter(strings.Repeat("x", 61)), "footer has 61"},
+		{"List image header", NewList("Body", "Open", ListSection("", ListRow("a", "A", ""))).WithImageHeader("https://example.com/a.png"), "only support text headers"},
+		{"List without button", NewList("Body", "", ListSection("", ListRow("a", "A", ""))), "list button is required"},
+		{"Untitled sections", NewList("Body", "Open", ListSection("", ListRow("a", "A", "")), ListSection("B", ListRow("b", "B", ""))), "section titles are required"},
+		{"Too many rows", NewList("Body", "Open", ListSection("", rows...)), "at most 10 rows, got 11"},
+		{"Duplicate row", NewList("Body", "Open", ListSection("", ListRow("a", "A", ""), ListRow("a", "B", ""))), "unique"},
+		{"Long description", NewList("Body", "Open", ListSection("", ListRow("a", "A", strings.Repeat("x", 73)))), "row description has 73"},
+		{"CTA without text", NewCTAURL("Body", "", "https://example.com"), "display text is required"},
+		{"CTA relative URL", NewCTAURL("Body", "Open", "/track"), "HTTP(S) URL"},
+	}
+
+	for _, tt := range cases {
+		err := tt.interactive.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.e) {
+			t.Errorf("got %v, expected an error containing %q, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+func TestMessageInteractiveReply(t *testing.T) {
+	var m Message
+	data := `{"type":"interactive","content":{"interactive":{"type":"list_reply","reply":{"id":"mon-9","text":"09:00","description":"Morning"}}}}`
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	reply, ok := m.InteractiveReply()
+	if !ok || reply.ID != "mon-9" || reply.Text != "09:00" {
+		t.Errorf("got reply %+v, expected the mon-9 row", reply)
+	}
+
+	m.Content = MessageContent{Interactive: NewButtons("Body", ReplyButton("a", "A"))}
+	if _, ok := m.InteractiveReply(); ok {
+		t.Errorf("expected no reply for a button message")
+	}
+}
+
+func TestSendInvalidInteractive(t *testing.T) {
+	req := &SendRequest{
+		To:      "31612345678",
+		From:    "chid",
+		Type:    MessageTypeInteractive,
+		Content: &MessageContent{Interactive: NewButtons("Body")},
+	}
+	if _, err := Send(nil, req); err == nil {
+		t.Fatalf("expected an error sending an invalid interactive message")
+	}
+}
@@ -1,8 +1,11 @@
 package conversation
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	messagebird "github.com/messagebird/go-rest-api"
 )
@@ -11,11 +14,100 @@ type MessageCreateRequest struct {
 	ChannelID string          `json:"channelid"`
 	Content   *MessageContent `json:"content"`
 	Type      MessageType     `json:"type"`
+	Fallback  *Fallback       `json:"fallback,omitempty"`
+}
+
+// Fallback configures a message to be sent over another channel when the
+// primary channel fails to deliver, e.g. SMS when WhatsApp is unavailable.
+type Fallback struct {
+	ChannelID string          `json:"channelId"`
+	Content   *MessageContent `json:"content"`
+	Type      MessageType     `json:"type"`
+
+	// AfterDatetime triggers the fallback if the message has not been
+	// delivered over the primary channel at this time. The fallback is only
+	// triggered by failures when it's not set.
+	AfterDatetime *time.Time `json:"afterDatetime,omitempty"`
+}
+
+// validate checks the fallback can be sent in place of a message on the
+// primary channel with ID channelID.
+func (f *Fallback) validate(channelID string) error {
+	if f.ChannelID == "" {
+		return errors.New("fallback channel ID is required")
+	}
+	if f.ChannelID == channelID {
+		return errors.New("fallback channel must differ from the primary channel")
+	}
+	if f.Content == nil {
+		return errors.New("fallback content is required")
+	}
+
+	if !f.Content.hasType(f.Type) {
+		return fmt.Errorf("fallback content does not match type %s", f.Type)
+	}
+
+	return nil
+}
+
+// SendRequest contains the request data for the Send endpoint.
+type SendRequest struct {
+	// To is the recipient's identifier on the channel's platform, e.g. a
+	// phone number for SMS and WhatsApp.
+	To string `json:"to"`
+
+	// From is the ID of the channel to send the message over.
+	From      string          `json:"from"`
+	Type      MessageType     `json:"type"`
+	Content   *MessageContent `json:"content"`
+	ReportURL string          `json:"reportUrl,omitempty"`
+	Fallback  *Fallback       `json:"fallback,omitempty"`
+}
+
+// SendResponse is returned by Send. The message is sent asynchronously, so
+// only its ID and initial status are known.
+type SendResponse struct {
+	ID       string
+	Status   string
+	Fallback *SendResponse
+}
+
+// Send sends a message directly to a contact over a channel. The message is
+// added to the contact's active conversation, which is created if there is
+// none.
+func Send(c *messagebird.Client, req *SendRequest) (*SendResponse, error) {
+	if req.To == "" || req.From == "" {
+		return nil, errors.New("to and from are required")
+	}
+	if err := validateInteractive(req.Content); err != nil {
+		return nil, err
+	}
+	if req.Fallback != nil {
+		if err := req.Fallback.validate(req.From); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &SendResponse{}
+	if err := request(c, resp, http.MethodPost, "send", req); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
 }
 
 // CreateMessage sends a new message to the specified conversation. To create a
 // new conversation and send an initial message, use conversation.Start().
 func CreateMessage(c *messagebird.Client, conversationID string, req *MessageCreateRequest) (*Message, error) {
+	if err := validateInteractive(req.Content); err != nil {
+		return nil, err
+	}
+	if req.Fallback != nil {
+		if err := req.Fallback.validate(req.ChannelID); err != nil {
+			return nil, err
+		}
+	}
+
 	uri := fmt.Sprintf("%s/%s/%s", path, conversationID, messagesPath)
 
 	message := &Message{}
@@ -27,7 +119,7 @@ func CreateMessage(c *messagebird.Client, conversationID string, req *MessageCre
 }
 
 // ListMessages gets a collection of messages from a conversation. Pagination
-// can be set in the options.
+// can be set in the options, or DefaultListOptions is used if they are nil.
 func ListMessages(c *messagebird.Client, conversationID string, options *ListOptions) (*MessageList, error) {
 	query := paginationQuery(options)
 	uri := fmt.Sprintf("%s/%s/%s?%s", path, conversationID, messagesPath, query)
@@ -40,7 +132,25 @@ func ListMessages(c *messagebird.Client, conversationID string, options *ListOpt
 	return messageList, nil
 }
 
-// ReadMessage gets a single message based on its ID.
+// IterateMessages returns an iterator over all messages of a conversation.
+// DefaultListOptions is used when options is nil.
+func IterateMessages(c *messagebird.Client, conversationID string, options *ListOptions) *messagebird.Iterator[*Message] {
+	if options == nil {
+		options = DefaultListOptions
+	}
+	opts := *options
+	return messagebird.NewOffsetIterator(opts.Offset, func(ctx context.Context, offset int) ([]*Message, int, error) {
+		opts.Offset = offset
+		list, err := ListMessages(c.WithContext(ctx), conversationID, &opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
+// ReadMessage gets a single message based on its ID, which includes its
+// delivery status.
 func ReadMessage(c *messagebird.Client, messageID string) (*Message, error) {
 	message := &Message{}
 	if err := request(c, message, http.MethodGet, messagesPath+"/"+messageID, nil); err != nil {
@@ -3,6 +3,7 @@ package conversation
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/messagebird/go-rest-api/internal/mbtest"
 )
@@ -30,6 +31,52 @@ func TestCreateMessage(t *testing.T) {
 	mbtest.AssertTestdata(t, "messageCreateRequest.json", mbtest.Request.Body)
 }
 
+func TestSend(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "sendResponse.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	resp, err := Send(client, &SendRequest{
+		To:   "31612345678",
+		From: "chid",
+		Type: MessageTypeText,
+		Content: &MessageContent{
+			Text: "Hello world",
+		},
+		ReportURL: "https://example.com/status",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error sending Message: %s", err)
+	}
+
+	if resp.ID != "mesid" || resp.Status != "accepted" {
+		t.Fatalf("got %s with status %s, expected mesid with status accepted", resp.ID, resp.Status)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/send")
+	mbtest.AssertTestdata(t, "sendRequest.json", mbtest.Request.Body)
+}
+
+func TestSendWithoutRecipient(t *testing.T) {
+	client := mbtest.Client(t)
+
+	if _, err := Send(client, &SendRequest{From: "chid", Type: MessageTypeText, Content: &MessageContent{Text: "Hello world"}}); err == nil {
+		t.Fatal("expected error sending Message without recipient, got nil")
+	}
+}
+
+func TestListMessagesWithoutOptions(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := ListMessages(client, "convid", nil); err != nil {
+		t.Fatalf("unexpected error listing Messages: %s", err)
+	}
+
+	if query := mbtest.Request.URL.RawQuery; query != "limit=10&offset=0" {
+		t.Fatalf("got %s, expected limit=10&offset=0", query)
+	}
+}
+
 func TestListMessages(t *testing.T) {
 	mbtest.WillReturnTestdata(t, "messageListObject.json", http.StatusOK)
 	client := mbtest.Client(t)
@@ -77,3 +124,79 @@ func TestReadMessage(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/messages/mesid")
 }
+
+func TestCreateMessageWithFallback(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	after := time.Date(2018, 8, 24, 9, 49, 1, 0, time.UTC)
+
+	_, err := CreateMessage(client, "convid", &MessageCreateRequest{
+		ChannelID: "chid",
+		Content: &MessageContent{
+			HSM: &HSM{
+				Namespace:    "ns",
+				TemplateName: "template",
+				Language: &HSMLanguage{
+					Policy: HSMLanguagePolicyDeterministic,
+					Code:   "en",
+				},
+			},
+		},
+		Type: MessageTypeHSM,
+		Fallback: &Fallback{
+			ChannelID: "smschid",
+			Content: &MessageContent{
+				Text: "Hello world",
+			},
+			Type:          MessageTypeText,
+			AfterDatetime: &after,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating Message: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/conversations/convid/messages")
+	mbtest.AssertTestdata(t, "messageCreateFallbackRequest.json", mbtest.Request.Body)
+}
+
+func TestCreateMessageWithInvalidFallback(t *testing.T) {
+	client := mbtest.Client(t)
+
+	tt := []struct {
+		name     string
+		fallback *Fallback
+	}{
+		{
+			name:     "missing channel",
+			fallback: &Fallback{Content: &MessageContent{Text: "Hello"}, Type: MessageTypeText},
+		},
+		{
+			name:     "same channel",
+			fallback: &Fallback{ChannelID: "chid", Content: &MessageContent{Text: "Hello"}, Type: MessageTypeText},
+		},
+		{
+			name:     "missing content",
+			fallback: &Fallback{ChannelID: "smschid", Type: MessageTypeText},
+		},
+		{
+			name:     "content does not match type",
+			fallback: &Fallback{ChannelID: "smschid", Content: &MessageContent{Text: "Hello"}, Type: MessageTypeImage},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := CreateMessage(client, "convid", &MessageCreateRequest{
+				ChannelID: "chid",
+				Content:   &MessageContent{Text: "Hello"},
+				Type:      MessageTypeText,
+				Fallback:  tc.fallback,
+			})
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
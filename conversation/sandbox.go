@@ -0,0 +1,57 @@
+package conversation
+
+import (
+	"errors"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// ErrNoSandboxChannel is returned by SandboxChannel when the account has no
+// WhatsApp sandbox channel, e.g. because the client does not use the
+// sandbox.
+var ErrNoSandboxChannel = errors.New("conversation: no WhatsApp sandbox channel")
+
+// SandboxChannel returns the WhatsApp channel of the sandbox. The client
+// must be created with messagebird.WithWhatsAppSandbox:
+//
+//	client := messagebird.New(accessKey, messagebird.WithWhatsAppSandbox())
+//	channel, err := conversation.SandboxChannel(client)
+//
+// Only numbers that joined the sandbox, by sending the code shown in the
+// MessageBird dashboard to the sandbox number, receive its messages.
+func SandboxChannel(c *messagebird.Client) (*Channel, error) {
+	if c.Endpoints.Conversations != messagebird.WhatsAppSandboxEndpoint {
+		return nil, ErrNoSandboxChannel
+	}
+
+	it := IterateChannels(c, nil)
+	for it.Next(c.Context()) {
+		if ch := it.Value(); ch.PlatformID == PlatformWhatsApp {
+			return ch, nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return nil, ErrNoSandboxChannel
+}
+
+// SendSandboxText sends a text message to a number that joined the WhatsApp
+// sandbox, over the sandbox channel. Unlike production WhatsApp channels,
+// the sandbox does not require an approved template to start a
+// conversation, so no HSM is needed.
+func SendSandboxText(c *messagebird.Client, to, text string) (*SendResponse, error) {
+	if text == "" {
+		return nil, errors.New("text is required")
+	}
+	channel, err := SandboxChannel(c)
+	if err != nil {
+		return nil, err
+	}
+	return Send(c, &SendRequest{
+		To:      to,
+		From:    channel.ID,
+		Type:    MessageTypeText,
+		Content: &MessageContent{Text: text},
+	})
+}
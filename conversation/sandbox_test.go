@@ -0,0 +1,86 @@
+package conversation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestSandboxChannel(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "channelListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := SandboxChannel(client); err != ErrNoSandboxChannel {
+		t.Fatalf("got %v without the sandbox, expected %v", err, ErrNoSandboxChannel)
+	}
+
+	messagebird.WithWhatsAppSandbox()(client)
+	channel, err := SandboxChannel(client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if channel.ID != "chid" {
+		t.Errorf("got channel %s, expected chid", channel.ID)
+	}
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/channels")
+}
+
+// hostTransport sends all requests to the server at u, keeping their path.
+type hostTransport struct {
+	u     *url.URL
+	hosts []string
+}
+
+func (rt *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.hosts = append(rt.hosts, req.URL.Host)
+	req.URL.Scheme, req.URL.Host = rt.u.Scheme, rt.u.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSendSandboxText(t *testing.T) {
+	var sent map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/channels":
+			w.Write([]byte(`{"totalCount":2,"items":[{"id":"sms","platformId":"sms"},{"id":"sandbox","platformId":"whatsapp"}]}`))
+		case "/v1/send":
+			b, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &sent); err != nil {
+				t.Errorf("unexpected request body %s", b)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":"msg-1","status":"accepted"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	rt := &hostTransport{u: u}
+	client := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithWhatsAppSandbox(), messagebird.WithTransport(rt))
+
+	resp, err := SendSandboxText(client, "31612345678", "Hello from the sandbox")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.ID != "msg-1" {
+		t.Errorf("got message %s, expected msg-1", resp.ID)
+	}
+	if sent["from"] != "sandbox" || sent["type"] != "text" || sent["to"] != "31612345678" {
+		t.Errorf("got request %v, expected a text message from the sandbox channel", sent)
+	}
+	for _, host := range rt.hosts {
+		if host != "whatsapp-sandbox.messagebird.com" {
+			t.Errorf("got request to %s, expected the sandbox", host)
+		}
+	}
+}
@@ -1,26 +1,37 @@
 package conversation
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	messagebird "github.com/messagebird/go-rest-api"
 )
 
 type WebhookCreateRequest struct {
-	ChannelID string         `json:"channelId"`
-	Events    []WebhookEvent `json:"events"`
-	URL       string         `json:"url"`
+	ChannelID string           `json:"channelId"`
+	Events    []WebhookEvent   `json:"events"`
+	URL       string           `json:"url"`
+	Settings  *WebhookSettings `json:"settings,omitempty"`
 }
 
 type WebhookUpdateRequest struct {
-	Events []WebhookEvent `json:"events,omitempty"`
-	URL    string         `json:"url,omitempty"`
-	Status WebhookStatus  `json:"status,omitempty"`
+	Events   []WebhookEvent   `json:"events,omitempty"`
+	URL      string           `json:"url,omitempty"`
+	Status   WebhookStatus    `json:"status,omitempty"`
+	Settings *WebhookSettings `json:"settings,omitempty"`
 }
 
 // CreateWebhook registers a webhook that is invoked when something interesting
 // happens.
 func CreateWebhook(c *messagebird.Client, req *WebhookCreateRequest) (*Webhook, error) {
+	if req.ChannelID == "" || req.URL == "" {
+		return nil, errors.New("channel ID and URL are required")
+	}
+	if len(req.Events) == 0 {
+		return nil, errors.New("at least one event is required")
+	}
+
 	webhook := &Webhook{}
 	if err := request(c, webhook, http.MethodPost, webhooksPath, req); err != nil {
 		return nil, err
@@ -47,6 +58,23 @@ func ListWebhooks(c *messagebird.Client, options *ListOptions) (*WebhookList, er
 	return webhookList, nil
 }
 
+// IterateWebhooks returns an iterator over all webhooks. DefaultListOptions is
+// used when options is nil.
+func IterateWebhooks(c *messagebird.Client, options *ListOptions) *messagebird.Iterator[*Webhook] {
+	if options == nil {
+		options = DefaultListOptions
+	}
+	opts := *options
+	return messagebird.NewOffsetIterator(opts.Offset, func(ctx context.Context, offset int) ([]*Webhook, int, error) {
+		opts.Offset = offset
+		list, err := ListWebhooks(c.WithContext(ctx), &opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
 // ReadWebhook gets a single webhook based on its ID.
 func ReadWebhook(c *messagebird.Client, id string) (*Webhook, error) {
 	webhook := &Webhook{}
@@ -31,6 +31,46 @@ func TestCreateWebhook(t *testing.T) {
 	mbtest.AssertTestdata(t, "webhookCreateRequest.json", mbtest.Request.Body)
 }
 
+func TestCreateWebhookWithSettings(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "webhookObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	_, err := CreateWebhook(client, &WebhookCreateRequest{
+		ChannelID: "chid",
+		Events:    []WebhookEvent{WebhookEventMessageCreated},
+		URL:       "https://example.com/webhooks",
+		Settings: &WebhookSettings{
+			Headers: map[string]string{"X-Environment": "staging"},
+			Retry:   3,
+			Timeout: 5,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating Webhook: %s", err)
+	}
+
+	mbtest.AssertTestdata(t, "webhookCreateSettingsRequest.json", mbtest.Request.Body)
+}
+
+func TestCreateWebhookInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	var cases = []struct {
+		name string
+		req  *WebhookCreateRequest
+	}{
+		{name: "Missing channel", req: &WebhookCreateRequest{Events: []WebhookEvent{WebhookEventMessageCreated}, URL: "https://example.com/webhooks"}},
+		{name: "Missing URL", req: &WebhookCreateRequest{ChannelID: "chid", Events: []WebhookEvent{WebhookEventMessageCreated}}},
+		{name: "Missing events", req: &WebhookCreateRequest{ChannelID: "chid", URL: "https://example.com/webhooks"}},
+	}
+
+	for _, tt := range cases {
+		if _, err := CreateWebhook(client, tt.req); err == nil {
+			t.Errorf("expected error creating Webhook, got nil, test case: %s", tt.name)
+		}
+	}
+}
+
 func TestDeleteWebhook(t *testing.T) {
 	mbtest.WillReturn([]byte(""), http.StatusNoContent)
 	client := mbtest.Client(t)
@@ -0,0 +1,228 @@
+package messagebird
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalScale is the maximum number of decimals a Decimal holds.
+const maxDecimalScale = 18
+
+// Decimal is an exact decimal number, used for monetary amounts such as
+// balances, prices and costs, which can not be represented exactly as floats.
+// It is decoded from JSON numbers and strings alike, and keeps the number of
+// decimals it was written with. The zero value is 0.
+//
+// A Decimal holds up to 18 digits. Add, Sub and MinorUnits report false if
+// their result does not fit, which does not happen for amounts returned by
+// the API.
+type Decimal struct {
+	unscaled int64
+	scale    int
+}
+
+// NewDecimal returns unscaled * 10^-scale, e.g. NewDecimal(75, 3) is 0.075.
+// It panics if a negative scale makes the number too large for a Decimal.
+func NewDecimal(unscaled int64, scale int) Decimal {
+	if scale >= 0 {
+		return Decimal{unscaled: unscaled, scale: scale}
+	}
+	for ; scale < 0; scale++ {
+		if unscaled > math.MaxInt64/10 || unscaled < math.MinInt64/10 {
+			panic(errDecimalOverflow)
+		}
+		unscaled *= 10
+	}
+	return Decimal{unscaled: unscaled}
+}
+
+// ParseDecimal parses a decimal number such as "9.2" or "-0.060000".
+// Exponents are not supported.
+func ParseDecimal(s string) (Decimal, error) {
+	digits, neg := s, false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		digits, neg = s[1:], s[0] == '-'
+	}
+
+	whole, frac := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		whole, frac = digits[:i], digits[i+1:]
+	}
+	if whole == "" && frac == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if len(frac) > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal %q has more than %d decimals", s, maxDecimalScale)
+	}
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+	}
+
+	unscaled, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("decimal %q out of range", s)
+	}
+	if neg {
+		unscaled = -unscaled
+	}
+	return Decimal{unscaled: unscaled, scale: len(frac)}, nil
+}
+
+// String formats d with the number of decimals it was created with.
+func (d Decimal) String() string {
+	s := strconv.FormatInt(d.unscaled, 10)
+	if d.scale == 0 {
+		return s
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if len(s) <= d.scale {
+		s = strings.Repeat("0", d.scale-len(s)+1) + s
+	}
+	s = s[:len(s)-d.scale] + "." + s[len(s)-d.scale:]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Float64 returns d as a float, e.g. for display. Use the Decimal itself for
+// calculations.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MinorUnits returns d in minor units with the given number of decimals,
+// e.g. cents for 2. It reports false if d has more significant decimals, as
+// they would be lost, or if the amount in minor units overflows an int64.
+func (d Decimal) MinorUnits(decimals int) (int64, bool) {
+	r, ok := d.rescale(decimals)
+	if !ok {
+		return 0, false
+	}
+	return r.unscaled, r.Cmp(d) == 0
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.unscaled == 0
+}
+
+// Add returns d + o, with the larger number of decimals of both. It reports
+// false if the sum does not fit in a Decimal.
+func (d Decimal) Add(o Decimal) (Decimal, bool) {
+	a, b, ok := align(d, o)
+	if !ok {
+		return Decimal{}, false
+	}
+	sum := a.unscaled + b.unscaled
+	if (b.unscaled > 0 && sum < a.unscaled) || (b.unscaled < 0 && sum > a.unscaled) {
+		return Decimal{}, false
+	}
+	return Decimal{unscaled: sum, scale: a.scale}, true
+}
+
+// Sub returns d - o, with the larger number of decimals of both. It reports
+// false if the difference does not fit in a Decimal.
+func (d Decimal) Sub(o Decimal) (Decimal, bool) {
+	if o.unscaled == math.MinInt64 {
+		return Decimal{}, false
+	}
+	return d.Add(Decimal{unscaled: -o.unscaled, scale: o.scale})
+}
+
+// Cmp compares d and o, returning -1, 0 or +1 if d is respectively less
+// than, equal to or greater than o.
+func (d Decimal) Cmp(o Decimal) int {
+	if d.scale == o.scale {
+		return big.NewInt(d.unscaled).Cmp(big.NewInt(o.unscaled))
+	}
+	return d.bigAt(o.scale).Cmp(o.bigAt(d.scale))
+}
+
+// bigAt returns the unscaled value of d with the larger of its scale and
+// scale as a big.Int.
+func (d Decimal) bigAt(scale int) *big.Int {
+	n := big.NewInt(d.unscaled)
+	if scale > d.scale {
+		exp := big.NewInt(int64(scale - d.scale))
+		n.Mul(n, exp.Exp(big.NewInt(10), exp, nil))
+	}
+	return n
+}
+
+// MarshalJSON implements json.Marshaler, encoding d as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts JSON numbers and
+// strings containing a number; null and the empty string are 0.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	s := string(data)
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		if s = s[1 : len(s)-1]; s == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid decimal %s", data)
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// errDecimalOverflow is raised by NewDecimal for numbers that do not fit.
+var errDecimalOverflow = errors.New("messagebird: decimal overflow")
+
+// rescale returns d with scale decimals, truncating extra decimals. It
+// reports false if d does not fit with scale decimals.
+func (d Decimal) rescale(scale int) (Decimal, bool) {
+	for d.scale < scale {
+		if d.unscaled > math.MaxInt64/10 || d.unscaled < math.MinInt64/10 {
+			return Decimal{}, false
+		}
+		d.unscaled *= 10
+		d.scale++
+	}
+	for d.scale > scale {
+		d.unscaled /= 10
+		d.scale--
+	}
+	return d, true
+}
+
+// align returns a and b with the same scale, and reports false if one of
+// them does not fit with the decimals of the other.
+func align(a, b Decimal) (Decimal, Decimal, bool) {
+	if a.scale < b.scale {
+		a, ok := a.rescale(b.scale)
+		return a, b, ok
+	}
+	b, ok := b.rescale(a.scale)
+	return a, b, ok
+}
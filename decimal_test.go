@@ -0,0 +1,170 @@
+package messagebird
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	var cases = []struct {
+		name string
+		in   string
+		e    string
+	}{
+		{name: "Integer", in: "9", e: "9"},
+		{name: "Decimals", in: "9.2", e: "9.2"},
+		{name: "Trailing zeros", in: "0.075000", e: "0.075000"},
+		{name: "Negative", in: "-0.06", e: "-0.06"},
+		{name: "Explicit sign", in: "+1.5", e: "1.5"},
+		{name: "No leading digit", in: ".5", e: "0.5"},
+	}
+
+	for _, tt := range cases {
+		d, err := ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("unexpected error: %s, test case: %s", err, tt.name)
+			continue
+		}
+		if d.String() != tt.e {
+			t.Errorf("got %s, expected %s, test case: %s", d, tt.e, tt.name)
+		}
+	}
+}
+
+func TestParseDecimalError(t *testing.T) {
+	for _, in := range []string{"", "-", ".", "1.2.3", "1e3", "abc", "0.1234567890123456789", "99999999999999999999"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("expected error parsing %q", in)
+		}
+	}
+}
+
+func TestNewDecimal(t *testing.T) {
+	if d := NewDecimal(75, 3); d.String() != "0.075" {
+		t.Errorf("got %s, expected 0.075", d)
+	}
+	if d := NewDecimal(-5, 2); d.String() != "-0.05" {
+		t.Errorf("got %s, expected -0.05", d)
+	}
+	if d := NewDecimal(12, -2); d.String() != "1200" {
+		t.Errorf("got %s, expected 1200", d)
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := NewDecimal(1, 1), NewDecimal(2, 1)
+	if s, ok := a.Add(b); !ok || s.String() != "0.3" {
+		t.Errorf("got 0.1 + 0.2 = %s, %t, expected 0.3", s, ok)
+	}
+	if d, ok := NewDecimal(92, 1).Sub(NewDecimal(75, 3)); !ok || d.String() != "9.125" {
+		t.Errorf("got 9.2 - 0.075 = %s, %t, expected 9.125", d, ok)
+	}
+
+	var cases = []struct {
+		name string
+		a, b Decimal
+		e    int
+	}{
+		{name: "Less", a: NewDecimal(7, 2), b: NewDecimal(75, 3), e: -1},
+		{name: "Equal with different decimals", a: NewDecimal(75, 3), b: NewDecimal(75000, 6), e: 0},
+		{name: "Greater", a: NewDecimal(1, 0), b: NewDecimal(-1, 0), e: 1},
+		{name: "Greater without common decimals", a: mustParseDecimal(t, "10"), b: mustParseDecimal(t, "0.000000000000000001"), e: 1},
+		{name: "Less without common decimals", a: mustParseDecimal(t, "-922337203685477580.7"), b: mustParseDecimal(t, "0.000000000000000001"), e: -1},
+	}
+
+	for _, tt := range cases {
+		if c := tt.a.Cmp(tt.b); c != tt.e {
+			t.Errorf("got %d comparing %s and %s, expected %d, test case: %s", c, tt.a, tt.b, tt.e, tt.name)
+		}
+	}
+}
+
+func TestDecimalOverflow(t *testing.T) {
+	max := NewDecimal(math.MaxInt64, 0)
+	tiny := mustParseDecimal(t, "0.000000000000000001")
+
+	var cases = []struct {
+		name string
+		op   func() (Decimal, bool)
+	}{
+		{name: "Add", op: func() (Decimal, bool) { return max.Add(NewDecimal(1, 0)) }},
+		{name: "Sub", op: func() (Decimal, bool) { return NewDecimal(math.MinInt64, 0).Sub(NewDecimal(1, 0)) }},
+		{name: "Sub MinInt64", op: func() (Decimal, bool) { return NewDecimal(0, 0).Sub(NewDecimal(math.MinInt64, 0)) }},
+		{name: "Align", op: func() (Decimal, bool) { return NewDecimal(10, 0).Add(tiny) }},
+	}
+
+	for _, tt := range cases {
+		if d, ok := tt.op(); ok {
+			t.Errorf("got %s, expected an overflow, test case: %s", d, tt.name)
+		}
+	}
+
+	if cents, ok := mustParseDecimal(t, "92233720368547758.07").MinorUnits(4); ok {
+		t.Errorf("got %d minor units, expected an overflow", cents)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected NewDecimal to panic on overflow")
+		}
+	}()
+	NewDecimal(math.MaxInt64/10, -2)
+}
+
+func mustParseDecimal(t *testing.T, s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("unexpected error parsing %s: %s", s, err)
+	}
+	return d
+}
+
+func TestDecimalMinorUnits(t *testing.T) {
+	if cents, ok := NewDecimal(920, 2).MinorUnits(2); !ok || cents != 920 {
+		t.Errorf("got %d, %t, expected 920, true", cents, ok)
+	}
+	if cents, ok := NewDecimal(92, 1).MinorUnits(2); !ok || cents != 920 {
+		t.Errorf("got %d, %t, expected 920, true", cents, ok)
+	}
+	if cents, ok := NewDecimal(75, 3).MinorUnits(2); ok || cents != 7 {
+		t.Errorf("got %d, %t, expected 7, false", cents, ok)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var cases = []struct {
+		name string
+		in   string
+		e    string
+	}{
+		{name: "Number", in: `{"amount":9.2}`, e: "9.2"},
+		{name: "String", in: `{"amount":"0.075000"}`, e: "0.075000"},
+		{name: "Exponent", in: `{"amount":7.5e-2}`, e: "0.075"},
+		{name: "Null", in: `{"amount":null}`, e: "0"},
+		{name: "Empty string", in: `{"amount":""}`, e: "0"},
+	}
+
+	for _, tt := range cases {
+		var v struct {
+			Amount Decimal `json:"amount"`
+		}
+		if err := json.Unmarshal([]byte(tt.in), &v); err != nil {
+			t.Errorf("unexpected error: %s, test case: %s", err, tt.name)
+			continue
+		}
+		if v.Amount.String() != tt.e {
+			t.Errorf("got %s, expected %s, test case: %s", v.Amount, tt.e, tt.name)
+		}
+	}
+
+	b, err := json.Marshal(struct {
+		Amount Decimal `json:"amount"`
+	}{NewDecimal(75000, 6)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(b) != `{"amount":0.075000}` {
+		t.Errorf("got %s, expected {\"amount\":0.075000}", b)
+	}
+}
@@ -0,0 +1,331 @@
+/*
+Package deliverystats aggregates delivery reports into delivery statistics
+per campaign and country, e.g. for delivery dashboards:
+
+	stats := deliverystats.New(nil)
+	http.Handle("/dlr", stats.Handler())
+
+	// ...
+	for key, s := range stats.Snapshot() {
+		log.Printf("%s/%s: %d of %d delivered", key.Campaign, key.Country, s.Delivered, s.Recipients)
+	}
+
+Status reports are received from the report URL of messages, and statuses
+of polled messages are added with AddMessage. Each recipient of a message is
+counted once, with its latest status, so repeated and retried reports do
+not skew the statistics. A recipient counted without a country, e.g. by
+AddMessage, moves to its country when a report including it arrives.
+
+The state of recipients and send times is kept for Options.Retention after
+their last report, and then forgotten to bound the memory used. The
+statistics themselves are kept until the Aggregator is discarded.
+*/
+package deliverystats
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/messagebird/go-rest-api/sms"
+	"github.com/messagebird/go-rest-api/webhooks"
+)
+
+// Key identifies the statistics of a campaign in a country.
+type Key struct {
+	Campaign string
+	Country  string
+}
+
+// Stats are the delivery statistics of a set of recipients.
+type Stats struct {
+	Recipients int // The number of recipients reported on.
+
+	Pending   int // Scheduled, sent or buffered.
+	Delivered int
+	Failed    int
+	Expired   int
+
+	// AverageLatency is the average time between sending and delivering a
+	// message, of the delivered messages with a known send time.
+	AverageLatency time.Duration
+
+	latencySum   time.Duration
+	latencyCount int
+}
+
+// DeliveryRate returns the fraction of recipients the message was delivered
+// to.
+func (s Stats) DeliveryRate() float64 {
+	if s.Recipients == 0 {
+		return 0
+	}
+	return float64(s.Delivered) / float64(s.Recipients)
+}
+
+// Options configure an Aggregator.
+type Options struct {
+	// Campaign returns the campaign of a report. It defaults to the
+	// reference of the message.
+	Campaign func(*webhooks.StatusReport) string
+
+	// Country returns the country of a report. It defaults to the MCC of
+	// the network of the report, e.g. "204" for the Netherlands, which is
+	// only set by status reports.
+	Country func(*webhooks.StatusReport) string
+
+	// Retention is how long the state of a recipient is kept after its
+	// last report, and the send time of a message after TrackSent. Reports
+	// arriving later count the recipient again. It defaults to 72 hours.
+	Retention time.Duration
+}
+
+const (
+	defaultRetention = 72 * time.Hour
+
+	// pruneInterval is how often state older than the retention is removed.
+	pruneInterval = time.Minute
+)
+
+// Aggregator maintains delivery statistics. It is safe for concurrent use.
+type Aggregator struct {
+	campaign func(*webhooks.StatusReport) string
+	country  func(*webhooks.StatusReport) string
+
+	retention time.Duration
+	now       func() time.Time
+
+	mu         sync.Mutex
+	recipients map[recipientKey]*recipientState
+	sent       map[string]sentMessage
+	stats      map[Key]*Stats
+	pruned     time.Time
+}
+
+type recipientKey struct {
+	id, recipient string
+}
+
+type recipientState struct {
+	key     Key
+	status  webhooks.Status
+	updated time.Time
+
+	// latency is the delivery latency of the recipient, if counted.
+	latency        time.Duration
+	latencyCounted bool
+}
+
+type sentMessage struct {
+	at, tracked time.Time
+}
+
+// New returns an aggregator without statistics. Options may be nil.
+func New(options *Options) *Aggregator {
+	var o Options
+	if options != nil {
+		o = *options
+	}
+	if o.Campaign == nil {
+		o.Campaign = func(r *webhooks.StatusReport) string { return r.Reference }
+	}
+	if o.Country == nil {
+		o.Country = mcc
+	}
+	if o.Retention <= 0 {
+		o.Retention = defaultRetention
+	}
+	return &Aggregator{
+		campaign:   o.Campaign,
+		country:    o.Country,
+		retention:  o.Retention,
+		now:        time.Now,
+		recipients: make(map[recipientKey]*recipientState),
+		sent:       make(map[string]sentMessage),
+		stats:      make(map[Key]*Stats),
+	}
+}
+
+// TrackSent records when the message with id was sent, to compute the
+// latency of its deliveries. AddMessage does this for polled messages.
+func (a *Aggregator) TrackSent(id string, at time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sent[id] = sentMessage{at: at, tracked: a.now()}
+}
+
+// Add adds a status report. Reports that do not change the status of a
+// recipient, and reports following a final status, are ignored.
+func (a *Aggregator) Add(r *webhooks.StatusReport) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if now.Sub(a.pruned) >= pruneInterval {
+		a.prune(now)
+	}
+
+	rk := recipientKey{r.ID, r.Recipient}
+	state, ok := a.recipients[rk]
+	if ok {
+		state.updated = now
+		if country := a.country(r); state.key.Country == "" && country != "" {
+			a.move(state, Key{Campaign: state.key.Campaign, Country: country})
+		}
+		if state.status == r.Status || state.status.Final() {
+			return
+		}
+	} else {
+		state = &recipientState{key: Key{Campaign: a.campaign(r), Country: a.country(r)}, updated: now}
+		a.recipients[rk] = state
+		a.statsFor(state.key).Recipients++
+	}
+
+	s := a.statsFor(state.key)
+	if ok {
+		*counter(s, state.status)--
+	}
+	state.status = r.Status
+	*counter(s, r.Status)++
+
+	if sent, ok := a.sent[r.ID]; ok && r.Status == webhooks.StatusDelivered && !r.StatusDatetime.Before(sent.at) {
+		state.latency, state.latencyCounted = r.StatusDatetime.Sub(sent.at), true
+		s.addLatency(state.latency, 1)
+	}
+}
+
+// move moves the counts of a recipient to the statistics of k.
+func (a *Aggregator) move(state *recipientState, k Key) {
+	from, to := a.statsFor(state.key), a.statsFor(k)
+	from.Recipients--
+	*counter(from, state.status)--
+	to.Recipients++
+	*counter(to, state.status)++
+	if state.latencyCounted {
+		from.addLatency(-state.latency, -1)
+		to.addLatency(state.latency, 1)
+	}
+	if from.Recipients == 0 {
+		delete(a.stats, state.key)
+	}
+	state.key = k
+}
+
+// prune forgets the recipients and send times older than the retention.
+func (a *Aggregator) prune(now time.Time) {
+	a.pruned = now
+	for rk, state := range a.recipients {
+		if now.Sub(state.updated) >= a.retention {
+			delete(a.recipients, rk)
+		}
+	}
+	for id, sent := range a.sent {
+		if now.Sub(sent.tracked) >= a.retention {
+			delete(a.sent, id)
+		}
+	}
+}
+
+// AddMessage adds the statuses of the recipients of a polled message, e.g.
+// from sms.Read or sms.WaitForStatus.
+func (a *Aggregator) AddMessage(m *sms.Message) {
+	if m.CreatedDatetime != nil {
+		a.TrackSent(m.ID, *m.CreatedDatetime)
+	}
+	for _, item := range m.Recipients.Items {
+		r := &webhooks.StatusReport{
+			ID:              m.ID,
+			Reference:       m.Reference,
+			Recipient:       strconv.FormatInt(item.Recipient, 10),
+			Status:          webhooks.Status(item.Status),
+			StatusReason:    item.StatusReason,
+			StatusErrorCode: item.StatusErrorCode,
+		}
+		if item.StatusDatetime != nil {
+			r.StatusDatetime = *item.StatusDatetime
+		}
+		a.Add(r)
+	}
+}
+
+// Handler returns a handler for the report URL of messages, which adds the
+// status reports it receives.
+func (a *Aggregator) Handler() http.Handler {
+	return webhooks.StatusReportHandler(func(r *webhooks.StatusReport) error {
+		a.Add(r)
+		return nil
+	})
+}
+
+// Snapshot returns a copy of the statistics per campaign and country.
+func (a *Aggregator) Snapshot() map[Key]Stats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	snapshot := make(map[Key]Stats, len(a.stats))
+	for k, s := range a.stats {
+		snapshot[k] = *s
+	}
+	return snapshot
+}
+
+// Total returns the statistics of all campaigns and countries together.
+func (a *Aggregator) Total() Stats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var total Stats
+	for _, s := range a.stats {
+		total.Recipients += s.Recipients
+		total.Pending += s.Pending
+		total.Delivered += s.Delivered
+		total.Failed += s.Failed
+		total.Expired += s.Expired
+		total.latencySum += s.latencySum
+		total.latencyCount += s.latencyCount
+	}
+	if total.latencyCount > 0 {
+		total.AverageLatency = total.latencySum / time.Duration(total.latencyCount)
+	}
+	return total
+}
+
+func (a *Aggregator) statsFor(k Key) *Stats {
+	s, ok := a.stats[k]
+	if !ok {
+		s = &Stats{}
+		a.stats[k] = s
+	}
+	return s
+}
+
+// addLatency adds count deliveries with a total latency of sum to s.
+func (s *Stats) addLatency(sum time.Duration, count int) {
+	s.latencySum += sum
+	s.latencyCount += count
+	s.AverageLatency = 0
+	if s.latencyCount > 0 {
+		s.AverageLatency = s.latencySum / time.Duration(s.latencyCount)
+	}
+}
+
+// counter returns the counter of s for status.
+func counter(s *Stats, status webhooks.Status) *int {
+	switch status {
+	case webhooks.StatusDelivered:
+		return &s.Delivered
+	case webhooks.StatusDeliveryFailed:
+		return &s.Failed
+	case webhooks.StatusExpired:
+		return &s.Expired
+	}
+	return &s.Pending
+}
+
+func mcc(r *webhooks.StatusReport) string {
+	if len(r.MCCMNC) < 3 {
+		return ""
+	}
+	return r.MCCMNC[:3]
+}
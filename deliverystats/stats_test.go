@@ -0,0 +1,146 @@
+package deliverystats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/sms"
+	"github.com/messagebird/go-rest-api/webhooks"
+)
+
+var sentAt = time.Date(2020, 3, 8, 12, 0, 0, 0, time.UTC)
+
+func report(id, recipient string, status webhooks.Status, after time.Duration) *webhooks.StatusReport {
+	return &webhooks.StatusReport{
+		ID:             id,
+		Reference:      "spring-sale",
+		Recipient:      recipient,
+		Status:         status,
+		StatusDatetime: sentAt.Add(after),
+		MCCMNC:         "20408",
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	a := New(nil)
+	a.TrackSent("msg-1", sentAt)
+
+	a.Add(report("msg-1", "31612345678", webhooks.StatusSent, time.Second))
+	a.Add(report("msg-1", "31612345678", webhooks.StatusDelivered, 4*time.Second))
+	a.Add(report("msg-1", "31612345678", webhooks.StatusDelivered, 4*time.Second))
+	a.Add(report("msg-1", "31687654321", webhooks.StatusBuffered, time.Second))
+	a.Add(report("msg-1", "31687654321", webhooks.StatusDelivered, 8*time.Second))
+	a.Add(report("msg-1", "31600000000", webhooks.StatusDeliveryFailed, time.Second))
+	a.Add(report("msg-1", "31600000000", webhooks.StatusSent, 2*time.Second))
+	a.Add(report("msg-1", "31600000001", webhooks.StatusBuffered, time.Second))
+
+	other := report("msg-1", "4915112345678", webhooks.StatusExpired, time.Hour)
+	other.MCCMNC = "26201"
+	a.Add(other)
+
+	snapshot := a.Snapshot()
+	nl := snapshot[Key{Campaign: "spring-sale", Country: "204"}]
+	if nl.Recipients != 4 || nl.Delivered != 2 || nl.Failed != 1 || nl.Pending != 1 || nl.Expired != 0 {
+		t.Errorf("got stats %+v, expected 4 recipients, 2 delivered, 1 failed and 1 pending", nl)
+	}
+	if nl.AverageLatency != 6*time.Second {
+		t.Errorf("got average latency %s, expected 6s", nl.AverageLatency)
+	}
+	if nl.DeliveryRate() != 0.5 {
+		t.Errorf("got delivery rate %f, expected 0.5", nl.DeliveryRate())
+	}
+
+	de := snapshot[Key{Campaign: "spring-sale", Country: "262"}]
+	if de.Recipients != 1 || de.Expired != 1 || de.AverageLatency != 0 {
+		t.Errorf("got stats %+v, expected 1 expired recipient", de)
+	}
+
+	if total := a.Total(); total.Recipients != 5 || total.Delivered != 2 || total.AverageLatency != 6*time.Second {
+		t.Errorf("got total %+v, expected 5 recipients and 2 delivered", total)
+	}
+}
+
+func TestAggregatorMessage(t *testing.T) {
+	deliveredAt := sentAt.Add(3 * time.Second)
+	a := New(&Options{Campaign: func(*webhooks.StatusReport) string { return "alerts" }})
+	a.AddMessage(&sms.Message{
+		ID:              "msg-1",
+		CreatedDatetime: &sentAt,
+		Recipients: messagebird.Recipients{Items: []messagebird.Recipient{
+			{Recipient: 31612345678, Status: sms.StatusDelivered, StatusDatetime: &deliveredAt},
+			{Recipient: 31687654321, Status: sms.StatusSent, StatusDatetime: &sentAt},
+		}},
+	})
+
+	s := a.Snapshot()[Key{Campaign: "alerts"}]
+	if s.Recipients != 2 || s.Delivered != 1 || s.Pending != 1 || s.AverageLatency != 3*time.Second {
+		t.Errorf("got stats %+v, expected 1 of 2 delivered after 3s", s)
+	}
+}
+
+func TestAggregatorHandler(t *testing.T) {
+	a := New(nil)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/dlr?id=msg-1&recipient=31612345678&status=delivered&statusDatetime=2020-03-08T12%3A30%3A00%2B00%3A00&mccmnc=20408", nil)
+	a.Handler().ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, expected 200", w.Code)
+	}
+	if s := a.Snapshot()[Key{Country: "204"}]; s.Delivered != 1 {
+		t.Errorf("got stats %+v, expected 1 delivered", s)
+	}
+}
+
+func TestAggregatorCountry(t *testing.T) {
+	deliveredAt := sentAt.Add(3 * time.Second)
+	a := New(nil)
+	a.AddMessage(&sms.Message{
+		ID:              "msg-1",
+		Reference:       "spring-sale",
+		CreatedDatetime: &sentAt,
+		Recipients: messagebird.Recipients{Items: []messagebird.Recipient{
+			{Recipient: 31612345678, Status: sms.StatusDelivered, StatusDatetime: &deliveredAt},
+			{Recipient: 31687654321, Status: sms.StatusSent, StatusDatetime: &sentAt},
+		}},
+	})
+	a.Add(report("msg-1", "31612345678", webhooks.StatusDelivered, 3*time.Second))
+	a.Add(report("msg-1", "31687654321", webhooks.StatusDelivered, 5*time.Second))
+
+	snapshot := a.Snapshot()
+	if s, ok := snapshot[Key{Campaign: "spring-sale"}]; ok {
+		t.Errorf("got stats %+v without a country, expected the recipients to move to 204", s)
+	}
+	s := snapshot[Key{Campaign: "spring-sale", Country: "204"}]
+	if s.Recipients != 2 || s.Delivered != 2 || s.Pending != 0 || s.AverageLatency != 4*time.Second {
+		t.Errorf("got stats %+v, expected 2 delivered after 4s on average", s)
+	}
+}
+
+func TestAggregatorRetention(t *testing.T) {
+	now := sentAt
+	a := New(&Options{Retention: time.Hour})
+	a.now = func() time.Time { return now }
+	a.TrackSent("msg-1", sentAt)
+	a.Add(report("msg-1", "31612345678", webhooks.StatusDelivered, time.Second))
+	a.Add(report("msg-1", "31687654321", webhooks.StatusSent, time.Second))
+
+	now = now.Add(30 * time.Minute)
+	a.Add(report("msg-1", "31687654321", webhooks.StatusBuffered, 30*time.Minute))
+
+	now = now.Add(45 * time.Minute)
+	a.Add(report("msg-2", "31600000000", webhooks.StatusSent, 75*time.Minute))
+
+	a.mu.Lock()
+	recipients, sent := len(a.recipients), len(a.sent)
+	a.mu.Unlock()
+	if recipients != 2 || sent != 0 {
+		t.Errorf("got %d recipients and %d send times, expected the delivered recipient and send time to be pruned", recipients, sent)
+	}
+	if total := a.Total(); total.Recipients != 3 || total.Delivered != 1 || total.Pending != 2 {
+		t.Errorf("got total %+v, expected the statistics to be kept", total)
+	}
+}
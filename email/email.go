@@ -0,0 +1,123 @@
+// Package email provides access to the Email API, which sends transactional
+// emails.
+package email
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// apiRoot is the absolute URL of the Email API.
+const apiRoot = messagebird.EmailEndpoint + "/v1"
+
+// The statuses of a Message.
+const (
+	StatusAccepted  = "accepted"
+	StatusSent      = "sent"
+	StatusDelivered = "delivered"
+	StatusBounced   = "bounced"
+	StatusFailed    = "failed"
+)
+
+// Address is an email address with an optional display name.
+type Address struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// Content is the body of an email. At least one of HTML and Text must be
+// set. Clients that can't display HTML show Text.
+type Content struct {
+	HTML string `json:"html,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"type"`
+
+	// Content is the raw file, which is sent encoded with base64.
+	Content []byte `json:"content"`
+}
+
+// Params holds the email to send.
+type Params struct {
+	From        Address       `json:"from"`
+	To          []Address     `json:"to"`
+	ReplyTo     *Address      `json:"replyTo,omitempty"`
+	Subject     string        `json:"subject"`
+	Content     Content       `json:"content"`
+	Attachments []*Attachment `json:"attachments,omitempty"`
+
+	// ReportURL receives the status reports of the email.
+	ReportURL string `json:"reportUrl,omitempty"`
+
+	// TrackOpens and TrackClicks enable tracking of the email, which is
+	// reported to ReportURL.
+	TrackOpens  bool `json:"trackOpens,omitempty"`
+	TrackClicks bool `json:"trackClicks,omitempty"`
+}
+
+// Message is an email sent through the Email API.
+type Message struct {
+	ID              string
+	Status          string
+	From            Address
+	To              []Address
+	Subject         string
+	CreatedDatetime *time.Time
+	UpdatedDatetime *time.Time
+}
+
+// Send sends a transactional email. The email is sent asynchronously: use
+// Read or the report URL to learn whether it was delivered.
+func Send(c *messagebird.Client, params *Params) (*Message, error) {
+	if err := validate(params); err != nil {
+		return nil, err
+	}
+
+	message := &Message{}
+	if err := c.Request(message, http.MethodPost, apiRoot+"/send", params); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Read retrieves a sent email, including its status.
+func Read(c *messagebird.Client, id string) (*Message, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	message := &Message{}
+	if err := c.Request(message, http.MethodGet, apiRoot+"/messages/"+url.PathEscape(id), nil); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func validate(params *Params) error {
+	if params == nil {
+		return errors.New("params are required")
+	}
+	if params.From.Email == "" {
+		return errors.New("from is required")
+	}
+	if len(params.To) == 0 {
+		return errors.New("at least one recipient is required")
+	}
+	if params.Subject == "" {
+		return errors.New("subject is required")
+	}
+	if params.Content.HTML == "" && params.Content.Text == "" {
+		return errors.New("html or text content is required")
+	}
+	return nil
+}
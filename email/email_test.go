@@ -0,0 +1,81 @@
+package email
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func testParams() *Params {
+	return &Params{
+		From:    Address{Email: "noreply@example.com", Name: "Example"},
+		To:      []Address{{Email: "jane@example.com"}},
+		ReplyTo: &Address{Email: "support@example.com"},
+		Subject: "Your invoice",
+		Content: Content{HTML: "<p>See attached.</p>", Text: "See attached."},
+		Attachments: []*Attachment{
+			{Filename: "invoice.txt", ContentType: "text/plain", Content: []byte("Invoice")},
+		},
+		ReportURL: "https://example.com/status",
+	}
+}
+
+func TestSend(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusAccepted)
+	client := mbtest.Client(t)
+
+	message, err := Send(client, testParams())
+	if err != nil {
+		t.Fatalf("unexpected error sending email: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/send")
+	mbtest.AssertTestdata(t, "sendRequest.json", mbtest.Request.Body)
+
+	if message.ID != "emid" || message.Status != StatusAccepted {
+		t.Errorf("got %s with status %s, expected emid with status accepted", message.ID, message.Status)
+	}
+}
+
+func TestSendInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	var cases = []struct {
+		name   string
+		modify func(p *Params)
+	}{
+		{name: "Missing from", modify: func(p *Params) { p.From = Address{} }},
+		{name: "Missing recipients", modify: func(p *Params) { p.To = nil }},
+		{name: "Missing subject", modify: func(p *Params) { p.Subject = "" }},
+		{name: "Missing content", modify: func(p *Params) { p.Content = Content{} }},
+	}
+
+	for _, tt := range cases {
+		params := testParams()
+		tt.modify(params)
+		if _, err := Send(client, params); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	message, err := Read(client, "emid")
+	if err != nil {
+		t.Fatalf("unexpected error reading email: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/messages/emid")
+
+	if len(message.To) != 1 || message.To[0].Email != "jane@example.com" {
+		t.Errorf("got recipients %v, expected jane@example.com", message.To)
+	}
+}
@@ -0,0 +1,121 @@
+package messagebird
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	// ConversationsEndpoint points you to the MessageBird Conversations API.
+	ConversationsEndpoint = "https://conversations.messagebird.com"
+
+	// VoiceEndpoint points you to the MessageBird Voice API.
+	VoiceEndpoint = "https://voice.messagebird.com"
+
+	// NumbersEndpoint points you to the MessageBird Numbers API.
+	NumbersEndpoint = "https://numbers.messagebird.com"
+
+	// IntegrationsEndpoint points you to the MessageBird Integrations API.
+	IntegrationsEndpoint = "https://integrations.messagebird.com"
+
+	// EmailEndpoint points you to the MessageBird Email API.
+	EmailEndpoint = "https://email.messagebird.com"
+
+	// PartnerAccountsEndpoint points you to the MessageBird Partner Accounts
+	// API.
+	PartnerAccountsEndpoint = "https://partner-accounts.messagebird.com"
+
+	// ReportingEndpoint points you to the MessageBird Reporting API.
+	ReportingEndpoint = "https://reporting.messagebird.com"
+
+	// MessagingEndpoint points you to the MessageBird Messaging API, which
+	// hosts uploaded media files.
+	MessagingEndpoint = "https://messaging.messagebird.com"
+
+	// WhatsAppSandboxEndpoint points you to the WhatsApp sandbox of the
+	// Conversations API, which can be used through Endpoints.Conversations.
+	WhatsAppSandboxEndpoint = "https://whatsapp-sandbox.messagebird.com"
+)
+
+// Endpoints overrides the base URLs of the MessageBird APIs, e.g. to send
+// requests to a mock server in CI or to the WhatsApp sandbox. Empty fields
+// use the production endpoint. A base URL may include a path, which is
+// prepended to the path of each request:
+//
+//	client := messagebird.New(accessKey, messagebird.WithEndpoints(messagebird.Endpoints{
+//	    REST:  "http://localhost:8080/rest",
+//	    Voice: "http://localhost:8080/voice",
+//	}))
+//
+// Test access keys, available in the MessageBird dashboard, can be used with
+// the production REST endpoint to make requests without sending messages.
+type Endpoints struct {
+	REST            string // Replaces Endpoint.
+	Conversations   string // Replaces ConversationsEndpoint.
+	Voice           string // Replaces VoiceEndpoint.
+	Numbers         string // Replaces NumbersEndpoint.
+	Integrations    string // Replaces IntegrationsEndpoint.
+	Email           string // Replaces EmailEndpoint.
+	PartnerAccounts string // Replaces PartnerAccountsEndpoint.
+	Reporting       string // Replaces ReportingEndpoint.
+	Messaging       string // Replaces MessagingEndpoint.
+}
+
+// WithEndpoints makes the client send requests to the provided endpoints.
+func WithEndpoints(e Endpoints) Option {
+	return func(c *Client) {
+		c.Endpoints = e
+	}
+}
+
+// WithWhatsAppSandbox makes the client send requests for the Conversations
+// API to the WhatsApp sandbox, to test WhatsApp flows before a WhatsApp
+// Business account is approved. Use conversation.SandboxChannel to find the
+// sandbox channel. Other endpoints are not changed, so it can be combined
+// with WithEndpoints given before it.
+func WithWhatsAppSandbox() Option {
+	return func(c *Client) {
+		c.Endpoints.Conversations = WhatsAppSandboxEndpoint
+	}
+}
+
+// ResolveURL rewrites an absolute URL for one of the production endpoints to
+// the endpoint configured by the client's Endpoints. It is for internal use
+// only and unstable.
+func (c *Client) ResolveURL(u string) string {
+	for _, e := range []struct{ prod, override string }{
+		{Endpoint, c.Endpoints.REST},
+		{ConversationsEndpoint, c.Endpoints.Conversations},
+		{VoiceEndpoint, c.Endpoints.Voice},
+		{NumbersEndpoint, c.Endpoints.Numbers},
+		{IntegrationsEndpoint, c.Endpoints.Integrations},
+		{EmailEndpoint, c.Endpoints.Email},
+		{PartnerAccountsEndpoint, c.Endpoints.PartnerAccounts},
+		{ReportingEndpoint, c.Endpoints.Reporting},
+		{MessagingEndpoint, c.Endpoints.Messaging},
+	} {
+		if e.override == "" || !hasBase(u, e.prod) {
+			continue
+		}
+		return strings.TrimSuffix(e.override, "/") + strings.TrimPrefix(u, e.prod)
+	}
+	return u
+}
+
+// hasBase reports whether u is base or a URL below it.
+func hasBase(u, base string) bool {
+	if !strings.HasPrefix(u, base) {
+		return false
+	}
+	rest := u[len(base):]
+	return rest == "" || rest[0] == '/' || rest[0] == '?'
+}
+
+// parseURL resolves path relative to the REST endpoint, unless it is
+// absolute, and applies the client's Endpoints.
+func (c *Client) parseURL(path string) (*url.URL, error) {
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		path = Endpoint + "/" + path
+	}
+	return url.Parse(c.ResolveURL(path))
+}
@@ -0,0 +1,71 @@
+package messagebird
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveURL(t *testing.T) {
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithEndpoints(Endpoints{
+		REST:          "http://localhost:8080/rest/",
+		Conversations: WhatsAppSandboxEndpoint,
+	}))
+
+	var cases = []struct {
+		name string
+		u    string
+		e    string
+	}{
+		{
+			name: "REST",
+			u:    Endpoint + "/balance?a=b",
+			e:    "http://localhost:8080/rest/balance?a=b",
+		},
+		{
+			name: "Conversations",
+			u:    ConversationsEndpoint + "/v1/conversations",
+			e:    WhatsAppSandboxEndpoint + "/v1/conversations",
+		},
+		{
+			name: "Voice not overridden",
+			u:    VoiceEndpoint + "/calls",
+			e:    VoiceEndpoint + "/calls",
+		},
+		{
+			name: "Other host",
+			u:    Endpoint + ".example.com/balance",
+			e:    Endpoint + ".example.com/balance",
+		},
+	}
+
+	for _, tt := range cases {
+		if got := c.ResolveURL(tt.u); got != tt.e {
+			t.Errorf("got %s, expected %s, test case: %s", got, tt.e, tt.name)
+		}
+	}
+}
+
+func TestRequestWithEndpoints(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithEndpoints(Endpoints{REST: ts.URL, Voice: ts.URL + "/voice"}))
+	if err := c.Request(nil, http.MethodGet, "balance", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "/balance" {
+		t.Errorf("got path %s, expected /balance", got)
+	}
+
+	if err := c.Request(nil, http.MethodGet, VoiceEndpoint+"/calls", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "/voice/calls" {
+		t.Errorf("got path %s, expected /voice/calls", got)
+	}
+}
@@ -0,0 +1,86 @@
+package messagebird
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// The environment variables read by NewFromEnv.
+const (
+	EnvAccessKey             = "MESSAGEBIRD_ACCESS_KEY"
+	EnvEndpoint              = "MESSAGEBIRD_ENDPOINT"
+	EnvConversationsEndpoint = "MESSAGEBIRD_CONVERSATIONS_ENDPOINT"
+	EnvVoiceEndpoint         = "MESSAGEBIRD_VOICE_ENDPOINT"
+	EnvTimeout               = "MESSAGEBIRD_TIMEOUT"
+	EnvDebug                 = "MESSAGEBIRD_DEBUG"
+)
+
+// NewFromEnv creates a new client configured by environment variables:
+//
+//	MESSAGEBIRD_ACCESS_KEY              the access key, required
+//	MESSAGEBIRD_ENDPOINT                overrides the REST API endpoint
+//	MESSAGEBIRD_CONVERSATIONS_ENDPOINT  overrides the Conversations API endpoint
+//	MESSAGEBIRD_VOICE_ENDPOINT          overrides the Voice API endpoint
+//	MESSAGEBIRD_TIMEOUT                 the HTTP client timeout, e.g. "10s"
+//	MESSAGEBIRD_DEBUG                   logs requests to stderr when true
+//
+// The options are applied after the environment, so they take precedence.
+func NewFromEnv(opts ...Option) (*Client, error) {
+	return newFromEnv(os.Getenv, opts...)
+}
+
+func newFromEnv(getenv func(string) string, opts ...Option) (*Client, error) {
+	accessKey := getenv(EnvAccessKey)
+	if accessKey == "" {
+		return nil, errors.New(EnvAccessKey + " is not set")
+	}
+
+	var e Endpoints
+	for _, ep := range []struct {
+		name string
+		dst  *string
+	}{
+		{EnvEndpoint, &e.REST},
+		{EnvConversationsEndpoint, &e.Conversations},
+		{EnvVoiceEndpoint, &e.Voice},
+	} {
+		v := getenv(ep.name)
+		if v == "" {
+			continue
+		}
+		if u, err := url.Parse(v); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%s must be an absolute http(s) URL, got %q", ep.name, v)
+		}
+		*ep.dst = v
+	}
+	envOpts := []Option{WithEndpoints(e)}
+
+	if v := getenv(EnvTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%s must be a positive duration, got %q", EnvTimeout, v)
+		}
+		envOpts = append(envOpts, func(c *Client) {
+			c.HTTPClient.Timeout = d
+		})
+	}
+
+	if v := getenv(EnvDebug); v != "" {
+		debug, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a boolean, got %q", EnvDebug, v)
+		}
+		if debug {
+			envOpts = append(envOpts, func(c *Client) {
+				c.DebugLog = log.New(os.Stderr, "messagebird: ", log.LstdFlags)
+			})
+		}
+	}
+
+	return New(accessKey, append(envOpts, opts...)...), nil
+}
@@ -0,0 +1,62 @@
+package messagebird
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	env := map[string]string{
+		EnvAccessKey: "test_gshuPaZoeEG6ovbc8M79w0QyM",
+		EnvEndpoint:  "http://localhost:8080",
+		EnvTimeout:   "3s",
+		EnvDebug:     "true",
+	}
+	c, err := newFromEnv(func(k string) string { return env[k] })
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if c.AccessKey != env[EnvAccessKey] {
+		t.Errorf("got access key %q, expected %q", c.AccessKey, env[EnvAccessKey])
+	}
+	if c.Endpoints.REST != "http://localhost:8080" || c.Endpoints.Voice != "" {
+		t.Errorf("got endpoints %+v, expected only REST to be overridden", c.Endpoints)
+	}
+	if c.HTTPClient.Timeout != 3*time.Second {
+		t.Errorf("got timeout %s, expected 3s", c.HTTPClient.Timeout)
+	}
+	if c.DebugLog == nil {
+		t.Errorf("expected debug logging to be enabled")
+	}
+}
+
+func TestNewFromEnvErrors(t *testing.T) {
+	var cases = []struct {
+		name string
+		env  map[string]string
+	}{
+		{
+			name: "Missing access key",
+			env:  map[string]string{},
+		},
+		{
+			name: "Relative endpoint",
+			env:  map[string]string{EnvAccessKey: "key", EnvVoiceEndpoint: "localhost:8080"},
+		},
+		{
+			name: "Invalid timeout",
+			env:  map[string]string{EnvAccessKey: "key", EnvTimeout: "10"},
+		},
+		{
+			name: "Invalid debug flag",
+			env:  map[string]string{EnvAccessKey: "key", EnvDebug: "yes please"},
+		},
+	}
+
+	for _, tt := range cases {
+		if _, err := newFromEnv(func(k string) string { return tt.env[k] }); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
@@ -1,9 +1,37 @@
 package messagebird
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
 const (
 	apiErrMessage = "The MessageBird API returned an error"
 )
 
+// Error codes returned by the MessageBird API, which are matched by the
+// exported errors below in addition to the HTTP status code.
+const (
+	errCodeRequestNotAllowed = 2
+	errCodeNotFound          = 20
+)
+
+var (
+	// ErrNotFound is matched by ErrorResponses for resources that do not
+	// exist.
+	ErrNotFound = errors.New("The requested resource could not be found")
+
+	// ErrUnauthorized is matched by ErrorResponses for requests with an
+	// invalid access key, or one lacking permission for the request.
+	ErrUnauthorized = errors.New("The request is not allowed with this access key")
+
+	// ErrRateLimited is matched by ErrorResponses for requests rejected with
+	// 429 Too Many Requests.
+	ErrRateLimited = errors.New("The request was rate limited")
+)
+
 // Error holds details including error code, human readable description and optional parameter that is related to the error.
 type Error struct {
 	Code        int
@@ -19,9 +47,132 @@ func (e Error) Error() string {
 // ErrorResponse represents errored API response.
 type ErrorResponse struct {
 	Errors []Error `json:"errors"`
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"-"`
+
+	// RequestID is the ID MessageBird assigned to the request, if the
+	// response included it. Include it in support tickets about the error.
+	RequestID string `json:"-"`
+}
+
+// StatusError is returned for unsuccessful responses without a JSON error
+// body, such as a 500 or an HTML page of a load balancer. StatusErrors of 5xx
+// responses match ErrUnexpectedResponse with errors.Is.
+type StatusError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// RequestID is the ID MessageBird assigned to the request, if the
+	// response included it.
+	RequestID string
+}
+
+// Error implements error interface.
+func (e StatusError) Error() string {
+	msg := fmt.Sprintf("bad HTTP status: %d", e.StatusCode)
+	if e.StatusCode >= 500 {
+		msg = fmt.Sprintf("%s (HTTP status %d)", ErrUnexpectedResponse, e.StatusCode)
+	}
+	if e.RequestID != "" {
+		msg += ", request ID " + e.RequestID
+	}
+	return msg
+}
+
+// Is reports whether the error matches target: ErrUnexpectedResponse for 5xx
+// responses, and ErrNotFound, ErrUnauthorized or ErrRateLimited by status
+// code as for ErrorResponse.
+func (e StatusError) Is(target error) bool {
+	if target == ErrUnexpectedResponse {
+		return e.StatusCode >= 500
+	}
+	return ErrorResponse{StatusCode: e.StatusCode}.Is(target)
+}
+
+// ResponseError returns the error for the unsuccessful response resp with
+// body: an ErrorResponse if body holds JSON errors, and a StatusError
+// otherwise or for 500 responses. Both carry the request ID of resp. It is
+// for internal use only and unstable.
+func ResponseError(resp *http.Response, body []byte) error {
+	id := requestID(resp.Header)
+	if resp.StatusCode == http.StatusInternalServerError {
+		// Status code 500 is a server error and means nothing can be done at
+		// this point.
+		return StatusError{StatusCode: resp.StatusCode, RequestID: id}
+	}
+
+	// Anything else than a 200/201/202/204/500 should be a JSON error.
+	errorResponse := ErrorResponse{StatusCode: resp.StatusCode, RequestID: id}
+	if err := json.Unmarshal(body, &errorResponse); err != nil {
+		return StatusError{StatusCode: resp.StatusCode, RequestID: id}
+	}
+	return errorResponse
+}
+
+// RequestID returns the ID MessageBird assigned to the request that failed
+// with err, or "" if err is not an ErrorResponse or StatusError, or the ID
+// is unknown:
+//
+//	if _, err := sms.Create(client, originator, recipients, body, nil); err != nil {
+//	    log.Printf("sending failed (request ID %s): %v", messagebird.RequestID(err), err)
+//	}
+//
+// The IDs of successful requests are available through Client.WithResponse.
+func RequestID(err error) string {
+	var errorResponse ErrorResponse
+	if errors.As(err, &errorResponse) {
+		return errorResponse.RequestID
+	}
+	var statusError StatusError
+	if errors.As(err, &statusError) {
+		return statusError.RequestID
+	}
+	return ""
 }
 
 // Error implements error interface.
 func (r ErrorResponse) Error() string {
 	return apiErrMessage
 }
+
+// Is reports whether the response matches target, which is one of
+// ErrNotFound, ErrUnauthorized or ErrRateLimited, so the category of an error
+// can be checked with errors.Is.
+func (r ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return r.StatusCode == http.StatusNotFound || r.hasCode(errCodeNotFound)
+	case ErrUnauthorized:
+		return r.StatusCode == http.StatusUnauthorized || r.StatusCode == http.StatusForbidden || r.hasCode(errCodeRequestNotAllowed)
+	case ErrRateLimited:
+		return r.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+func (r ErrorResponse) hasCode(code int) bool {
+	for _, e := range r.Errors {
+		if e.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err indicates the requested resource does not
+// exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsAuthError reports whether err indicates the access key is invalid or not
+// allowed to perform the request.
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRateLimited reports whether err indicates the request was rate limited.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
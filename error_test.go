@@ -0,0 +1,93 @@
+package messagebird
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorResponseCategories(t *testing.T) {
+	var cases = []struct {
+		name        string
+		status      int
+		body        string
+		notFound    bool
+		auth        bool
+		rateLimited bool
+	}{
+		{
+			name:     "Not found",
+			status:   http.StatusNotFound,
+			body:     `{"errors":[{"code":20,"description":"message not found","parameter":null}]}`,
+			notFound: true,
+		},
+		{
+			name:   "Incorrect access key",
+			status: http.StatusUnauthorized,
+			body:   `{"errors":[{"code":2,"description":"Request not allowed (incorrect access_key)","parameter":"access_key"}]}`,
+			auth:   true,
+		},
+		{
+			name:        "Rate limited",
+			status:      http.StatusTooManyRequests,
+			body:        `{"errors":[]}`,
+			rateLimited: true,
+		},
+		{
+			name:   "Invalid parameter",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"errors":[{"code":10,"description":"originator is invalid","parameter":"originator"}]}`,
+		},
+	}
+
+	for _, tt := range cases {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			fmt.Fprint(w, tt.body)
+		}))
+		err := New("test_gshuPaZoeEG6ovbc8M79w0QyM").Request(nil, http.MethodGet, ts.URL, nil)
+		ts.Close()
+
+		errResp, ok := err.(ErrorResponse)
+		if !ok {
+			t.Fatalf("expected ErrorResponse, got %v, test case: %s", err, tt.name)
+		}
+		if errResp.StatusCode != tt.status {
+			t.Errorf("got status %d, expected %d, test case: %s", errResp.StatusCode, tt.status, tt.name)
+		}
+
+		wrapped := fmt.Errorf("reading message: %w", err)
+		if IsNotFound(wrapped) != tt.notFound || IsAuthError(wrapped) != tt.auth || IsRateLimited(wrapped) != tt.rateLimited {
+			t.Errorf("got not found %v, auth %v, rate limited %v, test case: %s", IsNotFound(wrapped), IsAuthError(wrapped), IsRateLimited(wrapped), tt.name)
+		}
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	header := http.Header{"Request-Id": []string{"req-1"}}
+
+	var cases = []struct {
+		name   string
+		status int
+		body   string
+		target error
+		e      string
+	}{
+		{name: "JSON error", status: http.StatusNotFound, body: `{"errors":[{"code":20}]}`, target: ErrNotFound, e: "The MessageBird API returned an error"},
+		{name: "Internal server error", status: http.StatusInternalServerError, body: `{"errors":[]}`, target: ErrUnexpectedResponse, e: "The MessageBird API is currently unavailable (HTTP status 500), request ID req-1"},
+		{name: "HTML error", status: http.StatusServiceUnavailable, body: "<html></html>", target: ErrUnexpectedResponse, e: "The MessageBird API is currently unavailable (HTTP status 503), request ID req-1"},
+		{name: "Empty not found", status: http.StatusNotFound, target: ErrNotFound, e: "bad HTTP status: 404, request ID req-1"},
+	}
+
+	for _, tt := range cases {
+		err := ResponseError(&http.Response{StatusCode: tt.status, Header: header}, []byte(tt.body))
+		if !errors.Is(err, tt.target) || err.Error() != tt.e {
+			t.Errorf("got %v, expected %q matching %v, test case: %s", err, tt.e, tt.target, tt.name)
+		}
+		if id := RequestID(err); id != "req-1" {
+			t.Errorf("got request ID %q, expected req-1, test case: %s", id, tt.name)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package fake
+
+import "github.com/messagebird/go-rest-api/balance"
+
+// Balance implements balance.Service, returning Balance from Read.
+type Balance struct {
+	Balance balance.Balance
+	Err     error
+}
+
+var _ balance.Service = (*Balance)(nil)
+
+// Read implements balance.Service.
+func (b *Balance) Read() (*balance.Balance, error) {
+	if b.Err != nil {
+		return nil, b.Err
+	}
+	bal := b.Balance
+	return &bal, nil
+}
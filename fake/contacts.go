@@ -0,0 +1,154 @@
+package fake
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/messagebird/go-rest-api/contact"
+)
+
+// Contacts implements contact.Service, keeping contacts in memory.
+type Contacts struct {
+	Err error
+
+	mu       sync.Mutex
+	contacts []contact.Contact
+	created  int
+}
+
+var _ contact.Service = (*Contacts)(nil)
+
+// NewContacts returns a fake without contacts.
+func NewContacts() *Contacts {
+	return &Contacts{}
+}
+
+// Create implements contact.Service.
+func (c *Contacts) Create(contactRequest *contact.Request) (*contact.Contact, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	if contactRequest == nil || contactRequest.MSISDN == "" {
+		return nil, errors.New("msisdn is required")
+	}
+
+	now := time.Now()
+	ct := contact.Contact{CreatedDatetime: &now}
+	if err := apply(&ct, contactRequest); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.created++
+	ct.ID = id(c.created)
+	c.contacts = append(c.contacts, ct)
+	return &ct, nil
+}
+
+// Delete implements contact.Service.
+func (c *Contacts) Delete(id string) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, ct := range c.contacts {
+		if ct.ID == id {
+			c.contacts = append(c.contacts[:i], c.contacts[i+1:]...)
+			return nil
+		}
+	}
+	return notFound()
+}
+
+// List implements contact.Service.
+func (c *Contacts) List(options *contact.ListOptions) (*contact.ContactList, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	if options == nil {
+		options = contact.DefaultListOptions
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start, end := page(len(c.contacts), options.Offset, options.Limit)
+	return &contact.ContactList{
+		Offset:     start,
+		Limit:      options.Limit,
+		Count:      end - start,
+		TotalCount: len(c.contacts),
+		Items:      append([]contact.Contact(nil), c.contacts[start:end]...),
+	}, nil
+}
+
+// Read implements contact.Service.
+func (c *Contacts) Read(id string) (*contact.Contact, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ct := range c.contacts {
+		if ct.ID == id {
+			return &ct, nil
+		}
+	}
+	return nil, notFound()
+}
+
+// Update implements contact.Service. Only the fields set in contactRequest
+// are updated.
+func (c *Contacts) Update(id string, contactRequest *contact.Request) (*contact.Contact, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.contacts {
+		if c.contacts[i].ID != id {
+			continue
+		}
+		ct := c.contacts[i]
+		if err := apply(&ct, contactRequest); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		ct.UpdatedDatetime = &now
+		c.contacts[i] = ct
+		return &ct, nil
+	}
+	return nil, notFound()
+}
+
+// apply sets the fields of ct that are set in r.
+func apply(ct *contact.Contact, r *contact.Request) error {
+	if r == nil {
+		return nil
+	}
+	if r.MSISDN != "" {
+		msisdn, err := strconv.ParseInt(r.MSISDN, 10, 64)
+		if err != nil {
+			return errors.New("msisdn must be numeric")
+		}
+		ct.MSISDN = msisdn
+	}
+	set := func(field *string, v string) {
+		if v != "" {
+			*field = v
+		}
+	}
+	set(&ct.FirstName, r.FirstName)
+	set(&ct.LastName, r.LastName)
+	set(&ct.CustomDetails.Custom1, r.Custom1)
+	set(&ct.CustomDetails.Custom2, r.Custom2)
+	set(&ct.CustomDetails.Custom3, r.Custom3)
+	set(&ct.CustomDetails.Custom4, r.Custom4)
+	return nil
+}
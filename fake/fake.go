@@ -0,0 +1,54 @@
+// Package fake provides in-memory implementations of the resource Service
+// interfaces, for unit testing code that uses the MessageBird API without
+// sending requests:
+//
+//	func Notify(messages sms.Service) error { ... }
+//
+//	func TestNotify(t *testing.T) {
+//	    messages := fake.NewMessages()
+//	    if err := Notify(messages); err != nil {
+//	        t.Fatal(err)
+//	    }
+//	    if list, _ := messages.List(nil); list.TotalCount != 1 {
+//	        t.Errorf("expected one message to be sent, got %d", list.TotalCount)
+//	    }
+//	}
+//
+// Resources that do not exist are reported with an ErrorResponse matching
+// messagebird.ErrNotFound, as the API does. Setting Err on a fake makes all
+// its methods fail with that error.
+package fake
+
+import (
+	"net/http"
+	"strconv"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// notFound returns the error the API responds with for unknown resources.
+func notFound() error {
+	return messagebird.ErrorResponse{
+		Errors: []messagebird.Error{
+			{Code: 20, Description: "resource not found", Parameter: "id"},
+		},
+		StatusCode: http.StatusNotFound,
+	}
+}
+
+// page returns the bounds of the page of n items starting at offset.
+func page(n, offset, limit int) (int, int) {
+	if offset > n {
+		offset = n
+	}
+	end := n
+	if limit > 0 && offset+limit < n {
+		end = offset + limit
+	}
+	return offset, end
+}
+
+// id returns the ID of the n-th resource created by a fake.
+func id(n int) string {
+	return strconv.Itoa(n)
+}
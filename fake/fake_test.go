@@ -0,0 +1,71 @@
+package fake
+
+import (
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/contact"
+	"github.com/messagebird/go-rest-api/sms"
+)
+
+func TestMessages(t *testing.T) {
+	var messages sms.Service = NewMessages()
+
+	msg, err := messages.Create("TestName", []string{"31612345678", "31687654321"}, "Hello World", nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating message: %s", err)
+	}
+	if msg.Recipients.TotalCount != 2 || msg.TotalParts() != 2 {
+		t.Errorf("expected message to be sent to 2 recipients, got %+v", msg.Recipients)
+	}
+	if _, err := messages.Create("Other", []string{"31612345678"}, "Bye", nil); err != nil {
+		t.Fatalf("unexpected error creating message: %s", err)
+	}
+
+	list, err := messages.List(&sms.ListParams{Originator: "TestName"})
+	if err != nil {
+		t.Fatalf("unexpected error listing messages: %s", err)
+	}
+	if list.TotalCount != 1 || list.Items[0].ID != msg.ID {
+		t.Errorf("expected only the message from TestName, got %+v", list.Items)
+	}
+
+	if _, err := messages.Delete(msg.ID); err != nil {
+		t.Fatalf("unexpected error deleting message: %s", err)
+	}
+	if _, err := messages.Read(msg.ID); !messagebird.IsNotFound(err) {
+		t.Errorf("expected deleted message not to be found, got %v", err)
+	}
+}
+
+func TestContacts(t *testing.T) {
+	var contacts contact.Service = NewContacts()
+
+	ct, err := contacts.Create(&contact.Request{MSISDN: "31612345678", FirstName: "Foo"})
+	if err != nil {
+		t.Fatalf("unexpected error creating contact: %s", err)
+	}
+
+	if _, err := contacts.Update(ct.ID, &contact.Request{LastName: "Bar"}); err != nil {
+		t.Fatalf("unexpected error updating contact: %s", err)
+	}
+	got, err := contacts.Read(ct.ID)
+	if err != nil {
+		t.Fatalf("unexpected error reading contact: %s", err)
+	}
+	if got.MSISDN != 31612345678 || got.FirstName != "Foo" || got.LastName != "Bar" {
+		t.Errorf("got %+v, expected updated contact", got)
+	}
+
+	list, err := contacts.List(&contact.ListOptions{Limit: 10, Offset: 1})
+	if err != nil {
+		t.Fatalf("unexpected error listing contacts: %s", err)
+	}
+	if list.TotalCount != 1 || list.Count != 0 {
+		t.Errorf("expected an empty page of 1 contact, got %+v", list)
+	}
+
+	if err := contacts.Delete("unknown"); !messagebird.IsNotFound(err) {
+		t.Errorf("expected unknown contact not to be found, got %v", err)
+	}
+}
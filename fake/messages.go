@@ -0,0 +1,146 @@
+package fake
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/sms"
+)
+
+// Messages implements sms.Service, keeping created messages in memory.
+type Messages struct {
+	Err error
+
+	mu       sync.Mutex
+	messages []sms.Message
+	created  int
+}
+
+var _ sms.Service = (*Messages)(nil)
+
+// NewMessages returns a fake without messages.
+func NewMessages() *Messages {
+	return &Messages{}
+}
+
+// Create implements sms.Service. The message is stored as sent to all
+// recipients.
+func (m *Messages) Create(originator string, recipients []string, body string, msgParams *sms.Params) (*sms.Message, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	if originator == "" || len(recipients) == 0 || body == "" {
+		return nil, errors.New("originator, recipients and body are required")
+	}
+
+	now := time.Now()
+	msg := sms.Message{
+		Direction:       "mt",
+		Type:            "sms",
+		Originator:      originator,
+		Body:            body,
+		CreatedDatetime: &now,
+		Recipients: messagebird.Recipients{
+			TotalCount:     len(recipients),
+			TotalSentCount: len(recipients),
+		},
+	}
+	if msgParams != nil {
+		if msgParams.Type != "" {
+			msg.Type = msgParams.Type
+		}
+		msg.Reference = msgParams.Reference
+		if !msgParams.ScheduledDatetime.IsZero() {
+			scheduled := msgParams.ScheduledDatetime
+			msg.ScheduledDatetime = &scheduled
+		}
+	}
+	for _, r := range recipients {
+		n, err := strconv.ParseInt(r, 10, 64)
+		if err != nil {
+			return nil, errors.New("recipients must be MSISDNs")
+		}
+		msg.Recipients.Items = append(msg.Recipients.Items, messagebird.Recipient{
+			Recipient:        n,
+			Status:           "sent",
+			StatusDatetime:   &now,
+			MessagePartCount: 1,
+		})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.created++
+	msg.ID = id(m.created)
+	m.messages = append(m.messages, msg)
+	return &msg, nil
+}
+
+// Read implements sms.Service.
+func (m *Messages) Read(id string) (*sms.Message, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, msg := range m.messages {
+		if msg.ID == id {
+			return &msg, nil
+		}
+	}
+	return nil, notFound()
+}
+
+// Delete implements sms.Service.
+func (m *Messages) Delete(id string) (*sms.Message, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, msg := range m.messages {
+		if msg.ID == id {
+			m.messages = append(m.messages[:i], m.messages[i+1:]...)
+			return &msg, nil
+		}
+	}
+	return nil, notFound()
+}
+
+// List implements sms.Service, filtering by originator and type.
+func (m *Messages) List(msgListParams *sms.ListParams) (*sms.MessageList, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	var params sms.ListParams
+	if msgListParams != nil {
+		params = *msgListParams
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matches []sms.Message
+	for _, msg := range m.messages {
+		if params.Originator != "" && msg.Originator != params.Originator {
+			continue
+		}
+		if params.Type != "" && msg.Type != params.Type {
+			continue
+		}
+		matches = append(matches, msg)
+	}
+
+	start, end := page(len(matches), params.Offset, params.Limit)
+	return &sms.MessageList{
+		Offset:     start,
+		Limit:      params.Limit,
+		Count:      end - start,
+		TotalCount: len(matches),
+		Items:      append([]sms.Message(nil), matches[start:end]...),
+	}, nil
+}
@@ -1,6 +1,7 @@
 package group
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -69,6 +70,7 @@ var DefaultListOptions = &ListOptions{
 	Offset: 0,
 }
 
+// Create creates a new group. The Name of request is required.
 func Create(c *messagebird.Client, request *Request) (*Group, error) {
 	if err := validateCreate(request); err != nil {
 		return nil, err
@@ -97,8 +99,11 @@ func Delete(c *messagebird.Client, id string) error {
 }
 
 // List retrieves a paginated list of groups, based on the options provided.
-// It's worth noting DefaultListOptions.
+// DefaultListOptions is used when options is nil.
 func List(c *messagebird.Client, options *ListOptions) (*GroupList, error) {
+	if options == nil {
+		options = DefaultListOptions
+	}
 	query, err := listQuery(options)
 	if err != nil {
 		return nil, err
@@ -112,6 +117,23 @@ func List(c *messagebird.Client, options *ListOptions) (*GroupList, error) {
 	return groupList, nil
 }
 
+// Iterate returns an iterator over all groups, fetching them in pages of
+// options.Limit groups. DefaultListOptions is used when options is nil.
+func Iterate(c *messagebird.Client, options *ListOptions) *messagebird.Iterator[Group] {
+	if options == nil {
+		options = DefaultListOptions
+	}
+	opts := *options
+	return messagebird.NewOffsetIterator(opts.Offset, func(ctx context.Context, offset int) ([]Group, int, error) {
+		opts.Offset = offset
+		list, err := List(c.WithContext(ctx), &opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
 func listQuery(options *ListOptions) (string, error) {
 	if options.Limit < 10 {
 		return "", fmt.Errorf("minimum limit is 10, got %d", options.Limit)
@@ -189,14 +211,18 @@ func addContactsData(contactIDs []string) string {
 	params := make([]string, 0, cap)
 
 	for _, contactID := range contactIDs {
-		params = append(params, "ids[]="+contactID)
+		params = append(params, "ids[]="+url.QueryEscape(contactID))
 	}
 
 	return strings.Join(params, "&")
 }
 
 // ListContacts lists the contacts that are a member of a group.
+// DefaultListOptions is used when options is nil.
 func ListContacts(c *messagebird.Client, groupID string, options *ListOptions) (*contact.ContactList, error) {
+	if options == nil {
+		options = DefaultListOptions
+	}
 	query, err := listQuery(options)
 	if err != nil {
 		return nil, err
@@ -212,6 +238,23 @@ func ListContacts(c *messagebird.Client, groupID string, options *ListOptions) (
 	return contacts, nil
 }
 
+// IterateContacts returns an iterator over all contacts that are a member of
+// a group. DefaultListOptions is used when options is nil.
+func IterateContacts(c *messagebird.Client, groupID string, options *ListOptions) *messagebird.Iterator[contact.Contact] {
+	if options == nil {
+		options = DefaultListOptions
+	}
+	opts := *options
+	return messagebird.NewOffsetIterator(opts.Offset, func(ctx context.Context, offset int) ([]contact.Contact, int, error) {
+		opts.Offset = offset
+		list, err := ListContacts(c.WithContext(ctx), groupID, &opts)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
 // RemoveContact removes the contact from a group. If nil is returned, the
 // operation was successful.
 func RemoveContact(c *messagebird.Client, groupID, contactID string) error {
@@ -96,6 +96,7 @@ func TestListPagination(t *testing.T) {
 		options  *ListOptions
 	}{
 		{"limit=10&offset=0", DefaultListOptions},
+		{"limit=10&offset=0", nil},
 		{"limit=10&offset=25", &ListOptions{10, 25}},
 		{"limit=50&offset=10", &ListOptions{50, 10}},
 	}
@@ -259,3 +260,9 @@ func TestRemoveContact(t *testing.T) {
 
 	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/groups/group-id/contacts/contact-id")
 }
+
+func TestAddContactsData(t *testing.T) {
+	if data := addContactsData([]string{"first", "a&b"}); data != "ids[]=first&ids[]=a%26b" {
+		t.Errorf("got %s, expected ids[]=first&ids[]=a%%26b", data)
+	}
+}
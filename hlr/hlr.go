@@ -3,6 +3,7 @@ package hlr
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api"
@@ -17,12 +18,57 @@ type HLR struct {
 	MSISDN          int
 	Network         int
 	Reference       string
-	Status          string
-	Details         map[string]interface{}
+	Status          string                 // One of the Status values.
+	Details         map[string]interface{} // E.g. "ported" and "roaming".
 	CreatedDatetime *time.Time
 	StatusDatetime  *time.Time
 }
 
+// The statuses of an HLR lookup.
+const (
+	StatusSent    = "sent"    // The lookup is in progress.
+	StatusAbsent  = "absent"  // The number is not reachable.
+	StatusActive  = "active"  // The number is reachable.
+	StatusUnknown = "unknown" // The network did not report the status.
+	StatusFailed  = "failed"  // The lookup failed.
+)
+
+// Done reports whether the lookup is finished. A lookup is StatusSent until
+// the network responds, and then changes once to StatusActive,
+// StatusAbsent, StatusUnknown or StatusFailed.
+func (h *HLR) Done() bool {
+	return h.Status != "" && h.Status != StatusSent
+}
+
+// Ported reports whether the details mark the number as ported to another
+// network.
+func (h *HLR) Ported() bool {
+	return h.detailFlag("ported")
+}
+
+// Roaming reports whether the details mark the number as roaming.
+func (h *HLR) Roaming() bool {
+	return h.detailFlag("roaming")
+}
+
+// detailFlag reports whether the detail key is set to true or a non-zero
+// number. Details of HLR callbacks may be strings, e.g. "1" or "true".
+func (h *HLR) detailFlag(key string) bool {
+	switch v := h.Details[key].(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		return err == nil && n != 0
+	}
+	return false
+}
+
 // HLRList represents a list of HLR requests.
 type HLRList struct {
 	Offset     int
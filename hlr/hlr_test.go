@@ -136,3 +136,16 @@ func TestList(t *testing.T) {
 		assertHLRObject(t, &hlr)
 	}
 }
+
+func TestDetailFlags(t *testing.T) {
+	h := &HLR{Details: map[string]interface{}{"ported": 1.0, "roaming": false}}
+	if !h.Ported() {
+		t.Errorf("Expected HLR to be ported")
+	}
+	if h.Roaming() {
+		t.Errorf("Expected HLR not to be roaming")
+	}
+	if (&HLR{Status: StatusActive}).Ported() {
+		t.Errorf("Expected HLR without details not to be ported")
+	}
+}
@@ -0,0 +1,67 @@
+package messagebird
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// idempotencyKeyHeader is sent with create requests so the API processes
+// them at most once, even if they are retried.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKey returns a shallow copy of the client that sends key as
+// idempotency key with its create (POST) requests, e.g. when sending a
+// message:
+//
+//	msg, err := sms.Create(client.WithIdempotencyKey(key), originator, recipients, body, nil)
+//
+// The key is reused when the request is retried, so a retried create is not
+// processed twice. Use a new key, or a copy of the original client, for each
+// distinct create request.
+func (c *Client) WithIdempotencyKey(key string) *Client {
+	c2 := *c
+	c2.idempotencyKey = key
+	return &c2
+}
+
+// WithDerivedIdempotencyKey returns a shallow copy of the client whose
+// idempotency key is derived from the client's key and part, so each of the
+// requests making up one operation, such as the chunks of a message, gets
+// its own key that is stable when the operation is repeated. It returns c if
+// the client has no idempotency key.
+func (c *Client) WithDerivedIdempotencyKey(part string) *Client {
+	if c.idempotencyKey == "" {
+		return c
+	}
+	return c.WithIdempotencyKey(IdempotencyKey(c.idempotencyKey + ":" + part))
+}
+
+// IdempotencyKey derives an idempotency key from an ID identifying the
+// request in the caller's system, such as an order or notification ID, so
+// the same key is used when the request is made again after a crash.
+func IdempotencyKey(requestID string) string {
+	sum := sha256.Sum256([]byte("messagebird-idempotency-key:" + requestID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// setIdempotencyKey sets the idempotency key header on create requests.
+func (c *Client) setIdempotencyKey(req *http.Request) error {
+	if req.Method != http.MethodPost {
+		return nil
+	}
+
+	key := c.idempotencyKey
+	if key == "" && c.AutoIdempotencyKeys {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return err
+		}
+		key = hex.EncodeToString(b)
+	}
+	if key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+	return nil
+}
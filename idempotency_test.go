@@ -0,0 +1,81 @@
+package messagebird
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithIdempotencyKey(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+
+	key := IdempotencyKey("order-1234")
+	if err := c.WithIdempotencyKey(key).Request(nil, http.MethodPost, ts.URL, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(keys) != 2 || keys[0] != key || keys[1] != key {
+		t.Errorf("expected the POST to be retried with key %s, got %v", key, keys)
+	}
+}
+
+func TestAutoIdempotencyKeys(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.AutoIdempotencyKeys = true
+	for _, method := range []string{http.MethodPost, http.MethodPost, http.MethodGet} {
+		if err := c.Request(nil, method, ts.URL, nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if keys[0] == "" || keys[1] == "" || keys[0] == keys[1] {
+		t.Errorf("expected distinct keys for each create request, got %v", keys[:2])
+	}
+	if keys[2] != "" {
+		t.Errorf("expected no key for GET requests, got %s", keys[2])
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	if IdempotencyKey("order-1234") != IdempotencyKey("order-1234") {
+		t.Errorf("expected keys to be derived deterministically")
+	}
+	if IdempotencyKey("order-1234") == IdempotencyKey("order-1235") {
+		t.Errorf("expected different request IDs to derive different keys")
+	}
+}
+
+func TestWithDerivedIdempotencyKey(t *testing.T) {
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	if c.WithDerivedIdempotencyKey("a") != c {
+		t.Errorf("expected the client to be returned unchanged without idempotency key")
+	}
+
+	c = c.WithIdempotencyKey("order-1234")
+	a, b := c.WithDerivedIdempotencyKey("a"), c.WithDerivedIdempotencyKey("b")
+	if a.idempotencyKey == c.idempotencyKey || a.idempotencyKey == b.idempotencyKey {
+		t.Errorf("got keys %q and %q, expected distinct keys derived from %q", a.idempotencyKey, b.idempotencyKey, c.idempotencyKey)
+	}
+	if a.idempotencyKey != c.WithDerivedIdempotencyKey("a").idempotencyKey {
+		t.Errorf("expected keys to be derived deterministically")
+	}
+}
@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// Platform is the messaging platform of a channel integration.
+type Platform string
+
+const (
+	PlatformWhatsApp  Platform = "whatsapp"
+	PlatformMessenger Platform = "facebook"
+	PlatformTelegram  Platform = "telegram"
+	PlatformInstagram Platform = "instagram"
+)
+
+// Status is the onboarding state of a channel integration.
+type Status string
+
+const (
+	// StatusPending is returned while the integration waits for the
+	// platform, e.g. for WhatsApp to verify the business.
+	StatusPending Status = "pending"
+
+	// StatusActionRequired is returned when the integration can not proceed
+	// until the details listed in Integration.Reason are provided.
+	StatusActionRequired Status = "action_required"
+
+	StatusActive   Status = "active"
+	StatusFailed   Status = "failed"
+	StatusInactive Status = "inactive"
+)
+
+// Integration connects a channel on a messaging platform to the account.
+// ChannelID is set when the integration is active and is used to send
+// messages through the Conversations API.
+type Integration struct {
+	ID        string
+	Platform  Platform
+	Name      string
+	Status    Status
+	Reason    string
+	ChannelID string
+	Settings  map[string]interface{}
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+// CreateIntegrationParams holds the details needed to provision a channel.
+// The settings depend on the platform, e.g. the phone number and business ID
+// for WhatsApp.
+type CreateIntegrationParams struct {
+	Name     string                 `json:"name"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// Ready reports whether the integration completed onboarding, so its channel
+// can be used.
+func (i *Integration) Ready() bool {
+	return i.Status == StatusActive && i.ChannelID != ""
+}
+
+// CreateIntegration starts provisioning a channel on the platform. Poll the
+// returned integration with ReadIntegration until it is Ready.
+func CreateIntegration(c *messagebird.Client, platform Platform, params *CreateIntegrationParams) (*Integration, error) {
+	if platform == "" || params == nil || params.Name == "" {
+		return nil, errors.New("platform and name are required")
+	}
+
+	integration := &Integration{}
+	if err := c.Request(integration, http.MethodPost, integrationsPath(platform, ""), params); err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
+
+// ListIntegrations gets the channel integrations of the account on the
+// platform.
+func ListIntegrations(c *messagebird.Client, platform Platform) ([]*Integration, error) {
+	if platform == "" {
+		return nil, errors.New("platform is required")
+	}
+
+	var integrations []*Integration
+	if err := c.Request(&integrations, http.MethodGet, integrationsPath(platform, ""), nil); err != nil {
+		return nil, err
+	}
+
+	return integrations, nil
+}
+
+// ReadIntegration gets a channel integration, including its onboarding
+// status.
+func ReadIntegration(c *messagebird.Client, platform Platform, id string) (*Integration, error) {
+	if platform == "" || id == "" {
+		return nil, errors.New("platform and id are required")
+	}
+
+	integration := &Integration{}
+	if err := c.Request(integration, http.MethodGet, integrationsPath(platform, id), nil); err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
+
+// DeleteIntegration removes a channel integration. Its channel can no longer
+// be used to send messages.
+func DeleteIntegration(c *messagebird.Client, platform Platform, id string) error {
+	if platform == "" || id == "" {
+		return errors.New("platform and id are required")
+	}
+
+	return c.Request(nil, http.MethodDelete, integrationsPath(platform, id), nil)
+}
+
+// integrationsPath returns the URL of the platform's integrations, or of the
+// single integration with id if it is not empty.
+func integrationsPath(platform Platform, id string) string {
+	p := apiRoot + "/platforms/" + url.PathEscape(string(platform)) + "/integrations"
+	if id != "" {
+		p += "/" + url.PathEscape(id)
+	}
+	return p
+}
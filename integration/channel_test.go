@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestCreateIntegration(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "integrationObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	integration, err := CreateIntegration(client, PlatformWhatsApp, &CreateIntegrationParams{
+		Name:     "Support",
+		Settings: map[string]interface{}{"phoneNumber": "+31612345678"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating Integration: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v2/platforms/whatsapp/integrations")
+	mbtest.AssertTestdata(t, "integrationCreateRequest.json", mbtest.Request.Body)
+
+	if !integration.Ready() {
+		t.Errorf("got integration with status %s and channel %q, expected it to be ready", integration.Status, integration.ChannelID)
+	}
+}
+
+func TestListIntegrations(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "integrationListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	integrations, err := ListIntegrations(client, PlatformWhatsApp)
+	if err != nil {
+		t.Fatalf("unexpected error listing Integrations: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v2/platforms/whatsapp/integrations")
+
+	if len(integrations) != 2 {
+		t.Fatalf("got %d integrations, expected 2", len(integrations))
+	}
+	if i := integrations[1]; i.Status != StatusActionRequired || i.Ready() {
+		t.Errorf("got integration with status %s, expected action_required and not ready", i.Status)
+	}
+}
+
+func TestReadIntegration(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "integrationObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	integration, err := ReadIntegration(client, PlatformWhatsApp, "intid")
+	if err != nil {
+		t.Fatalf("unexpected error reading Integration: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v2/platforms/whatsapp/integrations/intid")
+
+	if integration.ChannelID != "chid" {
+		t.Errorf("got channel %s, expected chid", integration.ChannelID)
+	}
+}
+
+func TestDeleteIntegration(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	if err := DeleteIntegration(client, PlatformWhatsApp, "intid"); err != nil {
+		t.Fatalf("unexpected error deleting Integration: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v2/platforms/whatsapp/integrations/intid")
+}
@@ -0,0 +1,9 @@
+// Package integration provides access to the Integrations API, which manages
+// the configuration of the channels used by the Conversations API, such as
+// WhatsApp message templates.
+package integration
+
+import messagebird "github.com/messagebird/go-rest-api"
+
+// apiRoot is the absolute URL of the Integrations API.
+const apiRoot = messagebird.IntegrationsEndpoint + "/v2"
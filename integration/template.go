@@ -0,0 +1,200 @@
+package integration
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// templatesPath is the path for the WhatsApp Template resource, relative to
+// apiRoot.
+const templatesPath = "platforms/whatsapp/templates"
+
+// TemplateCategory is the category WhatsApp approves a template for.
+type TemplateCategory string
+
+const (
+	TemplateCategoryMarketing      TemplateCategory = "MARKETING"
+	TemplateCategoryUtility        TemplateCategory = "UTILITY"
+	TemplateCategoryAuthentication TemplateCategory = "AUTHENTICATION"
+)
+
+// TemplateStatus is the review status of a template. Only approved templates
+// can be sent.
+type TemplateStatus string
+
+const (
+	TemplateStatusNew             TemplateStatus = "NEW"
+	TemplateStatusPendingApproval TemplateStatus = "PENDING"
+	TemplateStatusApproved        TemplateStatus = "APPROVED"
+	TemplateStatusRejected        TemplateStatus = "REJECTED"
+	TemplateStatusPaused          TemplateStatus = "PAUSED"
+	TemplateStatusDisabled        TemplateStatus = "DISABLED"
+)
+
+// TemplateComponentType is the part of the message a TemplateComponent
+// defines.
+type TemplateComponentType string
+
+const (
+	TemplateComponentTypeHeader  TemplateComponentType = "HEADER"
+	TemplateComponentTypeBody    TemplateComponentType = "BODY"
+	TemplateComponentTypeFooter  TemplateComponentType = "FOOTER"
+	TemplateComponentTypeButtons TemplateComponentType = "BUTTONS"
+)
+
+// TemplateHeaderFormat is the kind of content in a header component.
+type TemplateHeaderFormat string
+
+const (
+	TemplateHeaderFormatText     TemplateHeaderFormat = "TEXT"
+	TemplateHeaderFormatImage    TemplateHeaderFormat = "IMAGE"
+	TemplateHeaderFormatDocument TemplateHeaderFormat = "DOCUMENT"
+	TemplateHeaderFormatVideo    TemplateHeaderFormat = "VIDEO"
+	TemplateHeaderFormatLocation TemplateHeaderFormat = "LOCATION"
+)
+
+// TemplateButtonType is the action of a template button.
+type TemplateButtonType string
+
+const (
+	TemplateButtonTypeQuickReply  TemplateButtonType = "QUICK_REPLY"
+	TemplateButtonTypeURL         TemplateButtonType = "URL"
+	TemplateButtonTypePhoneNumber TemplateButtonType = "PHONE_NUMBER"
+)
+
+// Template is a WhatsApp message template. Templates are identified by their
+// name and language together, as one name has a template per language.
+type Template struct {
+	ID         string              `json:"id,omitempty"`
+	Name       string              `json:"name"`
+	Language   string              `json:"language"`
+	Category   TemplateCategory    `json:"category"`
+	Components []TemplateComponent `json:"components"`
+	Status     TemplateStatus      `json:"status,omitempty"`
+	Reason     string              `json:"rejectedReason,omitempty"`
+	WABAID     string              `json:"wabaId,omitempty"`
+	Namespace  string              `json:"namespace,omitempty"`
+	CreatedAt  *time.Time          `json:"createdAt,omitempty"`
+	UpdatedAt  *time.Time          `json:"updatedAt,omitempty"`
+}
+
+// TemplateComponent defines a part of the message. Placeholders in Text are
+// numbered, e.g. {{1}}, and need an Example when the template is created.
+type TemplateComponent struct {
+	Type    TemplateComponentType `json:"type"`
+	Format  TemplateHeaderFormat  `json:"format,omitempty"` // Headers only.
+	Text    string                `json:"text,omitempty"`
+	Buttons []TemplateButton      `json:"buttons,omitempty"`
+	Example *TemplateExample      `json:"example,omitempty"`
+}
+
+// TemplateButton is a button of a component of type
+// TemplateComponentTypeButtons.
+type TemplateButton struct {
+	Type        TemplateButtonType `json:"type"`
+	Text        string             `json:"text"`
+	URL         string             `json:"url,omitempty"`
+	PhoneNumber string             `json:"phone_number,omitempty"`
+}
+
+// TemplateExample holds sample values WhatsApp uses to review the template.
+type TemplateExample struct {
+	HeaderText []string   `json:"header_text,omitempty"`
+	BodyText   [][]string `json:"body_text,omitempty"`
+	HeaderURL  []string   `json:"header_url,omitempty"` // Media headers only.
+}
+
+// ListTemplatesOptions can be used to set pagination options in
+// ListTemplates.
+type ListTemplatesOptions struct {
+	Limit, Offset int
+}
+
+// CreateTemplate submits a template for review by WhatsApp. The returned
+// template has status TemplateStatusNew until it is reviewed.
+func CreateTemplate(c *messagebird.Client, template *Template) (*Template, error) {
+	if template == nil || template.Name == "" || template.Language == "" {
+		return nil, errors.New("template name and language are required")
+	}
+
+	created := &Template{}
+	if err := c.Request(created, http.MethodPost, apiRoot+"/"+templatesPath, template); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListTemplates gets the templates of the account in all languages. Options
+// may be nil to use the API's default pagination.
+func ListTemplates(c *messagebird.Client, options *ListTemplatesOptions) ([]*Template, error) {
+	query := url.Values{}
+	if options != nil {
+		if options.Limit != 0 {
+			query.Set("limit", strconv.Itoa(options.Limit))
+		}
+		if options.Offset != 0 {
+			query.Set("offset", strconv.Itoa(options.Offset))
+		}
+	}
+
+	var templates []*Template
+	if err := c.Request(&templates, http.MethodGet, apiRoot+"/"+templatesPath+"?"+query.Encode(), nil); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// ReadTemplates gets the templates with the given name, one per language.
+func ReadTemplates(c *messagebird.Client, name string) ([]*Template, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	var templates []*Template
+	if err := c.Request(&templates, http.MethodGet, templatePath(name, ""), nil); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// ReadTemplate gets the template with the given name and language.
+func ReadTemplate(c *messagebird.Client, name, language string) (*Template, error) {
+	if name == "" || language == "" {
+		return nil, errors.New("name and language are required")
+	}
+
+	template := &Template{}
+	if err := c.Request(template, http.MethodGet, templatePath(name, language), nil); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate deletes the template with the given name and language. All
+// languages of the template are deleted when language is empty.
+func DeleteTemplate(c *messagebird.Client, name, language string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, templatePath(name, language), nil)
+}
+
+// templatePath returns the URL of the templates with name, or of the single
+// template in language if it is not empty.
+func templatePath(name, language string) string {
+	p := apiRoot + "/" + templatesPath + "/" + url.PathEscape(name)
+	if language != "" {
+		p += "/" + url.PathEscape(language)
+	}
+	return p
+}
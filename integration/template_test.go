@@ -0,0 +1,133 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func assertTemplateObject(t *testing.T, template *Template) {
+	if template.Name != "order_shipped" || template.Language != "en" {
+		t.Errorf("got template %s in %s, expected order_shipped in en", template.Name, template.Language)
+	}
+	if template.Status != TemplateStatusApproved {
+		t.Errorf("got status %s, expected APPROVED", template.Status)
+	}
+	if len(template.Components) != 3 {
+		t.Fatalf("got %d components, expected 3", len(template.Components))
+	}
+	if h := template.Components[0]; h.Format != TemplateHeaderFormatImage || h.Example == nil || len(h.Example.HeaderURL) != 1 {
+		t.Errorf("got header %+v, expected an image header with an example", h)
+	}
+	if b := template.Components[2]; len(b.Buttons) != 1 || b.Buttons[0].Type != TemplateButtonTypeQuickReply {
+		t.Errorf("got buttons %+v, expected one quick reply", b.Buttons)
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "templateObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	template, err := CreateTemplate(client, &Template{
+		Name:     "order_shipped",
+		Language: "en",
+		Category: TemplateCategoryUtility,
+		Components: []TemplateComponent{
+			{
+				Type:    TemplateComponentTypeBody,
+				Text:    "Hi {{1}}, your order has shipped.",
+				Example: &TemplateExample{BodyText: [][]string{{"Jane"}}},
+			},
+			{
+				Type:    TemplateComponentTypeButtons,
+				Buttons: []TemplateButton{{Type: TemplateButtonTypeQuickReply, Text: "Track"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating Template: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v2/platforms/whatsapp/templates")
+	mbtest.AssertTestdata(t, "templateCreateRequest.json", mbtest.Request.Body)
+	assertTemplateObject(t, template)
+}
+
+func TestCreateTemplateWithoutLanguage(t *testing.T) {
+	client := mbtest.Client(t)
+
+	if _, err := CreateTemplate(client, &Template{Name: "order_shipped"}); err == nil {
+		t.Fatal("expected error creating Template without language, got nil")
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "templateListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	templates, err := ListTemplates(client, &ListTemplatesOptions{Limit: 20})
+	if err != nil {
+		t.Fatalf("unexpected error listing Templates: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v2/platforms/whatsapp/templates")
+	if query := mbtest.Request.URL.RawQuery; query != "limit=20" {
+		t.Fatalf("got %s, expected limit=20", query)
+	}
+
+	if len(templates) != 1 {
+		t.Fatalf("got %d templates, expected 1", len(templates))
+	}
+	assertTemplateObject(t, templates[0])
+}
+
+func TestReadTemplates(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "templateListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := ReadTemplates(client, "order_shipped"); err != nil {
+		t.Fatalf("unexpected error reading Templates: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v2/platforms/whatsapp/templates/order_shipped")
+}
+
+func TestReadTemplate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "templateObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	template, err := ReadTemplate(client, "order_shipped", "en")
+	if err != nil {
+		t.Fatalf("unexpected error reading Template: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v2/platforms/whatsapp/templates/order_shipped/en")
+	assertTemplateObject(t, template)
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	var cases = []struct {
+		name     string
+		language string
+		e        string
+	}{
+		{name: "Single language", language: "en", e: "/v2/platforms/whatsapp/templates/order_shipped/en"},
+		{name: "All languages", language: "", e: "/v2/platforms/whatsapp/templates/order_shipped"},
+	}
+
+	for _, tt := range cases {
+		mbtest.WillReturn([]byte(""), http.StatusNoContent)
+		client := mbtest.Client(t)
+
+		if err := DeleteTemplate(client, "order_shipped", tt.language); err != nil {
+			t.Fatalf("unexpected error deleting Template: %s, test case: %s", err, tt.name)
+		}
+
+		mbtest.AssertEndpointCalled(t, http.MethodDelete, tt.e)
+	}
+}
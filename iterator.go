@@ -0,0 +1,302 @@
+package messagebird
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Iterator iterates over the items of a paginated list, fetching pages as
+// needed. The resource packages provide iterators for their list endpoints:
+//
+//	it := contact.Iterate(client, nil)
+//	for it.Next(ctx) {
+//	    c := it.Value()
+//	    // ...
+//	}
+//	if err := it.Err(); err != nil {
+//	    // handle error
+//	}
+//
+// Iterators over offset paginated lists can be resumed later, e.g. after a
+// restart, with the cursor returned by Cursor:
+//
+//	it := sms.Iterate(client, params)
+//	if err := it.Resume(savedCursor); err != nil {
+//	    // handle error
+//	}
+type Iterator[T any] struct {
+	fetch func(ctx context.Context) ([]T, bool, error)
+
+	// position returns the cursor of the item buffered items before the next
+	// page, and seek moves the next page to a cursor. Both are nil if the
+	// iterator does not support cursors.
+	position func(buffered int) string
+	seek     func(cursor string) error
+
+	// stop cancels the pages being fetched in the background, if any.
+	stop func()
+
+	items  []T
+	value  T
+	more   bool
+	err    error
+	closed bool
+}
+
+// NewIterator returns an iterator over the pages returned by fetch, which
+// returns the next page and whether there are more pages after it. It can be
+// used for cursor based pagination by keeping the cursor in fetch's closure.
+func NewIterator[T any](fetch func(ctx context.Context) (items []T, more bool, err error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, more: true}
+}
+
+// NewOffsetIterator returns an iterator for offset/limit pagination. It calls
+// fetch with the offset of each page, starting at offset, until the total
+// number of items reported by fetch has been reached.
+//
+// Offset iterators support Cursor and Resume.
+func NewOffsetIterator[T any](offset int, fetch func(ctx context.Context, offset int) (items []T, total int, err error)) *Iterator[T] {
+	it := NewIterator(func(ctx context.Context) ([]T, bool, error) {
+		items, total, err := fetch(ctx, offset)
+		if err != nil {
+			return nil, false, err
+		}
+		offset += len(items)
+		return items, len(items) > 0 && offset < total, nil
+	})
+	it.position = func(buffered int) string {
+		return encodeCursor(offsetCursorPrefix + strconv.Itoa(offset-buffered))
+	}
+	it.seek = func(cursor string) error {
+		n, err := parseOffsetCursor(cursor)
+		if err != nil {
+			return err
+		}
+		offset = n
+		return nil
+	}
+	return it
+}
+
+// NewConcurrentOffsetIterator returns an iterator for offset/limit
+// pagination like NewOffsetIterator, which fetches up to concurrency pages
+// at the same time once the first page returned the page size and total
+// number of items. Items are still returned in order. This speeds up
+// exporting large lists, at the cost of fetching pages that may not be used
+// if the iteration is stopped early.
+//
+// Pages are fetched in the background with the values of the context passed
+// to Next, but they are not cancelled with it, so each Next call may use its
+// own deadline. If the context of Next is done while it waits for a page,
+// Next fails with its error. Call Close to cancel the pages being fetched
+// when stopping the iteration early.
+func NewConcurrentOffsetIterator[T any](offset, concurrency int, fetch func(ctx context.Context, offset int) (items []T, total int, err error)) *Iterator[T] {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	type page struct {
+		offset int
+		items  []T
+		err    error
+	}
+	type fetching struct {
+		offset int
+		ch     chan page
+	}
+	// Progress is tracked by the offsets pages were scheduled at rather than
+	// by the items received, as pages may be short when items are deleted
+	// during the iteration. The current page starts at start, has count
+	// items and is followed by the page at end.
+	var (
+		pageSize, total, next int
+		start, count, end     int
+		pending               []fetching
+		background            context.Context
+		cancel                context.CancelFunc
+	)
+	stop := func() {
+		if cancel != nil {
+			cancel()
+		}
+		background, cancel, pending = nil, nil, nil
+	}
+	start, end = offset, offset
+	it := NewIterator(func(ctx context.Context) ([]T, bool, error) {
+		if pageSize == 0 {
+			items, n, err := fetch(ctx, end)
+			if err != nil {
+				return nil, false, err
+			}
+			start, count = end, len(items)
+			end += len(items)
+			pageSize, total, next = len(items), n, end
+			return items, len(items) > 0 && end < total, nil
+		}
+
+		if background == nil && next < total {
+			background, cancel = context.WithCancel(context.Background())
+		}
+		for len(pending) < concurrency && next < total {
+			ch := make(chan page, 1)
+			go func(ctx context.Context, offset int) {
+				items, _, err := fetch(ctx, offset)
+				ch <- page{offset, items, err}
+			}(prefetchContext{background, ctx}, next)
+			pending = append(pending, fetching{next, ch})
+			next += pageSize
+		}
+		if len(pending) == 0 {
+			stop()
+			return nil, false, nil
+		}
+
+		var p page
+		select {
+		case p = <-pending[0].ch:
+		case <-ctx.Done():
+			start, count, end = pending[0].offset, 0, pending[0].offset
+			stop()
+			return nil, false, ctx.Err()
+		}
+		pending = pending[1:]
+		if p.err != nil {
+			start, count, end = p.offset, 0, p.offset
+			stop()
+			return nil, false, p.err
+		}
+		start, count, end = p.offset, len(p.items), p.offset+pageSize
+		if end >= total {
+			stop()
+		}
+		return p.items, end < total, nil
+	})
+	it.stop = stop
+	it.position = func(buffered int) string {
+		if buffered == 0 {
+			return encodeCursor(offsetCursorPrefix + strconv.Itoa(end))
+		}
+		return encodeCursor(offsetCursorPrefix + strconv.Itoa(start+count-buffered))
+	}
+	it.seek = func(cursor string) error {
+		n, err := parseOffsetCursor(cursor)
+		if err != nil {
+			return err
+		}
+		stop()
+		start, count, end, pageSize = n, 0, n, 0
+		return nil
+	}
+	return it
+}
+
+// prefetchContext is the context of a page fetched in the background. It has
+// the values of the context of the Next call that scheduled it, and is done
+// when the iterator stops.
+type prefetchContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c prefetchContext) Value(key interface{}) interface{} {
+	return c.values.Value(key)
+}
+
+// Next advances the iterator to the next item, which is then available
+// through Value. It returns false when there are no more items or fetching a
+// page failed, in which case Err returns the error.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.closed {
+		return false
+	}
+	for len(it.items) == 0 {
+		if !it.more || it.err != nil {
+			return false
+		}
+		it.items, it.more, it.err = it.fetch(ctx)
+	}
+	it.value, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Close stops the iteration: Next returns false afterwards, until the
+// iterator is resumed. It cancels the pages a concurrent iterator is fetching
+// in the background, so call it when stopping such an iteration early:
+//
+//	it := sms.IterateConcurrent(client, params, 4)
+//	defer it.Close()
+func (it *Iterator[T]) Close() {
+	it.closed = true
+	if it.stop != nil {
+		it.stop()
+	}
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// ErrInvalidCursor is returned by Iterator.Resume for cursors that were not
+// returned by Iterator.Cursor.
+var ErrInvalidCursor = errors.New("messagebird: invalid cursor")
+
+// ErrCursorNotSupported is returned by Iterator.Resume for iterators that
+// can not be resumed.
+var ErrCursorNotSupported = errors.New("messagebird: iterator does not support cursors")
+
+// Cursor returns an opaque cursor pointing at the item after the current
+// one, which can be persisted and passed to Resume. It returns the empty
+// string if the iterator does not support cursors.
+//
+// After an error, the cursor points at the first item that could not be
+// fetched, so resuming retries the failed page.
+func (it *Iterator[T]) Cursor() string {
+	if it.position == nil {
+		return ""
+	}
+	return it.position(len(it.items))
+}
+
+// Resume continues the iteration at cursor, which was returned by Cursor of
+// an iterator over the same list with the same parameters. Items created or
+// deleted in the meantime may shift the list, so items can be skipped or
+// returned twice.
+func (it *Iterator[T]) Resume(cursor string) error {
+	if it.seek == nil {
+		return ErrCursorNotSupported
+	}
+	if err := it.seek(cursor); err != nil {
+		return err
+	}
+	var zero T
+	it.items, it.value, it.more, it.err, it.closed = nil, zero, true, nil, false
+	return nil
+}
+
+const offsetCursorPrefix = "offset:"
+
+func encodeCursor(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func parseOffsetCursor(cursor string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	s := string(b)
+	if err != nil || !strings.HasPrefix(s, offsetCursorPrefix) {
+		return 0, ErrInvalidCursor
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(s, offsetCursorPrefix))
+	if err != nil || n < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return n, nil
+}
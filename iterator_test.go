@@ -0,0 +1,346 @@
+package messagebird
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOffsetIterator(t *testing.T) {
+	all := []int{1, 2, 3, 4, 5}
+	var offsets []int
+	it := NewOffsetIterator(0, func(_ context.Context, offset int) ([]int, int, error) {
+		offsets = append(offsets, offset)
+		end := offset + 2
+		if end > len(all) {
+			end = len(all)
+		}
+		return all[offset:end], len(all), nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(got, all) {
+		t.Errorf("got %v, expected %v", got, all)
+	}
+	if !reflect.DeepEqual(offsets, []int{0, 2, 4}) {
+		t.Errorf("got offsets %v, expected [0 2 4]", offsets)
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	calls := 0
+	it := NewIterator(func(context.Context) ([]string, bool, error) {
+		calls++
+		if calls == 1 {
+			return []string{"a"}, true, nil
+		}
+		return nil, false, errFetch
+	})
+
+	ctx := context.Background()
+	if !it.Next(ctx) || it.Value() != "a" {
+		t.Fatalf("expected first item a, got %q", it.Value())
+	}
+	if it.Next(ctx) {
+		t.Fatalf("expected iteration to stop on error")
+	}
+	if it.Err() != errFetch {
+		t.Errorf("got %v, expected %v", it.Err(), errFetch)
+	}
+	if it.Next(ctx) || calls != 2 {
+		t.Errorf("expected no fetches after an error, got %d calls", calls)
+	}
+}
+
+func TestIteratorEmptyPage(t *testing.T) {
+	it := NewOffsetIterator(0, func(context.Context, int) ([]int, int, error) {
+		return nil, 10, nil
+	})
+	if it.Next(context.Background()) {
+		t.Errorf("expected no items for an empty page")
+	}
+}
+
+func TestOffsetIteratorResume(t *testing.T) {
+	all := []int{1, 2, 3, 4, 5}
+	var offsets []int
+	newIterator := func() *Iterator[int] {
+		return NewOffsetIterator(0, func(_ context.Context, offset int) ([]int, int, error) {
+			offsets = append(offsets, offset)
+			end := offset + 2
+			if end > len(all) {
+				end = len(all)
+			}
+			return all[offset:end], len(all), nil
+		})
+	}
+
+	ctx := context.Background()
+	it := newIterator()
+	for i := 0; i < 3; i++ {
+		if !it.Next(ctx) {
+			t.Fatalf("expected item %d, got error %v", i+1, it.Err())
+		}
+	}
+	cursor := it.Cursor()
+
+	offsets = nil
+	it = newIterator()
+	if err := it.Resume(cursor); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got []int
+	for it.Next(ctx) {
+		got = append(got, it.Value())
+	}
+	if !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Errorf("got %v after resuming, expected [4 5]", got)
+	}
+	if !reflect.DeepEqual(offsets, []int{3}) {
+		t.Errorf("got offsets %v after resuming, expected [3]", offsets)
+	}
+
+	if err := newIterator().Resume("not a cursor"); err != ErrInvalidCursor {
+		t.Errorf("got %v, expected %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestIteratorCursorNotSupported(t *testing.T) {
+	it := NewIterator(func(context.Context) ([]int, bool, error) {
+		return nil, false, nil
+	})
+	if it.Cursor() != "" {
+		t.Errorf("got cursor %q, expected none", it.Cursor())
+	}
+	if err := it.Resume("b2Zmc2V0OjA"); err != ErrCursorNotSupported {
+		t.Errorf("got %v, expected %v", err, ErrCursorNotSupported)
+	}
+}
+
+func TestConcurrentOffsetIterator(t *testing.T) {
+	all := make([]int, 95)
+	for i := range all {
+		all[i] = i
+	}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight, fetches := 0, 0, 0
+	it := NewConcurrentOffsetIterator(0, 3, func(_ context.Context, offset int) ([]int, int, error) {
+		mu.Lock()
+		fetches++
+		if inFlight++; inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		// Later pages finish first, so they must be reordered.
+		time.Sleep(time.Duration(100-offset) * 50 * time.Microsecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		end := offset + 10
+		if end > len(all) {
+			end = len(all)
+		}
+		return all[offset:end], len(all), nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(got, all) {
+		t.Errorf("got %v, expected %v", got, all)
+	}
+	if fetches != 10 {
+		t.Errorf("got %d fetches, expected 10", fetches)
+	}
+	if maxInFlight != 3 {
+		t.Errorf("got %d concurrent fetches, expected 3", maxInFlight)
+	}
+}
+
+func TestConcurrentOffsetIteratorError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	it := NewConcurrentOffsetIterator(0, 4, func(_ context.Context, offset int) ([]int, int, error) {
+		if offset == 20 {
+			return nil, 0, errFetch
+		}
+		return make([]int, 10), 100, nil
+	})
+
+	n := 0
+	for it.Next(context.Background()) {
+		n++
+	}
+	if it.Err() != errFetch || n != 20 {
+		t.Errorf("got error %v after %d items, expected %v after 20", it.Err(), n, errFetch)
+	}
+
+	cursor := it.Cursor()
+	if offset, err := parseOffsetCursor(cursor); err != nil || offset != 20 {
+		t.Errorf("got cursor at %d, %v, expected 20", offset, err)
+	}
+}
+
+func TestConcurrentOffsetIteratorShortPages(t *testing.T) {
+	// Pages in the middle of the list are short when items are deleted
+	// during the iteration, here item 5.
+	var mu sync.Mutex
+	newIterator := func() *Iterator[int] {
+		return NewConcurrentOffsetIterator(0, 2, func(_ context.Context, offset int) ([]int, int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			var items []int
+			for i := offset; i < offset+3 && i < 10; i++ {
+				if i != 5 {
+					items = append(items, i)
+				}
+			}
+			return items, 10, nil
+		})
+	}
+
+	ctx := context.Background()
+	var got []int
+	it := newIterator()
+	for it.Next(ctx) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if e := []int{0, 1, 2, 3, 4, 6, 7, 8, 9}; !reflect.DeepEqual(got, e) {
+		t.Errorf("got %v, expected %v", got, e)
+	}
+
+	var cases = []struct {
+		name  string
+		items int
+		e     []int
+	}{
+		{name: "After short page", items: 5, e: []int{6, 7, 8, 9}},
+		{name: "Within later page", items: 6, e: []int{7, 8, 9}},
+		{name: "Last page", items: 8, e: []int{9}},
+	}
+
+	for _, tt := range cases {
+		it := newIterator()
+		for i := 0; i < tt.items; i++ {
+			it.Next(ctx)
+		}
+		cursor := it.Cursor()
+
+		it = newIterator()
+		if err := it.Resume(cursor); err != nil {
+			t.Fatalf("unexpected error: %s, test case: %s", err, tt.name)
+		}
+		var got []int
+		for it.Next(ctx) {
+			got = append(got, it.Value())
+		}
+		if !reflect.DeepEqual(got, tt.e) {
+			t.Errorf("got %v after resuming, expected %v, test case: %s", got, tt.e, tt.name)
+		}
+	}
+}
+
+func TestConcurrentOffsetIteratorPerCallContext(t *testing.T) {
+	type key struct{}
+	all := make([]int, 50)
+	for i := range all {
+		all[i] = i
+	}
+	it := NewConcurrentOffsetIterator(0, 3, func(ctx context.Context, offset int) ([]int, int, error) {
+		if ctx.Value(key{}) != "trace" {
+			return nil, 0, errors.New("missing context value")
+		}
+		select {
+		case <-time.After(time.Millisecond):
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+		return all[offset : offset+10], len(all), nil
+	})
+
+	var got []int
+	for {
+		ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), key{}, "trace"), time.Second)
+		ok := it.Next(ctx)
+		cancel()
+		if !ok {
+			break
+		}
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(got, all) {
+		t.Errorf("got %v, expected %v", got, all)
+	}
+}
+
+func TestConcurrentOffsetIteratorClose(t *testing.T) {
+	cancelled := make(chan int, 3)
+	newIterator := func() *Iterator[int] {
+		return NewConcurrentOffsetIterator(0, 3, func(ctx context.Context, offset int) ([]int, int, error) {
+			if offset <= 10 {
+				return make([]int, 10), 100, nil
+			}
+			<-ctx.Done()
+			cancelled <- offset
+			return nil, 0, ctx.Err()
+		})
+	}
+	waitCancelled := func(n int, name string) {
+		for i := 0; i < n; i++ {
+			select {
+			case <-cancelled:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("got %d cancelled fetches, expected %d, test case: %s", i, n, name)
+			}
+		}
+	}
+
+	it := newIterator()
+	for i := 0; i < 11; i++ {
+		if !it.Next(context.Background()) {
+			t.Fatalf("unexpected end of the iteration: %v", it.Err())
+		}
+	}
+	it.Close()
+	waitCancelled(2, "Close")
+	if it.Next(context.Background()) || it.Err() != nil {
+		t.Errorf("got %v, expected Next to return false without error after Close", it.Err())
+	}
+
+	it = newIterator()
+	for i := 0; i < 20; i++ {
+		it.Next(context.Background())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if it.Next(ctx) || it.Err() != context.DeadlineExceeded {
+		t.Fatalf("got %v, expected %v while waiting for a page", it.Err(), context.DeadlineExceeded)
+	}
+	waitCancelled(3, "Deadline")
+	if offset, err := parseOffsetCursor(it.Cursor()); err != nil || offset != 20 {
+		t.Errorf("got cursor at %d, %v, expected 20", offset, err)
+	}
+}
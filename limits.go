@@ -0,0 +1,122 @@
+package messagebird
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// The limits applied to responses unless they are changed with
+// WithMaxResponseSize and WithMaxJSONDepth.
+const (
+	DefaultMaxResponseSize = 10 << 20 // 10 MiB
+	DefaultMaxJSONDepth    = 64
+)
+
+// ResponseTooLargeError is returned for responses with a body larger than
+// the limit set by WithMaxResponseSize. The request is not retried.
+type ResponseTooLargeError struct {
+	Limit int64 // In bytes.
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("messagebird: response body exceeds %d bytes", e.Limit)
+}
+
+// JSONDepthError is returned for responses with JSON nested deeper than the
+// limit set by WithMaxJSONDepth.
+type JSONDepthError struct {
+	Limit int
+}
+
+func (e *JSONDepthError) Error() string {
+	return fmt.Sprintf("messagebird: response JSON is nested deeper than %d levels", e.Limit)
+}
+
+// WithMaxResponseSize limits the size of response bodies the client reads
+// to n bytes, instead of DefaultMaxResponseSize. Larger responses fail with
+// a *ResponseTooLargeError, e.g. when a misconfigured endpoint returns a
+// file. An n of 0 or less removes the limit.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) {
+		if n <= 0 {
+			n = -1
+		}
+		c.maxResponseSize = n
+	}
+}
+
+// WithMaxJSONDepth limits the nesting of arrays and objects in responses
+// to n levels, instead of DefaultMaxJSONDepth. Responses nested deeper fail
+// with a *JSONDepthError before they are decoded. An n of 0 or less removes
+// the limit.
+func WithMaxJSONDepth(n int) Option {
+	return func(c *Client) {
+		if n <= 0 {
+			n = -1
+		}
+		c.maxJSONDepth = n
+	}
+}
+
+// readBody reads the body of resp up to the client's size limit.
+func (c *Client) readBody(resp *http.Response) ([]byte, error) {
+	limit := c.maxResponseSize
+	if limit == 0 {
+		limit = DefaultMaxResponseSize
+	}
+	if limit < 0 {
+		return ioutil.ReadAll(resp.Body)
+	}
+
+	if resp.ContentLength > limit {
+		return nil, &ResponseTooLargeError{Limit: limit}
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, &ResponseTooLargeError{Limit: limit}
+	}
+	return body, nil
+}
+
+// checkJSONDepth returns a *JSONDepthError if the arrays and objects of the
+// JSON in b are nested deeper than the client's limit. It does not validate
+// the JSON otherwise.
+func (c *Client) checkJSONDepth(b []byte) error {
+	limit := c.maxJSONDepth
+	if limit == 0 {
+		limit = DefaultMaxJSONDepth
+	}
+	if limit < 0 {
+		return nil
+	}
+
+	depth := 0
+	inString, escaped := false, false
+	for _, ch := range b {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			switch ch {
+			case '\\':
+				escaped = true
+			case '"':
+				inString = false
+			}
+		case ch == '"':
+			inString = true
+		case ch == '[' || ch == '{':
+			if depth++; depth > limit {
+				return &JSONDepthError{Limit: limit}
+			}
+		case ch == ']' || ch == '}':
+			depth--
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package messagebird
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func limitServer(body string, attempts *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*attempts++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	attempts := 0
+	ts := limitServer(`{"body":"`+strings.Repeat("a", 100)+`"}`, &attempts)
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithMaxResponseSize(50))
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 3}
+
+	var v map[string]string
+	err := c.Request(&v, http.MethodGet, ts.URL, nil)
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 50 {
+		t.Fatalf("got %v, expected a *ResponseTooLargeError with limit 50", err)
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, expected no retries", attempts)
+	}
+
+	c = New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithMaxResponseSize(0))
+	if err := c.Request(&v, http.MethodGet, ts.URL, nil); err != nil || len(v["body"]) != 100 {
+		t.Errorf("got %v, expected the full response without a limit", err)
+	}
+}
+
+func TestMaxJSONDepth(t *testing.T) {
+	attempts := 0
+	deep := strings.Repeat(`{"a":`, 10) + "1" + strings.Repeat("}", 10)
+	ts := limitServer(deep, &attempts)
+	defer ts.Close()
+
+	var cases = []struct {
+		name string
+		opts []Option
+		e    bool
+	}{
+		{name: "Default limit", e: false},
+		{name: "Exceeded", opts: []Option{WithMaxJSONDepth(9)}, e: true},
+		{name: "At limit", opts: []Option{WithMaxJSONDepth(10)}, e: false},
+		{name: "No limit", opts: []Option{WithMaxJSONDepth(-1)}, e: false},
+	}
+
+	for _, tt := range cases {
+		c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", tt.opts...)
+		var v interface{}
+		err := c.Request(&v, http.MethodGet, ts.URL, nil)
+		var depthErr *JSONDepthError
+		if got := errors.As(err, &depthErr); got != tt.e {
+			t.Errorf("got error %v, expected depth error: %t, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+func TestCheckJSONDepthStrings(t *testing.T) {
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithMaxJSONDepth(2))
+	if err := c.checkJSONDepth([]byte(`{"body":"[[[{{{ \"[[[\" ]]]"}`)); err != nil {
+		t.Errorf("got %v, expected brackets in strings to be ignored", err)
+	}
+	if err := c.checkJSONDepth([]byte(`{"items":[{"a":1}]}`)); err == nil {
+		t.Errorf("expected an error for 3 levels")
+	}
+}
@@ -0,0 +1,191 @@
+package lookup
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/hlr"
+)
+
+// Cache stores the results of lookups by key. Caches must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, if it has not expired.
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{})
+}
+
+// Stats are the cache statistics of a Cached.
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// HitRatio returns the fraction of reads served from the cache.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Cached performs lookups through a cache, so repeatedly validating the same
+// number does not result in paid requests:
+//
+//	lookups := lookup.NewCached(client, lookup.NewMemoryCache(24*time.Hour))
+//	l, err := lookups.Read("31612345678", nil)
+//
+// Results are cached per number and params. Errors are not cached.
+type Cached struct {
+	c     *messagebird.Client
+	cache Cache
+
+	hits   uint64
+	misses uint64
+}
+
+// NewCached returns a Cached sending its requests with c and storing the
+// results in cache.
+func NewCached(c *messagebird.Client, cache Cache) *Cached {
+	return &Cached{c: c, cache: cache}
+}
+
+// Read performs a lookup as Read does, unless the result is cached.
+func (cc *Cached) Read(phoneNumber string, params *Params) (*Lookup, error) {
+	key := cacheKey("lookup", phoneNumber, params)
+	if v, ok := cc.get(key); ok {
+		if l, ok := v.(*Lookup); ok {
+			cp := *l
+			return &cp, nil
+		}
+	}
+
+	l, err := Read(cc.c, phoneNumber, params)
+	if err != nil {
+		return nil, err
+	}
+	cp := *l
+	cc.cache.Set(key, &cp)
+	return l, nil
+}
+
+// ReadHLR performs an HLR lookup as ReadHLR does, unless the result is
+// cached.
+func (cc *Cached) ReadHLR(phoneNumber string, params *Params) (*hlr.HLR, error) {
+	key := cacheKey("hlr", phoneNumber, params)
+	if v, ok := cc.get(key); ok {
+		if h, ok := v.(*hlr.HLR); ok {
+			cp := *h
+			return &cp, nil
+		}
+	}
+
+	h, err := ReadHLR(cc.c, phoneNumber, params)
+	if err != nil {
+		return nil, err
+	}
+	cp := *h
+	cc.cache.Set(key, &cp)
+	return h, nil
+}
+
+// Stats returns the number of cache hits and misses so far.
+func (cc *Cached) Stats() Stats {
+	return Stats{
+		Hits:   atomic.LoadUint64(&cc.hits),
+		Misses: atomic.LoadUint64(&cc.misses),
+	}
+}
+
+func (cc *Cached) get(key string) (interface{}, bool) {
+	v, ok := cc.cache.Get(key)
+	if ok {
+		atomic.AddUint64(&cc.hits, 1)
+	} else {
+		atomic.AddUint64(&cc.misses, 1)
+	}
+	return v, ok
+}
+
+func cacheKey(kind, phoneNumber string, params *Params) string {
+	var p Params
+	if params != nil {
+		p = *params
+	}
+	return strings.Join([]string{kind, phoneNumber, p.CountryCode, p.Reference}, ":")
+}
+
+// MemoryCache is a Cache that keeps values in memory for a fixed duration.
+type MemoryCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	purgeAt int
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// minPurge is the number of entries from which expired entries are removed
+// when a value is set.
+const minPurge = 1024
+
+// NewMemoryCache returns a cache that keeps values for ttl.
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+		purgeAt: minPurge,
+	}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !m.now().Before(e.expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, value interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if len(m.entries) >= m.purgeAt {
+		for k, e := range m.entries {
+			if !now.Before(e.expires) {
+				delete(m.entries, k)
+			}
+		}
+		if m.purgeAt = 2 * len(m.entries); m.purgeAt < minPurge {
+			m.purgeAt = minPurge
+		}
+	}
+	m.entries[key] = cacheEntry{value: value, expires: now.Add(m.ttl)}
+}
+
+// Len returns the number of values in the cache, including those that
+// expired but were not removed yet.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
@@ -0,0 +1,114 @@
+package lookup
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+func cacheServer(t *testing.T, requests *int) (*messagebird.Client, func()) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		file := "testdata/lookupObject.json"
+		if strings.HasSuffix(r.URL.Path, "/hlr") {
+			file = "testdata/lookupHLRObject.json"
+		}
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}))
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	return c, ts.Close
+}
+
+func TestCachedRead(t *testing.T) {
+	var requests int
+	c, stop := cacheServer(t, &requests)
+	defer stop()
+
+	cached := NewCached(c, NewMemoryCache(time.Hour))
+	for i := 0; i < 3; i++ {
+		l, err := cached.Read("31624971134", &Params{CountryCode: "NL"})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if l.CountryCode != "NL" {
+			t.Errorf("got country code %s, expected NL", l.CountryCode)
+		}
+		l.CountryCode = "modified"
+	}
+	if _, err := cached.Read("31624971134", &Params{CountryCode: "BE"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("got %d requests, expected 2", requests)
+	}
+	if s := cached.Stats(); s.Hits != 2 || s.Misses != 2 || s.HitRatio() != 0.5 {
+		t.Errorf("got stats %+v, expected 2 hits and 2 misses", s)
+	}
+}
+
+func TestCachedReadHLR(t *testing.T) {
+	var requests int
+	c, stop := cacheServer(t, &requests)
+	defer stop()
+
+	cached := NewCached(c, NewMemoryCache(time.Hour))
+	for i := 0; i < 2; i++ {
+		if _, err := cached.ReadHLR("31624971134", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	// A lookup of the same number is cached separately.
+	if _, err := cached.Read("31624971134", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("got %d requests, expected 2", requests)
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	now := time.Now()
+	m := NewMemoryCache(time.Minute)
+	m.now = func() time.Time { return now }
+
+	m.Set("key", 1)
+	if v, ok := m.Get("key"); !ok || v != 1 {
+		t.Fatalf("got %v, %v, expected the value", v, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := m.Get("key"); ok {
+		t.Errorf("expected the value to be expired")
+	}
+	if m.Len() != 0 {
+		t.Errorf("got %d entries, expected the expired entry to be removed", m.Len())
+	}
+}
+
+func TestMemoryCachePurge(t *testing.T) {
+	now := time.Now()
+	m := NewMemoryCache(time.Minute)
+	m.now = func() time.Time { return now }
+
+	for i := 0; i < minPurge; i++ {
+		m.Set(string(rune(i)), i)
+	}
+	now = now.Add(time.Hour)
+	m.Set("fresh", 1)
+
+	if m.Len() != 1 {
+		t.Errorf("got %d entries, expected the expired entries to be purged", m.Len())
+	}
+}
@@ -27,8 +27,26 @@ type Lookup struct {
 	HLR           *hlr.HLR
 }
 
+// The types of phone numbers reported by Lookup.
+const (
+	TypeFixedLine         = "fixed line"
+	TypeMobile            = "mobile"
+	TypeFixedLineOrMobile = "fixed line or mobile"
+	TypeTollFree          = "toll free"
+	TypePremiumRate       = "premium rate"
+	TypeSharedCost        = "shared cost"
+	TypeVoIP              = "voip"
+	TypePersonalNumber    = "personal number"
+	TypePager             = "pager"
+	TypeUniversalAccess   = "universal access number"
+	TypeVoiceMail         = "voice mail"
+	TypeUnknown           = "unknown"
+)
+
 // Params provide additional lookup information.
 type Params struct {
+	// CountryCode is the ISO 3166-1 alpha-2 country code used to parse
+	// numbers in national format, e.g. "NL".
 	CountryCode string
 	Reference   string
 }
@@ -47,7 +65,7 @@ const lookupPath = "lookup"
 // Read performs a new lookup for the specified number.
 func Read(c *messagebird.Client, phoneNumber string, params *Params) (*Lookup, error) {
 	urlParams := paramsForLookup(params)
-	path := lookupPath + "/" + phoneNumber + "?" + urlParams.Encode()
+	path := lookupPath + "/" + url.PathEscape(phoneNumber) + "?" + urlParams.Encode()
 
 	lookup := &Lookup{}
 	if err := c.Request(lookup, http.MethodGet, path, nil); err != nil {
@@ -60,7 +78,7 @@ func Read(c *messagebird.Client, phoneNumber string, params *Params) (*Lookup, e
 // CreateHLR creates a new HLR lookup for the specified number.
 func CreateHLR(c *messagebird.Client, phoneNumber string, params *Params) (*hlr.HLR, error) {
 	requestData := requestDataForLookup(params)
-	path := lookupPath + "/" + phoneNumber + "/" + hlrPath
+	path := lookupPath + "/" + url.PathEscape(phoneNumber) + "/" + hlrPath
 
 	hlr := &hlr.HLR{}
 	if err := c.Request(hlr, http.MethodPost, path, requestData); err != nil {
@@ -73,7 +91,7 @@ func CreateHLR(c *messagebird.Client, phoneNumber string, params *Params) (*hlr.
 // ReadHLR performs a HLR lookup for the specified number.
 func ReadHLR(c *messagebird.Client, phoneNumber string, params *Params) (*hlr.HLR, error) {
 	urlParams := paramsForLookup(params)
-	path := lookupPath + "/" + phoneNumber + "/" + hlrPath + "?" + urlParams.Encode()
+	path := lookupPath + "/" + url.PathEscape(phoneNumber) + "/" + hlrPath + "?" + urlParams.Encode()
 
 	hlr := &hlr.HLR{}
 	if err := c.Request(hlr, http.MethodGet, path, nil); err != nil {
@@ -96,3 +96,17 @@ func TestCreateHLR(t *testing.T) {
 
 	checkHLR(t, hlr)
 }
+
+func TestReadEscapesPhoneNumber(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "lookupObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := Read(client, "06 24/971134", &Params{CountryCode: "NL"}); err != nil {
+		t.Fatalf("Didn't expect error while doing the lookup: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/lookup/06%2024%2F971134")
+	if q := mbtest.Request.URL.Query().Get("countryCode"); q != "NL" {
+		t.Errorf("Unexpected countryCode: %s, expected NL", q)
+	}
+}
@@ -0,0 +1,175 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// ManyOptions configure Many.
+type ManyOptions struct {
+	Params *Params // Applied to all lookups.
+
+	// Concurrency is the maximum number of lookups sent at the same time.
+	// It defaults to 4.
+	Concurrency int
+
+	// RateLimitDelay is the delay after a lookup is rate limited, during
+	// which no lookups are sent. It doubles for each time the same lookup is
+	// rate limited, up to 3 times, and defaults to 1 second.
+	RateLimitDelay time.Duration
+}
+
+// Result is the result of the lookup of a number by Many. Either Lookup or
+// Err is set.
+type Result struct {
+	Number string
+	Lookup *Lookup
+	Err    error
+}
+
+// Results are the results of Many, in the order of the numbers.
+type Results []*Result
+
+// Lookups returns the successful lookups by number.
+func (rs Results) Lookups() map[string]*Lookup {
+	lookups := make(map[string]*Lookup)
+	for _, r := range rs {
+		if r.Err == nil {
+			lookups[r.Number] = r.Lookup
+		}
+	}
+	return lookups
+}
+
+// Failed returns the numbers that could not be looked up, and the errors
+// they failed with.
+func (rs Results) Failed() map[string]error {
+	failed := make(map[string]error)
+	for _, r := range rs {
+		if r.Err != nil {
+			failed[r.Number] = r.Err
+		}
+	}
+	return failed
+}
+
+// Err returns the error of the first lookup that failed, or nil if all
+// numbers were looked up.
+func (rs Results) Err() error {
+	for _, r := range rs {
+		if r.Err != nil {
+			return r.Err
+		}
+	}
+	return nil
+}
+
+// maxRateLimitRetries is the number of times Many retries a rate limited
+// lookup.
+const maxRateLimitRetries = 3
+
+// Many looks up numbers concurrently, e.g. to validate the phone numbers of
+// imported contacts. A failed lookup does not prevent the others, so check
+// the Err of the results; numbers that are not valid phone numbers fail with
+// an ErrorResponse. Numbers occurring more than once are looked up once.
+// Options may be nil.
+//
+// Lookups are rate limited by the client's RateLimiter, if any. Rate limited
+// lookups pause all lookups and are retried. When the context of the client
+// is done, the remaining numbers fail with its error.
+func Many(c *messagebird.Client, numbers []string, options *ManyOptions) Results {
+	var o ManyOptions
+	if options != nil {
+		o = *options
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.RateLimitDelay <= 0 {
+		o.RateLimitDelay = time.Second
+	}
+
+	results := make(Results, len(numbers))
+	unique := make(map[string]*Result)
+	var pending []*Result
+	for i, n := range numbers {
+		r, ok := unique[n]
+		if !ok {
+			r = &Result{Number: n}
+			unique[n] = r
+			pending = append(pending, r)
+		}
+		results[i] = r
+	}
+
+	p := &pause{}
+	ctx := c.Context()
+	sem := make(chan struct{}, o.Concurrency)
+	var wg sync.WaitGroup
+	for _, r := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(r *Result) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r.Lookup, r.Err = lookupRetrying(ctx, c, r.Number, &o, p)
+		}(r)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func lookupRetrying(ctx context.Context, c *messagebird.Client, number string, o *ManyOptions, p *pause) (*Lookup, error) {
+	for attempt := 0; ; attempt++ {
+		if err := p.wait(ctx); err != nil {
+			return nil, err
+		}
+		l, err := Read(c, number, o.Params)
+		if err == nil || !errors.Is(err, messagebird.ErrRateLimited) || attempt == maxRateLimitRetries {
+			return l, err
+		}
+		p.extend(o.RateLimitDelay << attempt)
+	}
+}
+
+// pause holds off the lookups of Many after one was rate limited.
+type pause struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (p *pause) extend(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until := time.Now().Add(d); until.After(p.until) {
+		p.until = until
+	}
+}
+
+// wait waits until the pause is over, or returns the context's error when
+// ctx is done.
+func (p *pause) wait(ctx context.Context) error {
+	p.mu.Lock()
+	d := time.Until(p.until)
+	p.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,99 @@
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+func manyServer(t *testing.T, handle func(number string, attempt int) (int, string)) (*messagebird.Client, map[string]int, func()) {
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		number := strings.TrimPrefix(r.URL.Path, "/lookup/")
+		mu.Lock()
+		attempts[number]++
+		attempt := attempts[number]
+		mu.Unlock()
+
+		status, body := handle(number, attempt)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	return c, attempts, ts.Close
+}
+
+func TestMany(t *testing.T) {
+	c, attempts, stop := manyServer(t, func(number string, attempt int) (int, string) {
+		switch {
+		case number == "invalid":
+			return http.StatusUnprocessableEntity, `{"errors":[{"code":21,"description":"Bad request (phone number has unknown format)","parameter":"phoneNumber"}]}`
+		case number == "31612345678" && attempt == 1:
+			return http.StatusTooManyRequests, `{"errors":[{"code":0,"description":"Too many requests"}]}`
+		}
+		return http.StatusOK, fmt.Sprintf(`{"countryCode":"NL","phoneNumber":%s,"type":"mobile"}`, number)
+	})
+	defer stop()
+
+	numbers := []string{"31612345678", "invalid", "31687654321", "31612345678"}
+	results := Many(c, numbers, &ManyOptions{Concurrency: 2, RateLimitDelay: time.Millisecond})
+
+	if len(results) != 4 || results[0] != results[3] {
+		t.Fatalf("got %d results, expected 4 with a shared result for the duplicate", len(results))
+	}
+	if attempts["31612345678"] != 2 || attempts["31687654321"] != 1 {
+		t.Errorf("got attempts %v, expected a retry of the rate limited lookup only", attempts)
+	}
+
+	lookups := results.Lookups()
+	if len(lookups) != 2 || lookups["31612345678"].PhoneNumber != 31612345678 || lookups["31687654321"].Type != TypeMobile {
+		t.Errorf("got lookups %v, expected both valid numbers", lookups)
+	}
+	failed := results.Failed()
+	if _, ok := failed["invalid"]; len(failed) != 1 || !ok {
+		t.Errorf("got failed %v, expected only the invalid number", failed)
+	}
+	if err, ok := results.Err().(messagebird.ErrorResponse); !ok || err.Errors[0].Code != 21 {
+		t.Errorf("got %v, expected the error of the invalid number", results.Err())
+	}
+}
+
+func TestManyRateLimitExhausted(t *testing.T) {
+	c, attempts, stop := manyServer(t, func(string, int) (int, string) {
+		return http.StatusTooManyRequests, `{"errors":[]}`
+	})
+	defer stop()
+
+	results := Many(c, []string{"31612345678"}, &ManyOptions{RateLimitDelay: time.Millisecond})
+	if err := results.Err(); err == nil || attempts["31612345678"] != maxRateLimitRetries+1 {
+		t.Errorf("got %v after %d attempts, expected an error after %d", err, attempts["31612345678"], maxRateLimitRetries+1)
+	}
+}
+
+func TestManyContextDone(t *testing.T) {
+	c, attempts, stop := manyServer(t, func(number string, _ int) (int, string) {
+		return http.StatusOK, fmt.Sprintf(`{"phoneNumber":%s}`, number)
+	})
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := Many(c.WithContext(ctx), []string{"31612345678", "31687654321"}, nil)
+	for _, r := range results {
+		if r.Err != context.Canceled {
+			t.Errorf("got %v for %s, expected %v", r.Err, r.Number, context.Canceled)
+		}
+	}
+	if len(attempts) != 0 {
+		t.Errorf("got requests %v, expected none", attempts)
+	}
+}
@@ -0,0 +1,146 @@
+// Package media uploads files to MessageBird, e.g. to send them in
+// Conversations messages, and downloads the media of received messages.
+// Files are streamed rather than buffered in memory.
+package media
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// apiRoot is the absolute URL of the Files API.
+const apiRoot = messagebird.MessagingEndpoint + "/v1"
+
+// path is the path for the File resource, relative to apiRoot.
+const path = "files"
+
+// File is an uploaded file.
+type File struct {
+	ID string
+}
+
+// URL returns the URL of the uploaded file, which can be used as the media URL
+// of a message.
+func (f *File) URL() string {
+	return apiRoot + "/" + path + "/" + url.PathEscape(f.ID)
+}
+
+// Content is a downloaded file. It must be closed.
+type Content struct {
+	io.ReadCloser
+
+	Type   string // The Content-Type of the file, e.g. image/jpeg.
+	Length int64  // -1 if unknown.
+}
+
+// Upload uploads the file read from r, which has the given MIME type, e.g.
+// image/png.
+func Upload(c *messagebird.Client, r io.Reader, contentType string) (*File, error) {
+	if contentType == "" {
+		return nil, errors.New("contentType is required")
+	}
+
+	req, err := http.NewRequestWithContext(c.Context(), http.MethodPost, c.ResolveURL(apiRoot+"/"+path), r)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SetHeaders(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.Doer().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, messagebird.ResponseError(resp, b)
+	}
+
+	file := &File{}
+	if err := json.Unmarshal(b, file); err != nil {
+		return nil, fmt.Errorf("could not decode response JSON, %s: %v", string(b), err)
+	}
+
+	return file, nil
+}
+
+// Download streams the file at mediaURL, e.g. the URL of an image received in
+// a Conversations message. The client only authenticates https requests to
+// MessageBird hosts, so other URLs are downloaded anonymously and the access
+// key is never sent in cleartext.
+func Download(c *messagebird.Client, mediaURL string) (*Content, error) {
+	u, err := url.Parse(mediaURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported media URL scheme: %q", u.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(c.Context(), http.MethodGet, c.ResolveURL(mediaURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "https" && isMessageBirdHost(u.Hostname()) {
+		if err := c.SetHeaders(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.Doer().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, messagebird.ResponseError(resp, b)
+	}
+
+	return &Content{
+		ReadCloser: resp.Body,
+		Type:       resp.Header.Get("Content-Type"),
+		Length:     resp.ContentLength,
+	}, nil
+}
+
+// DownloadFile streams an uploaded file.
+func DownloadFile(c *messagebird.Client, id string) (*Content, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+	return Download(c, (&File{ID: id}).URL())
+}
+
+// WriteTo writes the file at mediaURL to w, without buffering it in memory,
+// and returns the number of bytes written.
+func WriteTo(c *messagebird.Client, mediaURL string, w io.Writer) (int64, error) {
+	content, err := Download(c, mediaURL)
+	if err != nil {
+		return 0, err
+	}
+	defer content.Close()
+	return io.Copy(w, content)
+}
+
+// isMessageBirdHost reports whether host belongs to MessageBird, so the
+// client's credentials may be sent to it.
+func isMessageBirdHost(host string) bool {
+	return host == "messagebird.com" || strings.HasSuffix(host, ".messagebird.com")
+}
@@ -0,0 +1,153 @@
+package media
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestUpload(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"fileid"}`), http.StatusOK)
+	client := mbtest.Client(t)
+
+	file, err := Upload(client, strings.NewReader("png data"), "image/png")
+	if err != nil {
+		t.Fatalf("unexpected error uploading file: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/files")
+	if mbtest.Request.ContentType != "image/png" {
+		t.Errorf("got content type %s, expected image/png", mbtest.Request.ContentType)
+	}
+	if string(mbtest.Request.Body) != "png data" {
+		t.Errorf("got body %q, expected png data", mbtest.Request.Body)
+	}
+
+	if file.ID != "fileid" {
+		t.Errorf("got ID %s, expected fileid", file.ID)
+	}
+	if u := file.URL(); u != "https://messaging.messagebird.com/v1/files/fileid" {
+		t.Errorf("got URL %s, expected https://messaging.messagebird.com/v1/files/fileid", u)
+	}
+}
+
+func TestUploadError(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"errors":[{"code":9,"description":"unsupported file type"}]}`), http.StatusUnprocessableEntity)
+	client := mbtest.Client(t)
+
+	_, err := Upload(client, strings.NewReader("data"), "application/x-unknown")
+	errResp, ok := err.(messagebird.ErrorResponse)
+	if !ok {
+		t.Fatalf("got error %v, expected an ErrorResponse", err)
+	}
+	if errResp.StatusCode != http.StatusUnprocessableEntity || len(errResp.Errors) != 1 {
+		t.Errorf("got %+v, expected one error with status 422", errResp)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	mbtest.WillReturn([]byte("media data"), http.StatusOK)
+	client := mbtest.Client(t)
+
+	var buf bytes.Buffer
+	n, err := WriteTo(client, "https://media.messagebird.com/v1/media/mediaid", &buf)
+	if err != nil {
+		t.Fatalf("unexpected error downloading media: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/media/mediaid")
+	if n != 10 || buf.String() != "media data" {
+		t.Errorf("got %d bytes %q, expected media data", n, buf.String())
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	mbtest.WillReturn([]byte("file data"), http.StatusOK)
+	client := mbtest.Client(t)
+
+	content, err := DownloadFile(client, "fileid")
+	if err != nil {
+		t.Fatalf("unexpected error downloading file: %s", err)
+	}
+	defer content.Close()
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/files/fileid")
+	if content.Type != "application/json" {
+		t.Errorf("got type %s, expected the server's application/json", content.Type)
+	}
+}
+
+func TestDownloadNotFound(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNotFound)
+	client := mbtest.Client(t)
+
+	if _, err := DownloadFile(client, "fileid"); err == nil {
+		t.Fatal("expected error downloading missing file, got nil")
+	}
+}
+
+func TestDownloadCredentials(t *testing.T) {
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte("data"))
+	}))
+	defer ts.Close()
+
+	client := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	content, err := Download(client, ts.URL+"/image.jpg")
+	if err != nil {
+		t.Fatalf("unexpected error downloading media: %s", err)
+	}
+	content.Close()
+
+	if auth != "" {
+		t.Errorf("got Authorization %q for a host outside MessageBird, expected none", auth)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestDownloadCredentialsScheme(t *testing.T) {
+	var auth string
+	client := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		auth = r.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("data")), Request: r}, nil
+	})))
+
+	var cases = []struct {
+		name string
+		url  string
+		e    string
+	}{
+		{name: "https", url: "https://media.messagebird.com/v1/media/abc", e: "AccessKey test_gshuPaZoeEG6ovbc8M79w0QyM"},
+		{name: "http", url: "http://media.messagebird.com/v1/media/abc", e: ""},
+	}
+
+	for _, tt := range cases {
+		auth = ""
+		content, err := Download(client, tt.url)
+		if err != nil {
+			t.Fatalf("unexpected error downloading media: %s, test case: %s", err, tt.name)
+		}
+		content.Close()
+
+		if auth != tt.e {
+			t.Errorf("got Authorization %q, expected %q, test case: %s", auth, tt.e, tt.name)
+		}
+	}
+}
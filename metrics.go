@@ -0,0 +1,65 @@
+package messagebird
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// RequestMetrics describes an attempt to send a request, for recording
+// metrics such as request counts, error rates and latency per endpoint.
+// It is passed to the client's ObserveRequest hook. With Prometheus, for
+// example:
+//
+//	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+//	    Name: "messagebird_request_duration_seconds",
+//	}, []string{"method", "endpoint", "status"})
+//	client.ObserveRequest = func(_ context.Context, m messagebird.RequestMetrics) {
+//	    status := strconv.Itoa(m.Status)
+//	    latency.WithLabelValues(m.Method, m.Endpoint, status).Observe(m.Latency.Seconds())
+//	}
+type RequestMetrics struct {
+	Method string
+
+	// Endpoint is the host and path of the request with IDs replaced by
+	// ":id", e.g. "rest.messagebird.com/messages/:id", so it can be used as
+	// a metric label without creating a series per resource.
+	Endpoint string
+
+	Status  int           // The response status code, or zero on error.
+	Latency time.Duration // The time until the response body was read.
+	Err     error         // The error that prevented a response, if any.
+	Attempt int           // The attempt number, starting at 1 and increased on retries.
+}
+
+// idSegment matches path segments holding resource IDs: hexadecimal IDs,
+// UUIDs and numbers.
+var idSegment = regexp.MustCompile(`^(?:[0-9a-fA-F]{32}|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|\d+)$`)
+
+// endpoint returns the host and path of req with IDs replaced by ":id".
+func endpoint(req *http.Request) string {
+	segs := strings.Split(req.URL.Path, "/")
+	for i, seg := range segs {
+		if idSegment.MatchString(seg) {
+			segs[i] = ":id"
+		}
+	}
+	return req.URL.Host + strings.Join(segs, "/")
+}
+
+// observeRequest passes the metrics of an attempt to the ObserveRequest hook.
+func (c *Client) observeRequest(ctx context.Context, req *http.Request, resp *http.Response, err error, latency time.Duration, attempt int) {
+	m := RequestMetrics{
+		Method:   req.Method,
+		Endpoint: endpoint(req),
+		Latency:  latency,
+		Err:      err,
+		Attempt:  attempt,
+	}
+	if resp != nil {
+		m.Status = resp.StatusCode
+	}
+	c.ObserveRequest(ctx, m)
+}
@@ -0,0 +1,66 @@
+package messagebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObserveRequest(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	var metrics []RequestMetrics
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	c.ObserveRequest = func(_ context.Context, m RequestMetrics) {
+		metrics = append(metrics, m)
+	}
+
+	if err := c.Request(nil, http.MethodDelete, ts.URL+"/messages/6fe65f90454aa61536e6a88b88972670", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(metrics) != 2 {
+		t.Fatalf("got %d metrics, expected one per attempt", len(metrics))
+	}
+	for i, status := range []int{http.StatusBadGateway, http.StatusNoContent} {
+		m := metrics[i]
+		if m.Attempt != i+1 || m.Status != status || m.Method != http.MethodDelete || m.Latency <= 0 {
+			t.Errorf("got %+v for attempt %d", m, i+1)
+		}
+		if !strings.HasSuffix(m.Endpoint, "/messages/:id") {
+			t.Errorf("got endpoint %s, expected IDs to be replaced", m.Endpoint)
+		}
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	var cases = []struct {
+		url string
+		e   string
+	}{
+		{url: "https://rest.messagebird.com/messages", e: "rest.messagebird.com/messages"},
+		{url: "https://rest.messagebird.com/groups/61afc0531573b08ddbe36e1c85602827/contacts?ids[]=1", e: "rest.messagebird.com/groups/:id/contacts"},
+		{url: "https://voice.messagebird.com/calls/f1aa71c0-8f2a-4fe8-b5ef-9a330454ef58/legs", e: "voice.messagebird.com/calls/:id/legs"},
+		{url: "https://rest.messagebird.com/lookup/31612345678/hlr", e: "rest.messagebird.com/lookup/:id/hlr"},
+	}
+
+	for _, tt := range cases {
+		req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+		if got := endpoint(req); got != tt.e {
+			t.Errorf("got %s, expected %s", got, tt.e)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package messagebird
+
+import "net/http"
+
+// Doer sends HTTP requests. It is implemented by *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer that sends the client's requests, e.g. to stamp
+// headers onto each request:
+//
+//	client.Use(func(next messagebird.Doer) messagebird.Doer {
+//	    return messagebird.DoerFunc(func(req *http.Request) (*http.Response, error) {
+//	        req.Header.Set("X-Tenant", tenant)
+//	        return next.Do(req)
+//	    })
+//	})
+type Middleware func(next Doer) Doer
+
+// Use adds middleware that runs for every request sent by the client,
+// including each retry. The first middleware added is the outermost.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware[:len(c.middleware):len(c.middleware)], mw...)
+}
+
+// Doer returns the client's HTTP client wrapped in its middleware. It is for
+// internal use only and unstable.
+func (c *Client) Doer() Doer {
+	var d Doer = c.httpClient()
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		d = c.middleware[i](d)
+	}
+	return d
+}
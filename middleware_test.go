@@ -0,0 +1,48 @@
+package messagebird
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestUse(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, "server "+r.Header.Get("X-Stamp"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	stamp := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				got = append(got, name)
+				req.Header.Set("X-Stamp", req.Header.Get("X-Stamp")+name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.Use(stamp("a"), stamp("b"))
+	if err := c.Request(nil, http.MethodGet, ts.URL+"/balance", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if e := []string{"a", "b", "server ab"}; !reflect.DeepEqual(got, e) {
+		t.Errorf("got %q, expected %q", got, e)
+	}
+}
+
+func TestUseDoesNotAffectCopies(t *testing.T) {
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.Use(func(next Doer) Doer { return next })
+	c2 := c.WithIdempotencyKey("key")
+	c2.Use(func(next Doer) Doer { return next })
+
+	if len(c.middleware) != 1 || len(c2.middleware) != 2 {
+		t.Errorf("got %d and %d middleware, expected 1 and 2", len(c.middleware), len(c2.middleware))
+	}
+}
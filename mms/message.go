@@ -2,8 +2,10 @@ package mms
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +27,24 @@ type Message struct {
 	Recipients        messagebird.Recipients
 }
 
+// MessageList represents a list of MMS Messages.
+type MessageList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Links      map[string]*string
+	Items      []Message
+}
+
+// ListOptions can be used to set pagination options in List().
+type ListOptions struct {
+	Limit, Offset int
+}
+
+// MaxMediaUrls is the maximum number of media attachments of a message.
+const MaxMediaUrls = 10
+
 // Params represents the parameters that can be supplied when creating
 // a request.
 type Params struct {
@@ -48,6 +68,35 @@ func Read(c *messagebird.Client, id string) (*Message, error) {
 	return mmsMessage, nil
 }
 
+// Delete deletes an existing MmsMessage, which cancels sending it if it is
+// scheduled.
+func Delete(c *messagebird.Client, id string) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, path+"/"+id, nil)
+}
+
+// List retrieves the MMS messages of the user. Options may be nil to use the
+// API's defaults.
+func List(c *messagebird.Client, options *ListOptions) (*MessageList, error) {
+	query := url.Values{}
+	if options != nil {
+		if options.Limit != 0 {
+			query.Set("limit", strconv.Itoa(options.Limit))
+		}
+		query.Set("offset", strconv.Itoa(options.Offset))
+	}
+
+	messageList := &MessageList{}
+	if err := c.Request(messageList, http.MethodGet, path+"?"+query.Encode(), nil); err != nil {
+		return nil, err
+	}
+
+	return messageList, nil
+}
+
 // Create creates a new MMS message for one or more recipients.
 func Create(c *messagebird.Client, originator string, recipients []string, msgParams *Params) (*Message, error) {
 	params, err := paramsForMessage(msgParams)
@@ -71,9 +120,12 @@ func Create(c *messagebird.Client, originator string, recipients []string, msgPa
 func paramsForMessage(params *Params) (*url.Values, error) {
 	urlParams := &url.Values{}
 
-	if params.Body == "" && params.MediaUrls == nil {
+	if params == nil || params.Body == "" && params.MediaUrls == nil {
 		return nil, errors.New("Body or MediaUrls is required")
 	}
+	if len(params.MediaUrls) > MaxMediaUrls {
+		return nil, fmt.Errorf("at most %d MediaUrls are allowed, got %d", MaxMediaUrls, len(params.MediaUrls))
+	}
 	if params.Body != "" {
 		urlParams.Set("body", params.Body)
 	}
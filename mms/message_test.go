@@ -137,3 +137,41 @@ func TestCreateWithEmptyParams(t *testing.T) {
 		t.Errorf("Unexpected error message, I got %s", err)
 	}
 }
+
+func TestCreateWithTooManyMediaUrls(t *testing.T) {
+	client := mbtest.Client(t)
+
+	params := &Params{MediaUrls: make([]string, MaxMediaUrls+1)}
+	if _, err := Create(client, "TestName", []string{"31612345678"}, params); err == nil {
+		t.Fatalf("Expected error to be returned, instead I got nil")
+	}
+}
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "mmsMessageListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	list, err := List(client, &ListOptions{Limit: 20})
+	if err != nil {
+		t.Fatalf("Didn't expect error while listing MMS messages: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/mms")
+	if list.TotalCount != 1 || len(list.Items) != 1 {
+		t.Fatalf("Unexpected total count %d with %d items, expected 1", list.TotalCount, len(list.Items))
+	}
+	if list.Items[0].ID != "6d9e7100b1f9406c81a3c303c30ccf05" {
+		t.Errorf("Unexpected message id: %s", list.Items[0].ID)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	if err := Delete(client, "6d9e7100b1f9406c81a3c303c30ccf05"); err != nil {
+		t.Fatalf("Didn't expect error while deleting MMS message: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/mms/6d9e7100b1f9406c81a3c303c30ccf05")
+}
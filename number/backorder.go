@@ -0,0 +1,229 @@
+package number
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// backorderPath is the path for the Backorder resource, relative to apiRoot.
+const backorderPath = "backorders"
+
+// The statuses of a backorder.
+const (
+	BackorderStatusPending   = "pending"
+	BackorderStatusBlocked   = "blocked" // Waiting for documents or end-user details.
+	BackorderStatusComplete  = "complete"
+	BackorderStatusCancelled = "cancelled"
+)
+
+// Backorder is an order for numbers that can't be purchased directly, e.g.
+// because the country requires documentation of the end user. The numbers
+// are provisioned when the backorder completes.
+type Backorder struct {
+	ID          string
+	ProductID   int
+	Country     string
+	Prefix      string
+	Quantity    int
+	Status      string
+	ReasonCodes []string // Why the backorder is blocked or cancelled.
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
+
+// BackorderParams describes the numbers to backorder. The product ID is that
+// of the number type in the country, as listed by the Numbers API.
+type BackorderParams struct {
+	ProductID int    `json:"productID"`
+	Prefix    string `json:"prefix"`
+	Quantity  int    `json:"quantity"`
+}
+
+// BackorderDocument is a document required for a backorder.
+type BackorderDocument struct {
+	ID          string
+	Name        string
+	Description string
+	Status      string
+}
+
+// BackorderDocumentUpload is a document uploaded for a backorder.
+type BackorderDocumentUpload struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mimeType"`
+
+	// Content is the raw file, which is sent encoded with base64.
+	Content []byte `json:"content"`
+}
+
+// BackorderEndUserDetail is a detail of the end user of the numbers
+// required for a backorder, e.g. their address.
+type BackorderEndUserDetail struct {
+	ID     string
+	Name   string
+	Status string
+}
+
+// CreateBackorder orders numbers for a product that requires documentation.
+// Poll the backorder with ReadBackorder or WaitForBackorder, and provide
+// what it requires with UploadBackorderDocument and
+// UpdateBackorderEndUserDetails.
+func CreateBackorder(c *messagebird.Client, params *BackorderParams) (*Backorder, error) {
+	if params == nil || params.ProductID == 0 || params.Prefix == "" || params.Quantity <= 0 {
+		return nil, errors.New("productID, prefix and quantity are required")
+	}
+
+	backorder := &Backorder{}
+	if err := c.Request(backorder, http.MethodPost, apiRoot+"/"+backorderPath, params); err != nil {
+		return nil, err
+	}
+
+	return backorder, nil
+}
+
+// ReadBackorder retrieves a backorder, e.g. to poll its status.
+func ReadBackorder(c *messagebird.Client, id string) (*Backorder, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	backorder := &Backorder{}
+	if err := c.Request(backorder, http.MethodGet, backorderURL(id, ""), nil); err != nil {
+		return nil, err
+	}
+
+	return backorder, nil
+}
+
+// ListBackorderDocuments retrieves the documents required for a backorder,
+// and whether they were provided.
+func ListBackorderDocuments(c *messagebird.Client, id string) ([]*BackorderDocument, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	var list struct {
+		Items []*BackorderDocument
+	}
+	if err := c.Request(&list, http.MethodGet, backorderURL(id, "documents"), nil); err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// UploadBackorderDocument provides a document required for a backorder.
+// The name is that of the required document.
+func UploadBackorderDocument(c *messagebird.Client, id string, doc *BackorderDocumentUpload) error {
+	if id == "" || doc == nil || doc.Name == "" || len(doc.Content) == 0 {
+		return errors.New("id, name and content are required")
+	}
+
+	return c.Request(nil, http.MethodPost, backorderURL(id, "documents"), doc)
+}
+
+// ListBackorderEndUserDetails retrieves the end-user details required for a
+// backorder, and whether they were provided.
+func ListBackorderEndUserDetails(c *messagebird.Client, id string) ([]*BackorderEndUserDetail, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	var list struct {
+		Items []*BackorderEndUserDetail
+	}
+	if err := c.Request(&list, http.MethodGet, backorderURL(id, "end-user-details"), nil); err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// UpdateBackorderEndUserDetails provides end-user details required for a
+// backorder. Values are keyed by the ID of the detail.
+func UpdateBackorderEndUserDetails(c *messagebird.Client, id string, values map[string]string) error {
+	if id == "" || len(values) == 0 {
+		return errors.New("id and values are required")
+	}
+
+	type detail struct {
+		ID    string `json:"id"`
+		Value string `json:"value"`
+	}
+	request := struct {
+		Data []detail `json:"data"`
+	}{}
+	for k, v := range values {
+		request.Data = append(request.Data, detail{k, v})
+	}
+	sort.Slice(request.Data, func(i, j int) bool { return request.Data[i].ID < request.Data[j].ID })
+
+	return c.Request(nil, http.MethodPost, backorderURL(id, "end-user-details"), &request)
+}
+
+// The delays between reading the backorder in WaitForBackorder, which
+// double up to the maximum.
+var (
+	backorderWaitMinDelay = 5 * time.Second
+	backorderWaitMaxDelay = 5 * time.Minute
+)
+
+// WaitForBackorder reads the backorder with id until it has one of
+// statuses, and returns it. The statuses default to those that need no
+// waiting: BackorderStatusBlocked, BackorderStatusComplete and
+// BackorderStatusCancelled. Backorders may take days, so bound the wait with
+// the context of the client. When the context ends first, the last backorder
+// read is returned with the error of the context.
+func WaitForBackorder(c *messagebird.Client, id string, statuses ...string) (*Backorder, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+	if len(statuses) == 0 {
+		statuses = []string{BackorderStatusBlocked, BackorderStatusComplete, BackorderStatusCancelled}
+	}
+
+	ctx := c.Context()
+	delay := backorderWaitMinDelay
+	var last *Backorder
+	for {
+		backorder, err := ReadBackorder(c, id)
+		if err != nil {
+			if last != nil && ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return nil, err
+		}
+		last = backorder
+		for _, s := range statuses {
+			if backorder.Status == s {
+				return backorder, nil
+			}
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return backorder, ctx.Err()
+		case <-t.C:
+		}
+
+		if delay *= 2; delay > backorderWaitMaxDelay {
+			delay = backorderWaitMaxDelay
+		}
+	}
+}
+
+// backorderURL returns the URL of the backorder with id, or of its resource.
+func backorderURL(id, resource string) string {
+	u := apiRoot + "/" + backorderPath + "/" + url.PathEscape(id)
+	if resource != "" {
+		u += "/" + resource
+	}
+	return u
+}
@@ -0,0 +1,110 @@
+package number
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestCreateBackorder(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "backorderObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	b, err := CreateBackorder(client, &BackorderParams{ProductID: 103, Prefix: "4930", Quantity: 2})
+	if err != nil {
+		t.Fatalf("Didn't expect an error while creating a backorder: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/backorders")
+	mbtest.AssertTestdata(t, "backorderRequest.json", mbtest.Request.Body)
+
+	if b.ID != "2f1d0e3c4b5a46978877665544332211" {
+		t.Errorf("Unexpected backorder id: %s, expected: 2f1d0e3c4b5a46978877665544332211", b.ID)
+	}
+	if b.Status != BackorderStatusBlocked {
+		t.Errorf("Unexpected status: %s, expected: %s", b.Status, BackorderStatusBlocked)
+	}
+	if len(b.ReasonCodes) != 1 || b.ReasonCodes[0] != "documents_required" {
+		t.Errorf("Unexpected reason codes: %v, expected: [documents_required]", b.ReasonCodes)
+	}
+}
+
+func TestCreateBackorderInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	var cases = []struct {
+		name   string
+		params *BackorderParams
+	}{
+		{name: "Nil params", params: nil},
+		{name: "Missing product", params: &BackorderParams{Prefix: "4930", Quantity: 1}},
+		{name: "Missing prefix", params: &BackorderParams{ProductID: 103, Quantity: 1}},
+		{name: "Missing quantity", params: &BackorderParams{ProductID: 103, Prefix: "4930"}},
+	}
+
+	for _, tt := range cases {
+		if _, err := CreateBackorder(client, tt.params); err == nil {
+			t.Errorf("Expected an error, got nil, test case: %s", tt.name)
+		}
+	}
+}
+
+func TestWaitForBackorder(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "backorderObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	b, err := WaitForBackorder(client, "2f1d0e3c4b5a46978877665544332211")
+	if err != nil {
+		t.Fatalf("Didn't expect an error while waiting for a backorder: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/backorders/2f1d0e3c4b5a46978877665544332211")
+	if b.Status != BackorderStatusBlocked {
+		t.Errorf("Unexpected status: %s, expected: %s", b.Status, BackorderStatusBlocked)
+	}
+}
+
+func TestBackorderDocuments(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "backorderDocumentListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	docs, err := ListBackorderDocuments(client, "2f1d0e3c4b5a46978877665544332211")
+	if err != nil {
+		t.Fatalf("Didn't expect an error while listing backorder documents: %s", err)
+	}
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/backorders/2f1d0e3c4b5a46978877665544332211/documents")
+	if len(docs) != 1 || docs[0].Name != "Proof of address" || docs[0].Status != "pending" {
+		t.Fatalf("Unexpected documents: %+v", docs)
+	}
+
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	doc := &BackorderDocumentUpload{Name: docs[0].Name, MimeType: "application/pdf", Content: []byte("%PDF-1.4")}
+	if err := UploadBackorderDocument(client, "2f1d0e3c4b5a46978877665544332211", doc); err != nil {
+		t.Fatalf("Didn't expect an error while uploading a backorder document: %s", err)
+	}
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/backorders/2f1d0e3c4b5a46978877665544332211/documents")
+	mbtest.AssertTestdata(t, "backorderDocumentRequest.json", mbtest.Request.Body)
+}
+
+func TestBackorderEndUserDetails(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "backorderEndUserDetailListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	details, err := ListBackorderEndUserDetails(client, "2f1d0e3c4b5a46978877665544332211")
+	if err != nil {
+		t.Fatalf("Didn't expect an error while listing end-user details: %s", err)
+	}
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/backorders/2f1d0e3c4b5a46978877665544332211/end-user-details")
+	if len(details) != 2 || details[1].ID != "zip" {
+		t.Fatalf("Unexpected end-user details: %+v", details)
+	}
+
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	values := map[string]string{"zip": "10117", "street": "Friedrichstraße 1"}
+	if err := UpdateBackorderEndUserDetails(client, "2f1d0e3c4b5a46978877665544332211", values); err != nil {
+		t.Fatalf("Didn't expect an error while updating end-user details: %s", err)
+	}
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/backorders/2f1d0e3c4b5a46978877665544332211/end-user-details")
+	mbtest.AssertTestdata(t, "backorderEndUserDetailsRequest.json", mbtest.Request.Body)
+}
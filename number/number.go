@@ -0,0 +1,170 @@
+// Package number provides access to the Numbers API, which manages the
+// virtual mobile numbers of your account.
+package number
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// apiRoot is the absolute URL of the Numbers API.
+const apiRoot = messagebird.NumbersEndpoint + "/v1"
+
+// path is the path for the purchased Number resource, relative to apiRoot.
+const path = "phone-numbers"
+
+// The features of a number.
+const (
+	FeatureSMS   = "sms"
+	FeatureVoice = "voice"
+	FeatureMMS   = "mms"
+)
+
+// Number is a virtual mobile number.
+type Number struct {
+	Number   string
+	Country  string
+	Region   string
+	Locality string
+	Features []string
+	Tags     []string
+	Type     string
+	Status   string
+}
+
+// NumberList represents a list of numbers.
+type NumberList struct {
+	Offset     int
+	Limit      int
+	Count      int
+	TotalCount int
+	Items      []*Number
+}
+
+// ListParams filters the purchased numbers returned by List.
+type ListParams struct {
+	Limit  int
+	Offset int
+
+	// Features and Tags only match numbers that have all of them.
+	Features []string
+	Tags     []string
+
+	Number   string // Matches numbers containing it.
+	Country  string // ISO 3166-1 alpha-2 country code, e.g. "NL".
+	Region   string
+	Locality string
+	Type     string // E.g. "mobile", "landline" or "premium".
+}
+
+// List retrieves the numbers purchased by the account. Params may be nil to
+// list all numbers.
+func List(c *messagebird.Client, params *ListParams) (*NumberList, error) {
+	numberList := &NumberList{}
+	if err := c.Request(numberList, http.MethodGet, apiRoot+"/"+path+"?"+paramsForList(params).Encode(), nil); err != nil {
+		return nil, err
+	}
+
+	return numberList, nil
+}
+
+// Iterate returns an iterator over all purchased numbers matching params,
+// which may be nil to iterate over all numbers.
+func Iterate(c *messagebird.Client, params *ListParams) *messagebird.Iterator[*Number] {
+	var p ListParams
+	if params != nil {
+		p = *params
+	}
+	return messagebird.NewOffsetIterator(p.Offset, func(ctx context.Context, offset int) ([]*Number, int, error) {
+		p.Offset = offset
+		list, err := List(c.WithContext(ctx), &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
+// Read retrieves the details of a purchased number.
+func Read(c *messagebird.Client, phoneNumber string) (*Number, error) {
+	number := &Number{}
+	if err := c.Request(number, http.MethodGet, apiRoot+"/"+path+"/"+url.PathEscape(phoneNumber), nil); err != nil {
+		return nil, err
+	}
+
+	return number, nil
+}
+
+// UpdateRequest holds the settings of a purchased number that can be
+// changed.
+type UpdateRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// Update changes the settings of a purchased number. The tags replace the
+// existing tags of the number. To route calls to the number, assign it to a
+// voice call flow with voice.CallFlow.AssignNumbers.
+func Update(c *messagebird.Client, phoneNumber string, req *UpdateRequest) (*Number, error) {
+	if req == nil {
+		return nil, errors.New("req is required")
+	}
+
+	number := &Number{}
+	if err := c.Request(number, http.MethodPatch, apiRoot+"/"+path+"/"+url.PathEscape(phoneNumber), req); err != nil {
+		return nil, err
+	}
+
+	return number, nil
+}
+
+// Delete cancels the subscription to a purchased number, releasing it at
+// the end of the current billing interval.
+func Delete(c *messagebird.Client, phoneNumber string) error {
+	if phoneNumber == "" {
+		return errors.New("phoneNumber is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, apiRoot+"/"+path+"/"+url.PathEscape(phoneNumber), nil)
+}
+
+func paramsForList(params *ListParams) url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset != 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+	for _, f := range params.Features {
+		query.Add("features", f)
+	}
+	for _, t := range params.Tags {
+		query.Add("tags", t)
+	}
+	if params.Number != "" {
+		query.Set("number", params.Number)
+	}
+	if params.Country != "" {
+		query.Set("country", params.Country)
+	}
+	if params.Region != "" {
+		query.Set("region", params.Region)
+	}
+	if params.Locality != "" {
+		query.Set("locality", params.Locality)
+	}
+	if params.Type != "" {
+		query.Set("type", params.Type)
+	}
+
+	return query
+}
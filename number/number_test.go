@@ -0,0 +1,112 @@
+package number
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func assertNumberObject(t *testing.T, n *Number) {
+	if n.Number != "31612345670" {
+		t.Errorf("Unexpected number: %s, expected: 31612345670", n.Number)
+	}
+	if n.Country != "NL" {
+		t.Errorf("Unexpected country: %s, expected: NL", n.Country)
+	}
+	if len(n.Features) != 2 || n.Features[0] != FeatureSMS || n.Features[1] != FeatureVoice {
+		t.Errorf("Unexpected features: %v, expected: [sms voice]", n.Features)
+	}
+	if len(n.Tags) != 1 || n.Tags[0] != "support" {
+		t.Errorf("Unexpected tags: %v, expected: [support]", n.Tags)
+	}
+	if n.Status != "active" {
+		t.Errorf("Unexpected status: %s, expected: active", n.Status)
+	}
+}
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "numberListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	list, err := List(client, &ListParams{Limit: 20, Features: []string{FeatureSMS, FeatureVoice}, Country: "NL", Type: "mobile"})
+	if err != nil {
+		t.Fatalf("Didn't expect an error while listing numbers: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/phone-numbers")
+	if q := mbtest.Request.URL.RawQuery; q != "country=NL&features=sms&features=voice&limit=20&type=mobile" {
+		t.Errorf("Unexpected query: %s", q)
+	}
+
+	if list.TotalCount != 1 || len(list.Items) != 1 {
+		t.Fatalf("Unexpected total count %d with %d items, expected 1", list.TotalCount, len(list.Items))
+	}
+	assertNumberObject(t, list.Items[0])
+}
+
+func TestIterate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "numberListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	var numbers []*Number
+	it := Iterate(client, &ListParams{Country: "NL"})
+	for it.Next(context.Background()) {
+		numbers = append(numbers, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Didn't expect an error while iterating numbers: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/phone-numbers")
+	if q := mbtest.Request.URL.RawQuery; q != "country=NL" {
+		t.Errorf("Unexpected query: %s", q)
+	}
+	if len(numbers) != 1 {
+		t.Fatalf("Unexpected %d numbers, expected 1", len(numbers))
+	}
+	assertNumberObject(t, numbers[0])
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "numberObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	n, err := Read(client, "31612345670")
+	if err != nil {
+		t.Fatalf("Didn't expect an error while reading a number: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/phone-numbers/31612345670")
+	assertNumberObject(t, n)
+}
+
+func TestUpdate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "numberObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	n, err := Update(client, "31612345670", &UpdateRequest{Tags: []string{"support", "sales"}})
+	if err != nil {
+		t.Fatalf("Didn't expect an error while updating a number: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/v1/phone-numbers/31612345670")
+	mbtest.AssertTestdata(t, "updateRequest.json", mbtest.Request.Body)
+	assertNumberObject(t, n)
+}
+
+func TestDelete(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	if err := Delete(client, "31612345670"); err != nil {
+		t.Fatalf("Didn't expect an error while deleting a number: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v1/phone-numbers/31612345670")
+}
@@ -0,0 +1,114 @@
+package number
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// portingPath is the path for the PortingRequest resource, relative to
+// apiRoot.
+const portingPath = "porting/requests"
+
+// The statuses of a porting request.
+const (
+	PortingStatusPending    = "pending"
+	PortingStatusInProgress = "in_progress"
+	PortingStatusCompleted  = "completed"
+	PortingStatusRejected   = "rejected"
+	PortingStatusCancelled  = "cancelled"
+)
+
+// PortingRequest is a request to port numbers from another carrier to
+// MessageBird.
+type PortingRequest struct {
+	ID            string
+	Status        string
+	StatusReason  string
+	CountryCode   string
+	Numbers       []string
+	Owner         *PortingOwner
+	PortingDate   *time.Time
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+	MissingFields []string // Information still required before porting.
+}
+
+// PortingOwner holds the details of the current owner of the numbers, as
+// known by the losing carrier.
+type PortingOwner struct {
+	Name          string `json:"name,omitempty"`
+	CompanyName   string `json:"companyName,omitempty"`
+	Address       string `json:"address,omitempty"`
+	PostalCode    string `json:"postalCode,omitempty"`
+	City          string `json:"city,omitempty"`
+	AccountNumber string `json:"accountNumber,omitempty"` // At the losing carrier.
+}
+
+// PortingRequestParams holds the details of a new porting request.
+type PortingRequestParams struct {
+	CountryCode string        `json:"countryCode"`
+	Numbers     []string      `json:"numbers"`
+	Owner       *PortingOwner `json:"owner,omitempty"`
+	PortingDate *time.Time    `json:"portingDate,omitempty"` // The requested date.
+}
+
+// CreatePortingRequest starts porting numbers to MessageBird. The owner
+// details can be completed later with UpdatePortingOwner.
+func CreatePortingRequest(c *messagebird.Client, params *PortingRequestParams) (*PortingRequest, error) {
+	if params == nil || params.CountryCode == "" || len(params.Numbers) == 0 {
+		return nil, errors.New("countryCode and numbers are required")
+	}
+
+	porting := &PortingRequest{}
+	if err := c.Request(porting, http.MethodPost, apiRoot+"/"+portingPath, params); err != nil {
+		return nil, err
+	}
+
+	return porting, nil
+}
+
+// ReadPortingRequest retrieves a porting request, e.g. to poll its status.
+func ReadPortingRequest(c *messagebird.Client, id string) (*PortingRequest, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	porting := &PortingRequest{}
+	if err := c.Request(porting, http.MethodGet, apiRoot+"/"+portingPath+"/"+url.PathEscape(id), nil); err != nil {
+		return nil, err
+	}
+
+	return porting, nil
+}
+
+// UpdatePortingOwner provides the owner details required by the losing
+// carrier to a pending porting request.
+func UpdatePortingOwner(c *messagebird.Client, id string, owner *PortingOwner) (*PortingRequest, error) {
+	if id == "" || owner == nil {
+		return nil, errors.New("id and owner are required")
+	}
+
+	request := struct {
+		Owner *PortingOwner `json:"owner"`
+	}{owner}
+
+	porting := &PortingRequest{}
+	if err := c.Request(porting, http.MethodPatch, apiRoot+"/"+portingPath+"/"+url.PathEscape(id), &request); err != nil {
+		return nil, err
+	}
+
+	return porting, nil
+}
+
+// CancelPortingRequest cancels a porting request that has not completed yet.
+func CancelPortingRequest(c *messagebird.Client, id string) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, apiRoot+"/"+portingPath+"/"+url.PathEscape(id), nil)
+}
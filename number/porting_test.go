@@ -0,0 +1,103 @@
+package number
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func assertPortingRequestObject(t *testing.T, p *PortingRequest) {
+	if p.ID != "a8d1ba3bd3a54d8ca7e8a0b88a5e9a01" {
+		t.Errorf("Unexpected porting request id: %s, expected: a8d1ba3bd3a54d8ca7e8a0b88a5e9a01", p.ID)
+	}
+	if p.Status != PortingStatusPending {
+		t.Errorf("Unexpected status: %s, expected: %s", p.Status, PortingStatusPending)
+	}
+	if len(p.Numbers) != 1 || p.Numbers[0] != "31201234567" {
+		t.Errorf("Unexpected numbers: %v, expected: [31201234567]", p.Numbers)
+	}
+	if p.Owner == nil || p.Owner.CompanyName != "ACME B.V." {
+		t.Errorf("Unexpected owner: %+v", p.Owner)
+	}
+	if p.PortingDate == nil || p.PortingDate.Format("2006-01-02") != "2026-11-02" {
+		t.Errorf("Unexpected porting date: %v, expected: 2026-11-02", p.PortingDate)
+	}
+	if len(p.MissingFields) != 1 || p.MissingFields[0] != "owner.address" {
+		t.Errorf("Unexpected missing fields: %v, expected: [owner.address]", p.MissingFields)
+	}
+}
+
+func TestCreatePortingRequest(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "portingRequestObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	p, err := CreatePortingRequest(client, &PortingRequestParams{
+		CountryCode: "NL",
+		Numbers:     []string{"31201234567"},
+		Owner:       &PortingOwner{CompanyName: "ACME B.V.", AccountNumber: "123456"},
+	})
+	if err != nil {
+		t.Fatalf("Didn't expect an error while creating a porting request: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/porting/requests")
+	mbtest.AssertTestdata(t, "portingRequest.json", mbtest.Request.Body)
+	assertPortingRequestObject(t, p)
+}
+
+func TestCreatePortingRequestInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	var cases = []struct {
+		name   string
+		params *PortingRequestParams
+	}{
+		{name: "Nil params", params: nil},
+		{name: "Missing country code", params: &PortingRequestParams{Numbers: []string{"31201234567"}}},
+		{name: "Missing numbers", params: &PortingRequestParams{CountryCode: "NL"}},
+	}
+
+	for _, tt := range cases {
+		if _, err := CreatePortingRequest(client, tt.params); err == nil {
+			t.Errorf("Expected an error, got nil, test case: %s", tt.name)
+		}
+	}
+}
+
+func TestReadPortingRequest(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "portingRequestObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	p, err := ReadPortingRequest(client, "a8d1ba3bd3a54d8ca7e8a0b88a5e9a01")
+	if err != nil {
+		t.Fatalf("Didn't expect an error while reading a porting request: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/porting/requests/a8d1ba3bd3a54d8ca7e8a0b88a5e9a01")
+	assertPortingRequestObject(t, p)
+}
+
+func TestUpdatePortingOwner(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "portingRequestObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	owner := &PortingOwner{Address: "Trompenburgstraat 2C", PostalCode: "1079 TX", City: "Amsterdam"}
+	if _, err := UpdatePortingOwner(client, "a8d1ba3bd3a54d8ca7e8a0b88a5e9a01", owner); err != nil {
+		t.Fatalf("Didn't expect an error while updating a porting request: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/v1/porting/requests/a8d1ba3bd3a54d8ca7e8a0b88a5e9a01")
+	mbtest.AssertTestdata(t, "portingOwnerRequest.json", mbtest.Request.Body)
+}
+
+func TestCancelPortingRequest(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	if err := CancelPortingRequest(client, "a8d1ba3bd3a54d8ca7e8a0b88a5e9a01"); err != nil {
+		t.Fatalf("Didn't expect an error while cancelling a porting request: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/v1/porting/requests/a8d1ba3bd3a54d8ca7e8a0b88a5e9a01")
+}
@@ -0,0 +1,129 @@
+package number
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// searchPath is the path for available numbers, relative to apiRoot.
+const searchPath = "available-phone-numbers"
+
+// SearchPattern determines where SearchParams.Number must occur in the
+// numbers found.
+type SearchPattern string
+
+// The supported search patterns.
+const (
+	SearchStart    SearchPattern = "start"
+	SearchEnd      SearchPattern = "end"
+	SearchAnywhere SearchPattern = "anywhere"
+)
+
+// The billing intervals a number can be purchased with, in months.
+const (
+	BillingMonthly   = 1
+	BillingQuarterly = 3
+	BillingYearly    = 12
+)
+
+// AvailableNumber is a number that can be purchased.
+type AvailableNumber struct {
+	Number   string
+	Country  string
+	Region   string
+	Locality string
+	Features []string
+	Type     string
+}
+
+// AvailableNumberList represents a list of numbers available for purchase.
+type AvailableNumberList struct {
+	Limit int
+	Count int
+	Items []*AvailableNumber
+}
+
+// SearchParams filters the numbers returned by Search.
+type SearchParams struct {
+	Limit int
+
+	// Number is the prefix, suffix or part of the number to search for, as
+	// set by SearchPattern. It defaults to matching the start.
+	Number        string
+	SearchPattern SearchPattern
+
+	// Features only matches numbers that support all of them.
+	Features []string
+	Type     string
+}
+
+// PurchaseRequest describes the number to purchase.
+type PurchaseRequest struct {
+	Number                string `json:"number"`
+	CountryCode           string `json:"countryCode"`
+	BillingIntervalMonths int    `json:"billingIntervalMonths"`
+}
+
+// Search retrieves the numbers available for purchase in the country with
+// the given ISO 3166-1 alpha-2 code. Params may be nil.
+func Search(c *messagebird.Client, countryCode string, params *SearchParams) (*AvailableNumberList, error) {
+	if countryCode == "" {
+		return nil, errors.New("countryCode is required")
+	}
+
+	numberList := &AvailableNumberList{}
+	if err := c.Request(numberList, http.MethodGet, apiRoot+"/"+searchPath+"/"+url.PathEscape(countryCode)+"?"+paramsForSearch(params).Encode(), nil); err != nil {
+		return nil, err
+	}
+
+	return numberList, nil
+}
+
+// Purchase buys a number found by Search. The billing interval defaults to
+// BillingMonthly.
+func Purchase(c *messagebird.Client, req *PurchaseRequest) (*Number, error) {
+	if req == nil || req.Number == "" || req.CountryCode == "" {
+		return nil, errors.New("number and countryCode are required")
+	}
+
+	data := *req
+	if data.BillingIntervalMonths == 0 {
+		data.BillingIntervalMonths = BillingMonthly
+	}
+
+	number := &Number{}
+	if err := c.Request(number, http.MethodPost, apiRoot+"/"+path, &data); err != nil {
+		return nil, err
+	}
+
+	return number, nil
+}
+
+func paramsForSearch(params *SearchParams) url.Values {
+	query := url.Values{}
+	if params == nil {
+		return query
+	}
+
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Number != "" {
+		query.Set("number", params.Number)
+	}
+	if params.SearchPattern != "" {
+		query.Set("search_pattern", string(params.SearchPattern))
+	}
+	for _, f := range params.Features {
+		query.Add("features", f)
+	}
+	if params.Type != "" {
+		query.Set("type", params.Type)
+	}
+
+	return query
+}
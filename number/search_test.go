@@ -0,0 +1,71 @@
+package number
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestSearch(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "availableNumberListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	list, err := Search(client, "NL", &SearchParams{Limit: 2, Number: "3185", SearchPattern: SearchStart, Features: []string{FeatureSMS}})
+	if err != nil {
+		t.Fatalf("Didn't expect an error while searching numbers: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/v1/available-phone-numbers/NL")
+	if q := mbtest.Request.URL.RawQuery; q != "features=sms&limit=2&number=3185&search_pattern=start" {
+		t.Errorf("Unexpected query: %s", q)
+	}
+
+	if list.Count != 2 || len(list.Items) != 2 {
+		t.Fatalf("Unexpected count %d with %d items, expected 2", list.Count, len(list.Items))
+	}
+	if list.Items[0].Number != "31852000001" {
+		t.Errorf("Unexpected number: %s, expected: 31852000001", list.Items[0].Number)
+	}
+}
+
+func TestSearchWithoutCountry(t *testing.T) {
+	client := mbtest.Client(t)
+
+	if _, err := Search(client, "", nil); err == nil {
+		t.Error("Expected an error while searching without a country code, got nil")
+	}
+}
+
+func TestPurchase(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "numberObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	n, err := Purchase(client, &PurchaseRequest{Number: "31612345670", CountryCode: "NL"})
+	if err != nil {
+		t.Fatalf("Didn't expect an error while purchasing a number: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/v1/phone-numbers")
+	mbtest.AssertTestdata(t, "purchaseRequest.json", mbtest.Request.Body)
+	assertNumberObject(t, n)
+}
+
+func TestPurchaseInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	var cases = []struct {
+		name string
+		req  *PurchaseRequest
+	}{
+		{name: "Nil request", req: nil},
+		{name: "Missing number", req: &PurchaseRequest{CountryCode: "NL"}},
+		{name: "Missing country code", req: &PurchaseRequest{Number: "31612345670"}},
+	}
+
+	for _, tt := range cases {
+		if _, err := Purchase(client, tt.req); err == nil {
+			t.Errorf("Expected an error, got nil, test case: %s", tt.name)
+		}
+	}
+}
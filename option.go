@@ -0,0 +1,93 @@
+package messagebird
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by New.
+type Option func(*Client)
+
+// WithHTTPClient makes the client send requests with hc, e.g. to use a proxy
+// or a transport recording requests in tests.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithTransport makes the client send requests with rt, e.g. a transport
+// configured for mTLS or with a custom CA bundle. It replaces the transport
+// of the HTTP client in use, so apply it after WithHTTPClient when combined.
+// The HTTP client is copied first, so clients shared with other code, such
+// as http.DefaultClient, are not changed.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := *c.httpClient()
+		hc.Transport = rt
+		c.HTTPClient = &hc
+	}
+}
+
+// defaultHTTPClient is used by clients without an HTTP client, e.g. those not
+// created by New.
+var defaultHTTPClient = newHTTPClient()
+
+// newHTTPClient returns an HTTP client with timeouts suitable for the API.
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout:   httpClientTimeout,
+		Transport: newTransport(),
+	}
+}
+
+// newTransport returns a transport like http.DefaultTransport, which is not
+// shared with other packages.
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: httpClientTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// httpClient returns the HTTP client requests are sent with.
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return defaultHTTPClient
+}
+
+// WithAppInfo appends the name and version of your application to the
+// User-Agent header of the client's requests, e.g. "billing/1.4.2", so they
+// can be attributed to it in MessageBird's logs.
+func WithAppInfo(name, version string) Option {
+	return func(c *Client) {
+		c.appInfo = name + "/" + version
+	}
+}
+
+// WithHeader makes the client send the header key with value in all its
+// requests. It does not replace the headers set by the client itself, such
+// as Authorization.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		h := c.headers.Clone()
+		if h == nil {
+			h = http.Header{}
+		}
+		h.Add(key, value)
+		c.headers = h
+	}
+}
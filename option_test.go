@@ -0,0 +1,93 @@
+package messagebird
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTransport(t *testing.T) {
+	var got *http.Request
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithHTTPClient(&http.Client{}), WithTransport(rt))
+	if err := c.Request(nil, http.MethodGet, "balance", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got == nil || got.URL.String() != Endpoint+"/balance" {
+		t.Errorf("expected request to be sent with the custom transport, got %v", got)
+	}
+}
+
+func TestWithTransportCopiesHTTPClient(t *testing.T) {
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("not sent")
+	})
+
+	defaultTransport := http.DefaultClient.Transport
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithHTTPClient(http.DefaultClient), WithTransport(rt))
+	if http.DefaultClient.Transport != defaultTransport {
+		http.DefaultClient.Transport = defaultTransport
+		t.Fatal("expected http.DefaultClient not to be changed")
+	}
+	if c.HTTPClient == http.DefaultClient || c.HTTPClient.Transport == nil {
+		t.Errorf("expected a copy of http.DefaultClient with the transport")
+	}
+
+	c = New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithHTTPClient(nil), WithTransport(rt))
+	if c.HTTPClient == nil || c.HTTPClient.Timeout != httpClientTimeout || c.HTTPClient.Transport == nil {
+		t.Errorf("expected a copy of the default HTTP client with the transport, got %+v", c.HTTPClient)
+	}
+}
+
+func TestNewDefaultTimeouts(t *testing.T) {
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	if c.HTTPClient == http.DefaultClient || c.HTTPClient.Timeout != httpClientTimeout {
+		t.Errorf("expected a dedicated HTTP client with a %s timeout", httpClientTimeout)
+	}
+	if tr, ok := c.HTTPClient.Transport.(*http.Transport); !ok || tr == http.DefaultTransport || tr.TLSHandshakeTimeout == 0 {
+		t.Errorf("expected a dedicated transport with timeouts, got %v", c.HTTPClient.Transport)
+	}
+}
+
+func TestWithAppInfoAndHeader(t *testing.T) {
+	var got *http.Request
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r
+		return &http.Response{
+			StatusCode: http.StatusNoContent,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithTransport(rt), WithAppInfo("billing", "1.4.2"), WithHeader("X-Team", "payments"), WithHeader("Authorization", "Bearer other"))
+	if err := c.Request(nil, http.MethodGet, "balance", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if ua := got.Header.Get("User-Agent"); !strings.HasPrefix(ua, "MessageBird/ApiClient/"+ClientVersion) || !strings.HasSuffix(ua, " billing/1.4.2") {
+		t.Errorf("got User-Agent %q, expected it to end with the app info", ua)
+	}
+	if h := got.Header.Get("X-Team"); h != "payments" {
+		t.Errorf("got X-Team header %q, expected payments", h)
+	}
+	if h := got.Header.Get("Authorization"); h != "AccessKey test_gshuPaZoeEG6ovbc8M79w0QyM" {
+		t.Errorf("got Authorization header %q, expected the access key", h)
+	}
+}
@@ -0,0 +1,110 @@
+// Package partner provides access to the Partner Accounts API, which manages
+// the child accounts of a partner account.
+package partner
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// apiRoot is the absolute URL of the Partner Accounts API.
+const apiRoot = messagebird.PartnerAccountsEndpoint
+
+// path is the path for the child Account resource, relative to apiRoot.
+const path = "child-accounts"
+
+// The modes of an AccessKey.
+const (
+	AccessKeyModeLive = "live"
+	AccessKeyModeTest = "test"
+)
+
+// Account is a child account of the partner account.
+type Account struct {
+	ID   int
+	Name string
+
+	// AccessKeys and SigningKey are only returned when the account is
+	// created. Store them, as they can not be retrieved afterwards.
+	AccessKeys []AccessKey
+	SigningKey string
+}
+
+// AccessKey is an access key of a child account, used to authenticate the
+// requests made on its behalf with messagebird.New.
+type AccessKey struct {
+	ID   string
+	Key  string
+	Mode string
+}
+
+// Params holds the settings of a child account.
+type Params struct {
+	Name string `json:"name"`
+}
+
+// Create creates a child account. The returned account includes its access
+// and signing keys.
+func Create(c *messagebird.Client, params *Params) (*Account, error) {
+	if params == nil || params.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	account := &Account{}
+	if err := c.Request(account, http.MethodPost, apiRoot+"/"+path, params); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// List retrieves all child accounts. The keys of the accounts are not
+// included.
+func List(c *messagebird.Client) ([]*Account, error) {
+	var accounts []*Account
+	if err := c.Request(&accounts, http.MethodGet, apiRoot+"/"+path, nil); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// Read retrieves a child account by its ID.
+func Read(c *messagebird.Client, id string) (*Account, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	account := &Account{}
+	if err := c.Request(account, http.MethodGet, apiRoot+"/"+path+"/"+url.PathEscape(id), nil); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// Update changes the settings of a child account.
+func Update(c *messagebird.Client, id string, params *Params) (*Account, error) {
+	if id == "" || params == nil || params.Name == "" {
+		return nil, errors.New("id and name are required")
+	}
+
+	account := &Account{}
+	if err := c.Request(account, http.MethodPatch, apiRoot+"/"+path+"/"+url.PathEscape(id), params); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// Delete deletes a child account.
+func Delete(c *messagebird.Client, id string) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
+	return c.Request(nil, http.MethodDelete, apiRoot+"/"+path+"/"+url.PathEscape(id), nil)
+}
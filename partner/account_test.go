@@ -0,0 +1,98 @@
+package partner
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestCreate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "accountCreatedObject.json", http.StatusCreated)
+	client := mbtest.Client(t)
+
+	account, err := Create(client, &Params{Name: "Partner Account 1 Sub 1"})
+	if err != nil {
+		t.Fatalf("unexpected error creating child account: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/child-accounts")
+	mbtest.AssertTestdata(t, "accountRequest.json", mbtest.Request.Body)
+
+	if account.ID != 6249609 {
+		t.Errorf("got ID %d, expected 6249609", account.ID)
+	}
+	if len(account.AccessKeys) != 2 || account.AccessKeys[1].Mode != AccessKeyModeLive {
+		t.Errorf("got access keys %v, expected a test and a live key", account.AccessKeys)
+	}
+	if account.SigningKey != "TOh6dSS4Bo8CpmUKfJy2Ewd2xn9gvIaR" {
+		t.Errorf("got signing key %s, expected TOh6dSS4Bo8CpmUKfJy2Ewd2xn9gvIaR", account.SigningKey)
+	}
+}
+
+func TestCreateWithoutName(t *testing.T) {
+	client := mbtest.Client(t)
+
+	if _, err := Create(client, &Params{}); err == nil {
+		t.Fatal("expected error creating child account without name, got nil")
+	}
+}
+
+func TestList(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "accountListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	accounts, err := List(client)
+	if err != nil {
+		t.Fatalf("unexpected error listing child accounts: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/child-accounts")
+
+	if len(accounts) != 2 || accounts[1].Name != "Partner Account 1 Sub 2" {
+		t.Errorf("got accounts %v, expected 2 accounts", accounts)
+	}
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "accountObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	account, err := Read(client, "6249609")
+	if err != nil {
+		t.Fatalf("unexpected error reading child account: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/child-accounts/6249609")
+
+	if account.Name != "Partner Account 1 Sub 1" {
+		t.Errorf("got name %s, expected Partner Account 1 Sub 1", account.Name)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "accountObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := Update(client, "6249609", &Params{Name: "Partner Account 1 Sub 1"}); err != nil {
+		t.Fatalf("unexpected error updating child account: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPatch, "/child-accounts/6249609")
+	mbtest.AssertTestdata(t, "accountRequest.json", mbtest.Request.Body)
+}
+
+func TestDelete(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	if err := Delete(client, "6249609"); err != nil {
+		t.Fatalf("unexpected error deleting child account: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/child-accounts/6249609")
+}
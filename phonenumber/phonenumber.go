@@ -0,0 +1,167 @@
+// Package phonenumber validates and normalizes phone numbers into the MSISDN
+// format accepted by the MessageBird API: the E.164 number without the
+// leading plus, e.g. 31612345678. Numbers written by people, such as
+// "+31 (0)6 1234 5678" or "06-12345678", are normalized with Normalize before
+// they're passed as recipients or looked up:
+//
+//	msisdn, err := phonenumber.Normalize(input, "31")
+//	if errors.Is(err, phonenumber.ErrTooShort) {
+//	    // ask the user for the full number
+//	}
+//
+// The package checks the form of numbers, not whether they are assigned. Use
+// the lookup package to check a number with its operator.
+package phonenumber
+
+import (
+	"errors"
+	"strings"
+)
+
+// The digit counts allowed by E.164, including the country calling code.
+const (
+	MinLength = 7
+	MaxLength = 15
+)
+
+// The reasons a number is invalid, wrapped by Error.
+var (
+	ErrEmpty              = errors.New("phone number is empty")
+	ErrInvalidCharacter   = errors.New("phone number contains an invalid character")
+	ErrMissingCountryCode = errors.New("phone number has no country calling code")
+	ErrInvalidCountryCode = errors.New("country calling code can not start with 0")
+	ErrTooShort           = errors.New("phone number is too short")
+	ErrTooLong            = errors.New("phone number is too long")
+)
+
+// Error is returned for invalid numbers. Err is one of the Err values, so it
+// can be checked with errors.Is.
+type Error struct {
+	Input string // The number as passed.
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error() + ": " + e.Input
+}
+
+// Unwrap returns the reason the number is invalid.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// separators are removed from numbers before they're validated.
+const separators = " \t\u00a0-./()"
+
+// Normalize returns number as an MSISDN. Spaces, dashes, dots, slashes and
+// parentheses are removed. Numbers starting with + or the international
+// prefix 00 contain their country calling code, as do MSISDNs. Numbers
+// starting with the trunk prefix 0 are in national format, so the prefix is
+// replaced by defaultCallingCode, e.g. "31" for the Netherlands. Pass an
+// empty defaultCallingCode to only accept international numbers.
+//
+// National numbers of countries without a trunk prefix, such as the United
+// States, can not be told apart from MSISDNs, so they must be passed in
+// international format.
+//
+// A trunk prefix written in parentheses in international format, as in
+// "+31 (0)6 12345678", is removed as well.
+func Normalize(number, defaultCallingCode string) (string, error) {
+	s := strings.TrimSpace(number)
+	if s == "" {
+		return "", &Error{Input: number, Err: ErrEmpty}
+	}
+
+	international := false
+	switch {
+	case strings.HasPrefix(s, "+"):
+		international, s = true, s[1:]
+	case strings.HasPrefix(s, "00"):
+		international, s = true, s[2:]
+	}
+	if international {
+		s = removeTrunkPrefix(s)
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case strings.ContainsRune(separators, r):
+		default:
+			return "", &Error{Input: number, Err: ErrInvalidCharacter}
+		}
+	}
+	digits := b.String()
+	if digits == "" {
+		return "", &Error{Input: number, Err: ErrEmpty}
+	}
+
+	if !international && digits[0] == '0' {
+		if defaultCallingCode == "" {
+			return "", &Error{Input: number, Err: ErrMissingCountryCode}
+		}
+		digits = strings.TrimPrefix(defaultCallingCode, "+") + digits[1:]
+	}
+
+	if err := validate(digits); err != nil {
+		return "", &Error{Input: number, Err: err}
+	}
+	return digits, nil
+}
+
+// NormalizeAll normalizes numbers as Normalize does. The first invalid number
+// is reported.
+func NormalizeAll(numbers []string, defaultCallingCode string) ([]string, error) {
+	msisdns := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		msisdn, err := Normalize(n, defaultCallingCode)
+		if err != nil {
+			return nil, err
+		}
+		msisdns = append(msisdns, msisdn)
+	}
+	return msisdns, nil
+}
+
+// Valid reports whether msisdn is a normalized MSISDN, as returned by
+// Normalize.
+func Valid(msisdn string) bool {
+	if msisdn == "" {
+		return false
+	}
+	for _, r := range msisdn {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return validate(msisdn) == nil
+}
+
+// E164 formats msisdn in E.164 format, e.g. +31612345678.
+func E164(msisdn string) string {
+	return "+" + strings.TrimPrefix(msisdn, "+")
+}
+
+// validate checks the digits of an international number.
+func validate(digits string) error {
+	switch {
+	case digits[0] == '0':
+		return ErrInvalidCountryCode
+	case len(digits) < MinLength:
+		return ErrTooShort
+	case len(digits) > MaxLength:
+		return ErrTooLong
+	}
+	return nil
+}
+
+// removeTrunkPrefix removes a trunk prefix in parentheses following the
+// country calling code of an international number.
+func removeTrunkPrefix(s string) string {
+	if i := strings.Index(s, "(0)"); i >= 0 {
+		return s[:i] + s[i+3:]
+	}
+	return s
+}
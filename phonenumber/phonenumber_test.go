@@ -0,0 +1,98 @@
+package phonenumber
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	var cases = []struct {
+		name   string
+		number string
+		cc     string
+		e      string
+		err    error
+	}{
+		{name: "MSISDN", number: "31612345678", cc: "", e: "31612345678"},
+		{name: "E.164", number: "+31612345678", cc: "", e: "31612345678"},
+		{name: "International prefix", number: "0031612345678", cc: "", e: "31612345678"},
+		{name: "Spaces and dashes", number: " +31 6-1234 5678 ", cc: "", e: "31612345678"},
+		{name: "Trunk prefix in parentheses", number: "+31 (0)6 12345678", cc: "", e: "31612345678"},
+		{name: "National", number: "06 12345678", cc: "31", e: "31612345678"},
+		{name: "National with plus in calling code", number: "(020) 123.4567", cc: "+31", e: "31201234567"},
+		{name: "MSISDN with default", number: "31612345678", cc: "31", e: "31612345678"},
+		{name: "Non-breaking spaces", number: "+1\u00a0202\u00a0555\u00a00143", cc: "", e: "12025550143"},
+		{name: "Empty", number: "  ", cc: "31", err: ErrEmpty},
+		{name: "Only separators", number: "+ ( )", cc: "31", err: ErrEmpty},
+		{name: "Letters", number: "+31 6 CALL ME", cc: "", err: ErrInvalidCharacter},
+		{name: "Second plus", number: "+31+612345678", cc: "", err: ErrInvalidCharacter},
+		{name: "National without default", number: "0612345678", cc: "", err: ErrMissingCountryCode},
+		{name: "Calling code starting with 0", number: "+0612345678", cc: "", err: ErrInvalidCountryCode},
+		{name: "Too short", number: "+31612", cc: "", err: ErrTooShort},
+		{name: "Too long", number: "+3161234567890123", cc: "", err: ErrTooLong},
+	}
+
+	for _, tt := range cases {
+		got, err := Normalize(tt.number, tt.cc)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("got error %v, expected %v, test case: %s", err, tt.err, tt.name)
+			continue
+		}
+		if got != tt.e {
+			t.Errorf("got %q, expected %q, test case: %s", got, tt.e, tt.name)
+		}
+	}
+}
+
+func TestNormalizeError(t *testing.T) {
+	_, err := Normalize("06 CALL ME", "31")
+
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Input != "06 CALL ME" {
+		t.Fatalf("got %v, expected an *Error for the input", err)
+	}
+	if err.Error() != "phone number contains an invalid character: 06 CALL ME" {
+		t.Errorf("got message %q", err.Error())
+	}
+}
+
+func TestNormalizeAll(t *testing.T) {
+	got, err := NormalizeAll([]string{"06 12345678", "+32 470 12 34 56"}, "31")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 2 || got[0] != "31612345678" || got[1] != "32470123456" {
+		t.Errorf("got %q, expected [31612345678 32470123456]", got)
+	}
+
+	if _, err := NormalizeAll([]string{"06 12345678", "0612"}, "31"); !errors.Is(err, ErrTooShort) {
+		t.Errorf("got %v, expected %v", err, ErrTooShort)
+	}
+}
+
+func TestValid(t *testing.T) {
+	var cases = []struct {
+		msisdn string
+		e      bool
+	}{
+		{"31612345678", true},
+		{"+31612345678", false},
+		{"0612345678", false},
+		{"316", false},
+		{"", false},
+	}
+
+	for _, tt := range cases {
+		if got := Valid(tt.msisdn); got != tt.e {
+			t.Errorf("got %v, expected %v, test case: %s", got, tt.e, tt.msisdn)
+		}
+	}
+}
+
+func TestE164(t *testing.T) {
+	for _, s := range []string{"31612345678", "+31612345678"} {
+		if got := E164(s); got != "+31612345678" {
+			t.Errorf("got %q, expected +31612345678, test case: %s", got, s)
+		}
+	}
+}
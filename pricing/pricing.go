@@ -0,0 +1,87 @@
+// Package pricing provides the prices the account pays for outbound SMS.
+package pricing
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// path is the path to the outbound SMS pricing resource.
+const path = "pricing/sms/outbound"
+
+// PriceList holds the prices of outbound SMS for the account.
+type PriceList struct {
+	Gateway      int
+	CurrencyCode string
+	TotalCount   int
+	Prices       []*Price
+}
+
+// Price is the price of an SMS to a country or network. An MCC and MNC of "0"
+// denote the default rate; an MNC of "0" alone the rate of the whole country.
+type Price struct {
+	Price          messagebird.Decimal // E.g. 0.060000.
+	CurrencyCode   string
+	MCC            string
+	MNC            string
+	CountryName    string
+	CountryIsoCode string
+	OperatorName   string
+}
+
+// Read retrieves the prices of outbound SMS for the account, which apply to
+// messages from the default originator.
+func Read(c *messagebird.Client) (*PriceList, error) {
+	return read(c, path)
+}
+
+// ReadForOriginator retrieves the prices of outbound SMS sent from
+// originator, which may differ from the default prices.
+func ReadForOriginator(c *messagebird.Client, originator string) (*PriceList, error) {
+	return read(c, path+"/"+url.PathEscape(originator))
+}
+
+func read(c *messagebird.Client, path string) (*PriceList, error) {
+	priceList := &PriceList{}
+	if err := c.Request(priceList, http.MethodGet, path, nil); err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// ForNetwork returns the price for the network with the given MCC and MNC,
+// falling back to the country's price and then the default rate. It returns
+// nil if none of them are in the list.
+func (l *PriceList) ForNetwork(mcc, mnc string) *Price {
+	var country, fallback *Price
+	for _, p := range l.Prices {
+		switch {
+		case p.MCC == mcc && p.MNC == mnc:
+			return p
+		case p.MCC == mcc && p.MNC == "0":
+			country = p
+		case p.MCC == "0":
+			fallback = p
+		}
+	}
+	if country != nil {
+		return country
+	}
+	return fallback
+}
+
+// ForCountry returns the prices for the country with the given ISO 3166-1
+// alpha-2 code, e.g. "NL", one per network that has its own price.
+func (l *PriceList) ForCountry(isoCode string) []*Price {
+	var prices []*Price
+	for _, p := range l.Prices {
+		if strings.EqualFold(p.CountryIsoCode, isoCode) {
+			prices = append(prices, p)
+		}
+	}
+	return prices
+}
@@ -0,0 +1,75 @@
+package pricing
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestRead(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "priceListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	priceList, err := Read(client)
+	if err != nil {
+		t.Fatalf("Didn't expect error while fetching prices: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/pricing/sms/outbound")
+
+	if priceList.Gateway != 10 || priceList.CurrencyCode != "EUR" {
+		t.Errorf("Unexpected gateway %d and currency %s, expected 10 and EUR", priceList.Gateway, priceList.CurrencyCode)
+	}
+	if len(priceList.Prices) != 3 {
+		t.Fatalf("Unexpected number of prices: %d, expected 3", len(priceList.Prices))
+	}
+	if p := priceList.Prices[2]; p.OperatorName != "KPN" || p.Price.String() != "0.075000" {
+		t.Errorf("Unexpected price: %+v", p)
+	}
+}
+
+func TestReadForOriginator(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "priceListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := ReadForOriginator(client, "MessageBird"); err != nil {
+		t.Fatalf("Didn't expect error while fetching prices: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/pricing/sms/outbound/MessageBird")
+}
+
+func TestForNetwork(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "priceListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	priceList, err := Read(client)
+	if err != nil {
+		t.Fatalf("Didn't expect error while fetching prices: %s", err)
+	}
+
+	var cases = []struct {
+		name     string
+		mcc, mnc string
+		e        string
+	}{
+		{name: "Network price", mcc: "204", mnc: "08", e: "0.075000"},
+		{name: "Country price", mcc: "204", mnc: "16", e: "0.070000"},
+		{name: "Default rate", mcc: "262", mnc: "01", e: "0.060000"},
+	}
+
+	for _, tt := range cases {
+		if p := priceList.ForNetwork(tt.mcc, tt.mnc); p == nil || p.Price.String() != tt.e {
+			t.Errorf("Unexpected price %+v, expected %s, test case: %s", p, tt.e, tt.name)
+		}
+	}
+
+	if prices := priceList.ForCountry("nl"); len(prices) != 2 {
+		t.Errorf("Unexpected number of prices for NL: %d, expected 2", len(prices))
+	}
+}
@@ -0,0 +1,69 @@
+package messagebird
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit holds the rate limiting information of an API response.
+type RateLimit struct {
+	StatusCode int // The status code of the response.
+
+	// Limit, Remaining and Reset are taken from the X-RateLimit-Limit,
+	// X-RateLimit-Remaining and X-RateLimit-Reset headers. They are -1 and
+	// the zero time respectively when the header is absent.
+	Limit     int
+	Remaining int
+	Reset     time.Time
+
+	// RetryAfter is the delay requested by the Retry-After header of 429 Too
+	// Many Requests responses, or zero.
+	RetryAfter time.Duration
+}
+
+// parseRateLimit returns the rate limiting information of resp, and whether
+// it had any.
+func parseRateLimit(resp *http.Response, now time.Time) (RateLimit, bool) {
+	rl := RateLimit{
+		StatusCode: resp.StatusCode,
+		Limit:      headerInt(resp.Header, "X-RateLimit-Limit"),
+		Remaining:  headerInt(resp.Header, "X-RateLimit-Remaining"),
+	}
+	if reset := headerInt(resp.Header, "X-RateLimit-Reset"); reset >= 0 {
+		rl.Reset = time.Unix(int64(reset), 0)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rl.RetryAfter = retryAfter(resp.Header, now)
+	}
+
+	ok := rl.Limit >= 0 || rl.Remaining >= 0 || !rl.Reset.IsZero() || resp.StatusCode == http.StatusTooManyRequests
+	return rl, ok
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0
+		}
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+func headerInt(h http.Header, key string) int {
+	n, err := strconv.Atoi(h.Get(key))
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
@@ -0,0 +1,74 @@
+package messagebird
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOnRateLimit(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Reset", "1544544948")
+		if attempts == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	var limits []RateLimit
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, RetryRateLimited: true}
+	c.OnRateLimit = func(rl RateLimit) {
+		limits = append(limits, rl)
+	}
+
+	if err := c.Request(nil, http.MethodPost, ts.URL, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attempts != 2 {
+		t.Errorf("got %d attempts, expected 2", attempts)
+	}
+	if len(limits) != 2 {
+		t.Fatalf("got %d rate limits, expected 2", len(limits))
+	}
+	if rl := limits[0]; rl.StatusCode != http.StatusTooManyRequests || rl.Limit != 100 || rl.Remaining != 0 || rl.Reset.Unix() != 1544544948 {
+		t.Errorf("got %+v for the rejected request", rl)
+	}
+	if rl := limits[1]; rl.StatusCode != http.StatusNoContent || rl.Remaining != 99 {
+		t.Errorf("got %+v for the retried request", rl)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2018, 12, 11, 16, 15, 48, 0, time.UTC)
+
+	var cases = []struct {
+		name  string
+		value string
+		e     time.Duration
+	}{
+		{name: "Seconds", value: "120", e: 2 * time.Minute},
+		{name: "HTTP date", value: "Tue, 11 Dec 2018 16:16:18 GMT", e: 30 * time.Second},
+		{name: "Date in the past", value: "Tue, 11 Dec 2018 16:15:00 GMT", e: 0},
+		{name: "Missing", value: "", e: 0},
+		{name: "Invalid", value: "soon", e: 0},
+	}
+
+	for _, tt := range cases {
+		h := http.Header{}
+		h.Set("Retry-After", tt.value)
+		if d := retryAfter(h, now); d != tt.e {
+			t.Errorf("got %s, expected %s, test case: %s", d, tt.e, tt.name)
+		}
+	}
+}
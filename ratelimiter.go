@@ -0,0 +1,115 @@
+package messagebird
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limit is a token bucket rate limit of Rate requests per second, with
+// bursts of up to Burst requests. Burst defaults to 1.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter limits the rate at which a Client sends requests, so it stays
+// within the API's request budgets instead of being throttled. Requests wait
+// for both the global Limit and the limit of their endpoint, as long as
+// their context allows:
+//
+//	client.RateLimiter = &messagebird.RateLimiter{
+//	    Limit: messagebird.Limit{Rate: 50, Burst: 50},
+//	    Endpoints: map[string]messagebird.Limit{
+//	        "rest.messagebird.com/messages": {Rate: 10},
+//	    },
+//	}
+//
+// Endpoints are keyed by host and path, with IDs replaced by ":id" as in
+// RequestMetrics. A zero Rate disables a limit. Retries are rate limited as
+// well, while requests failed by an open CircuitBreaker take no tokens. A RateLimiter must not be copied after first use; share it between
+// clients to apply a single budget.
+type RateLimiter struct {
+	Limit
+	Endpoints map[string]Limit
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// bucket is the state of a token bucket.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// wait blocks until req may be sent or ctx is done.
+func (l *RateLimiter) wait(ctx context.Context, req *http.Request) error {
+	if l == nil {
+		return nil
+	}
+	ep := endpoint(req)
+	if err := l.waitBucket(ctx, "", l.Limit); err != nil {
+		return err
+	}
+	if lim, ok := l.Endpoints[ep]; ok {
+		return l.waitBucket(ctx, ep, lim)
+	}
+	return nil
+}
+
+// waitBucket takes a token from the bucket for key with limit lim, waiting
+// for it to be refilled if needed. The token is returned if ctx is done
+// first.
+func (l *RateLimiter) waitBucket(ctx context.Context, key string, lim Limit) error {
+	if lim.Rate <= 0 {
+		return nil
+	}
+	d := l.reserve(key, lim)
+	if d <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, d); err != nil {
+		l.mu.Lock()
+		l.buckets[key].tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token from the bucket for key and returns how long to wait
+// before it is available.
+func (l *RateLimiter) reserve(key string, lim Limit) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.now != nil {
+		now = l.now()
+	}
+	burst := float64(defaultInt(lim.Burst, 1))
+	if l.buckets == nil {
+		l.buckets = make(map[string]*bucket)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * lim.Rate
+		if b.tokens > burst {
+			b.tokens = burst
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / lim.Rate * float64(time.Second))
+}
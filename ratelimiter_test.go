@@ -0,0 +1,55 @@
+package messagebird
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	now := time.Unix(1544544948, 0)
+	l := &RateLimiter{now: func() time.Time { return now }}
+	lim := Limit{Rate: 2, Burst: 2}
+
+	var cases = []struct {
+		name    string
+		elapsed time.Duration
+		e       time.Duration
+	}{
+		{name: "First of burst", e: 0},
+		{name: "Second of burst", e: 0},
+		{name: "Bucket empty", e: 500 * time.Millisecond},
+		{name: "Wait queued", e: time.Second},
+		{name: "Refilled", elapsed: 2 * time.Second, e: 0},
+	}
+
+	for _, tt := range cases {
+		now = now.Add(tt.elapsed)
+		if d := l.reserve("", lim); d != tt.e {
+			t.Errorf("got delay %s, expected %s, test case: %s", d, tt.e, tt.name)
+		}
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := &RateLimiter{
+		Endpoints: map[string]Limit{"rest.messagebird.com/messages": {Rate: 0.001}},
+	}
+	req, _ := http.NewRequest(http.MethodPost, Endpoint+"/messages", nil)
+
+	if err := l.wait(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error taking the burst: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx, req); err != context.DeadlineExceeded {
+		t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	other, _ := http.NewRequest(http.MethodGet, Endpoint+"/balance", nil)
+	if err := l.wait(ctx, other); err != nil {
+		t.Errorf("unexpected error for an endpoint without limit: %s", err)
+	}
+}
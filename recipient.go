@@ -4,9 +4,18 @@ import "time"
 
 // Recipient struct holds information for a single msisdn with status details.
 type Recipient struct {
-	Recipient      int64
-	Status         string
-	StatusDatetime *time.Time
+	Recipient        int64
+	Status           string
+	StatusDatetime   *time.Time
+	StatusReason     string // E.g. "successfully delivered" or "unknown subscriber".
+	StatusErrorCode  int    // The error code of a failed delivery, or 0.
+	MessagePartCount int    // Number of parts the message was split into for this recipient.
+}
+
+// Failed reports whether the message could not be delivered to r, i.e. its
+// status is "delivery_failed" or "expired".
+func (r *Recipient) Failed() bool {
+	return r.Status == "delivery_failed" || r.Status == "expired"
 }
 
 // Recipients holds a collection of Recepient structs along with send stats.
@@ -17,3 +26,15 @@ type Recipients struct {
 	TotalDeliveryFailedCount int
 	Items                    []Recipient
 }
+
+// Failed returns the recipients the message could not be delivered to, with
+// the reason and error code of each.
+func (r *Recipients) Failed() []Recipient {
+	var failed []Recipient
+	for _, item := range r.Items {
+		if item.Failed() {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
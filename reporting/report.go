@@ -0,0 +1,149 @@
+// Package reporting provides access to the Reporting API, which aggregates
+// the traffic of your account over time.
+package reporting
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// apiRoot is the absolute URL of the Reporting API.
+const apiRoot = messagebird.ReportingEndpoint
+
+// Product is the channel a report is created for.
+type Product string
+
+const (
+	ProductSMS   Product = "sms"
+	ProductVoice Product = "voice"
+)
+
+// PeriodGroup is the length of the periods a report is aggregated over.
+type PeriodGroup string
+
+const (
+	PeriodGroupHour  PeriodGroup = "hour"
+	PeriodGroupDay   PeriodGroup = "day"
+	PeriodGroupWeek  PeriodGroup = "week"
+	PeriodGroupMonth PeriodGroup = "month"
+)
+
+// The dimensions reports can be grouped and filtered by.
+const (
+	DimensionStatus     = "status"
+	DimensionCountry    = "country"
+	DimensionOriginator = "originator"
+	DimensionType       = "type"
+)
+
+// Params holds the options of a report.
+type Params struct {
+	// PeriodStart and PeriodEnd are required. PeriodEnd is exclusive.
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	PeriodGroup PeriodGroup // Defaults to PeriodGroupDay.
+
+	// GroupBy splits the counts of each period by these dimensions.
+	GroupBy []string
+
+	// Filters only counts traffic whose dimensions have one of the values,
+	// e.g. {DimensionCountry: {"NL", "BE"}}.
+	Filters map[string][]string
+}
+
+// Report is a time series of counts.
+type Report struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	PeriodGroup PeriodGroup
+	GroupBy     []string
+	Items       []*Point
+}
+
+// Point is the count for a period and a combination of the grouped by
+// dimensions.
+type Point struct {
+	Period time.Time
+	Count  int
+
+	// Dimensions holds the value of each dimension in Report.GroupBy, e.g.
+	// {DimensionStatus: "delivered"}.
+	Dimensions map[string]string
+}
+
+// Get retrieves a report of the product's traffic.
+func Get(c *messagebird.Client, product Product, params *Params) (*Report, error) {
+	if params == nil || params.PeriodStart.IsZero() || params.PeriodEnd.IsZero() {
+		return nil, errors.New("periodStart and periodEnd are required")
+	}
+	if !params.PeriodEnd.After(params.PeriodStart) {
+		return nil, errors.New("periodEnd must be after periodStart")
+	}
+
+	report := &Report{}
+	if err := c.Request(report, http.MethodGet, apiRoot+"/"+string(product)+"?"+paramsForReport(params).Encode(), nil); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// UnmarshalJSON collects the properties of a point other than its period and
+// count into its Dimensions.
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	point := Point{Dimensions: map[string]string{}}
+	for k, v := range raw {
+		var err error
+		switch k {
+		case "period":
+			err = json.Unmarshal(v, &point.Period)
+		case "count":
+			err = json.Unmarshal(v, &point.Count)
+		default:
+			var s string
+			if err = json.Unmarshal(v, &s); err == nil {
+				point.Dimensions[k] = s
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	*p = point
+	return nil
+}
+
+func paramsForReport(params *Params) url.Values {
+	query := url.Values{}
+	query.Set("periodStart", params.PeriodStart.Format(time.RFC3339))
+	query.Set("periodEnd", params.PeriodEnd.Format(time.RFC3339))
+
+	group := params.PeriodGroup
+	if group == "" {
+		group = PeriodGroupDay
+	}
+	query.Set("periodGroup", string(group))
+
+	if len(params.GroupBy) > 0 {
+		query.Set("groupBy", strings.Join(params.GroupBy, ","))
+	}
+	for dimension, values := range params.Filters {
+		for _, v := range values {
+			query.Add("filterBy["+dimension+"]", v)
+		}
+	}
+
+	return query
+}
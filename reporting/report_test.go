@@ -0,0 +1,72 @@
+package reporting
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
+func TestGet(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "reportObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	report, err := Get(client, ProductSMS, &Params{
+		PeriodStart: start,
+		PeriodEnd:   start.AddDate(0, 0, 2),
+		GroupBy:     []string{DimensionStatus},
+		Filters:     map[string][]string{DimensionCountry: {"NL"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error getting report: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/sms")
+
+	query := mbtest.Request.URL.Query()
+	for k, e := range map[string]string{
+		"periodStart":       "2022-03-01T00:00:00Z",
+		"periodEnd":         "2022-03-03T00:00:00Z",
+		"periodGroup":       "day",
+		"groupBy":           "status",
+		"filterBy[country]": "NL",
+	} {
+		if v := query.Get(k); v != e {
+			t.Errorf("got %s %s, expected %s", k, v, e)
+		}
+	}
+
+	if len(report.Items) != 3 {
+		t.Fatalf("got %d items, expected 3", len(report.Items))
+	}
+	p := report.Items[1]
+	if !p.Period.Equal(start) || p.Count != 3 || p.Dimensions[DimensionStatus] != "delivery_failed" {
+		t.Errorf("got point %+v, expected 3 failed on 2022-03-01", p)
+	}
+}
+
+func TestGetInvalidPeriod(t *testing.T) {
+	client := mbtest.Client(t)
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var cases = []struct {
+		name   string
+		params *Params
+	}{
+		{name: "Nil params", params: nil},
+		{name: "Missing end", params: &Params{PeriodStart: start}},
+		{name: "End before start", params: &Params{PeriodStart: start, PeriodEnd: start.Add(-time.Hour)}},
+	}
+
+	for _, tt := range cases {
+		if _, err := Get(client, ProductSMS, tt.params); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
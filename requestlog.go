@@ -0,0 +1,82 @@
+package messagebird
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxLoggedBody is the number of bytes of request and response bodies
+// included in a RequestLog.
+const maxLoggedBody = 1024
+
+// RequestLog describes a request sent by the client, with secrets redacted.
+// It is passed to the client's LogRequest hook once per attempt.
+type RequestLog struct {
+	Method  string
+	URL     string
+	Status  int           // The response status code, or zero on error.
+	Latency time.Duration // The time until the response body was read.
+	Err     error         // The error that prevented a response, if any.
+
+	// RequestID is the ID MessageBird assigned to the request, if the
+	// response included it.
+	RequestID string
+
+	// RequestBody and ResponseBody are truncated to 1024 bytes.
+	RequestBody  string
+	ResponseBody string
+}
+
+// redacted is logged in place of secrets.
+const redacted = "[REDACTED]"
+
+// secretJSON and secretForm match the values of JSON fields and form
+// parameters holding secrets, such as the token used to sign voice webhooks.
+var (
+	secretJSON = regexp.MustCompile(`(?i)("(?:signingKey|accessKey|key|token|secret|password)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	secretForm = regexp.MustCompile(`(?i)((?:^|[?&])(?:signingKey|accessKey|token|secret|password)=)[^&]*`)
+)
+
+// redact removes the client's access key and other secrets from s.
+func (c *Client) redact(s string) string {
+	if c.AccessKey != "" {
+		s = strings.ReplaceAll(s, c.AccessKey, redacted)
+	}
+	s = secretJSON.ReplaceAllString(s, `$1"`+redacted+`"`)
+	return secretForm.ReplaceAllString(s, `${1}`+redacted)
+}
+
+// logRequest passes the details of an attempt to the LogRequest hook.
+func (c *Client) logRequest(ctx context.Context, req *http.Request, resp *http.Response, body []byte, err error, latency time.Duration) {
+	rl := RequestLog{
+		Method:       req.Method,
+		URL:          c.redact(req.URL.String()),
+		Latency:      latency,
+		Err:          err,
+		ResponseBody: truncate(c.redact(string(body))),
+	}
+	if resp != nil {
+		rl.Status = resp.StatusCode
+		rl.RequestID = requestID(resp.Header)
+	}
+	if req.GetBody != nil {
+		if rb, err := req.GetBody(); err == nil {
+			b, _ := ioutil.ReadAll(rb)
+			rl.RequestBody = truncate(c.redact(string(decompressed(req, b))))
+		}
+	}
+	c.LogRequest(ctx, rl)
+}
+
+// truncate shortens s to maxLoggedBody bytes. Bodies are redacted before they
+// are truncated, so secrets cut in half are not leaked.
+func truncate(s string) string {
+	if len(s) > maxLoggedBody {
+		return s[:maxLoggedBody] + "..."
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+package messagebird
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+
+	var cases = []struct {
+		name string
+		s    string
+		e    string
+	}{
+		{
+			name: "Access key",
+			s:    "https://example.com/?access_key=test_gshuPaZoeEG6ovbc8M79w0QyM",
+			e:    "https://example.com/?access_key=[REDACTED]",
+		},
+		{
+			name: "JSON field",
+			s:    `{"url":"https://example.com","token":"sec\"ret"}`,
+			e:    `{"url":"https://example.com","token":"[REDACTED]"}`,
+		},
+		{
+			name: "Created access key",
+			s:    `{"id":"ODQ1MjA0NjAz","mode":"live","key":"live_3ZzEkliHrxxQ4Ag5hJmSjRmD3"}`,
+			e:    `{"id":"ODQ1MjA0NjAz","mode":"live","key":"[REDACTED]"}`,
+		},
+		{
+			name: "Form parameter",
+			s:    "id=123&token=123456",
+			e:    "id=123&token=[REDACTED]",
+		},
+		{
+			name: "Nothing to redact",
+			s:    `{"originator":"TestName"}`,
+			e:    `{"originator":"TestName"}`,
+		},
+	}
+
+	for _, tt := range cases {
+		if got := c.redact(tt.s); got != tt.e {
+			t.Errorf("got %s, expected %s, test case: %s", got, tt.e, tt.name)
+		}
+	}
+}
+
+func TestLogRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"token":"secret","data":"` + strings.Repeat("a", 2*maxLoggedBody) + `"}`))
+	}))
+	defer ts.Close()
+
+	var logs []RequestLog
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.LogRequest = func(_ context.Context, rl RequestLog) {
+		logs = append(logs, rl)
+	}
+
+	var v map[string]string
+	if err := c.Request(&v, http.MethodPost, ts.URL, map[string]string{"token": "secret"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(logs) != 1 {
+		t.Fatalf("got %d logs, expected 1", len(logs))
+	}
+	rl := logs[0]
+	if rl.Method != http.MethodPost || rl.Status != http.StatusOK || rl.Latency <= 0 {
+		t.Errorf("got %+v", rl)
+	}
+	if rl.RequestBody != `{"token":"[REDACTED]"}` {
+		t.Errorf("got request body %s", rl.RequestBody)
+	}
+	if strings.Contains(rl.ResponseBody, "secret") || len(rl.ResponseBody) > maxLoggedBody+3 {
+		t.Errorf("expected response body to be redacted and truncated, got %d bytes", len(rl.ResponseBody))
+	}
+}
+
+func TestDebugLogRedacted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"ODQ1MjA0NjAz","mode":"live","key":"live_3ZzEkliHrxxQ4Ag5hJmSjRmD3","signingKey":"TOh6dSS4Bo8CpmUKfJy2Ewd2xn9gvIaR"}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.DebugLog = log.New(&buf, "", 0)
+
+	var v map[string]string
+	if err := c.Request(&v, http.MethodPost, ts.URL+"/access-keys?access_key="+c.AccessKey, map[string]string{"mode": "live", "token": "secret"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v["key"] != "live_3ZzEkliHrxxQ4Ag5hJmSjRmD3" {
+		t.Errorf("got key %q, expected the created key to be decoded unredacted", v["key"])
+	}
+
+	for _, secret := range []string{"live_3ZzEkliHrxxQ4Ag5hJmSjRmD3", "TOh6dSS4Bo8CpmUKfJy2Ewd2xn9gvIaR", "secret", c.AccessKey} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("debug log contains %q: %s", secret, buf.String())
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package messagebird
+
+import (
+	"context"
+	"net/http"
+)
+
+// Response holds the metadata of an API response.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+
+	// RequestID is the ID MessageBird assigned to the request. Include it
+	// in support tickets about the request.
+	RequestID string
+}
+
+// requestIDHeaders are the headers that may hold the request ID, in order of
+// precedence.
+var requestIDHeaders = []string{"Request-Id", "X-Request-Id"}
+
+// requestID returns the request ID in the headers of a response.
+func requestID(h http.Header) string {
+	for _, key := range requestIDHeaders {
+		if id := h.Get(key); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// WithResponse returns a shallow copy of the client that stores the metadata
+// of the responses to its requests in resp, e.g. to inspect their headers:
+//
+//	var resp messagebird.Response
+//	msg, err := sms.Read(client.WithResponse(&resp), id)
+//	log.Println(resp.StatusCode, resp.RequestID, resp.Header.Get("X-RateLimit-Remaining"))
+//
+// resp holds the last response received, also if the request failed with an
+// error response. Use a separate Response for concurrent requests.
+func (c *Client) WithResponse(resp *Response) *Client {
+	c2 := *c
+	c2.response = resp
+	return &c2
+}
+
+// Do sends a request to an API endpoint the client does not support yet. The
+// path is relative to the REST endpoint, unless it is an absolute URL. The
+// body is encoded as JSON, or sent as a form if it is a string such as the
+// result of url.Values.Encode, and the response is decoded as JSON into out,
+// if not nil:
+//
+//	var out struct{ ID string }
+//	err := client.Do(ctx, http.MethodPost, "new-resource", map[string]string{"name": "x"}, &out)
+//
+// Error responses are returned as an ErrorResponse, and the client's
+// settings, such as its RetryPolicy and hooks, apply as for any request.
+func (c *Client) Do(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.RequestContext(ctx, out, method, path, body)
+}
@@ -0,0 +1,111 @@
+package messagebird
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	var gotBody map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(b, &gotBody)
+		if r.URL.Path != "/new-resource" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"code":20,"description":"not found"}]}`))
+			return
+		}
+		w.Header().Set("X-Test", "yes")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"abc"}`))
+	}))
+	defer ts.Close()
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithEndpoints(Endpoints{REST: ts.URL}))
+	var resp Response
+	var out struct{ ID string }
+	if err := c.WithResponse(&resp).Do(context.Background(), http.MethodPost, "new-resource", map[string]string{"name": "x"}, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if out.ID != "abc" || gotBody["name"] != "x" {
+		t.Errorf("got ID %q and body %v, expected abc and the name", out.ID, gotBody)
+	}
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("X-Test") != "yes" {
+		t.Errorf("got response %+v, expected the status and headers", resp)
+	}
+
+	err := c.WithResponse(&resp).Do(context.Background(), http.MethodGet, "other", nil, nil)
+	if !IsNotFound(err) {
+		t.Errorf("got %v, expected a not found error", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("got status %d for error response, expected 404", resp.StatusCode)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(r.URL.Query().Get("header"), "req-1")
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[{"code":20,"description":"not found"}]}`))
+			return
+		case "/internal":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case "/gateway":
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	var logged RequestLog
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithEndpoints(Endpoints{REST: ts.URL}))
+	c.LogRequest = func(ctx context.Context, rl RequestLog) { logged = rl }
+
+	var cases = []struct {
+		name string
+		path string
+		e    string
+	}{
+		{name: "Success", path: "resource?header=Request-Id", e: "req-1"},
+		{name: "Prefixed header", path: "resource?header=X-Request-Id", e: "req-1"},
+		{name: "Error", path: "missing?header=Request-Id", e: "req-1"},
+		{name: "Internal server error", path: "internal?header=Request-Id", e: "req-1"},
+		{name: "HTML error", path: "gateway?header=Request-Id", e: "req-1"},
+		{name: "No header", path: "resource?header=X-Test", e: ""},
+	}
+
+	for _, tt := range cases {
+		var resp Response
+		err := c.WithResponse(&resp).Do(context.Background(), http.MethodGet, tt.path, nil, nil)
+		if resp.RequestID != tt.e || logged.RequestID != tt.e {
+			t.Errorf("got request ID %q and logged %q, expected %q, test case: %s", resp.RequestID, logged.RequestID, tt.e, tt.name)
+		}
+		if err != nil && RequestID(err) != tt.e {
+			t.Errorf("got error request ID %q, expected %q, test case: %s", RequestID(err), tt.e, tt.name)
+		}
+	}
+
+	for _, path := range []string{"internal", "gateway"} {
+		err := c.Do(context.Background(), http.MethodGet, path+"?header=Request-Id", nil, nil)
+		var statusErr StatusError
+		if !errors.As(err, &statusErr) || !errors.Is(err, ErrUnexpectedResponse) || statusErr.RequestID != "req-1" {
+			t.Errorf("got %v for %s, expected an unexpected response with request ID", err, path)
+		}
+	}
+
+	if id := RequestID(ErrUnexpectedResponse); id != "" {
+		t.Errorf("got request ID %q for an error without response, expected none", id)
+	}
+}
@@ -0,0 +1,207 @@
+package messagebird
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 100 * time.Millisecond
+	defaultRetryMaxDelay  = 5 * time.Second
+)
+
+// RetryPolicy configures how a Client retries idempotent requests, i.e. those
+// that do not use POST or PATCH or carry an idempotency key, after transient
+// failures: 5xx responses, timeouts and connections that were reset or closed
+// unexpectedly.
+// Requests rejected with 429 Too Many Requests can be retried as well, with
+// any method, as they were not processed.
+//
+// Retries are delayed by an exponential backoff with full jitter: before
+// retry n, the client waits a random duration between zero and
+// min(MaxDelay, BaseDelay * 2^(n-1)).
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the first attempt. Requests are not retried if it is less
+	// than two.
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry, which doubles for
+	// every subsequent retry. It defaults to 100ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff between attempts. It defaults to 5s.
+	MaxDelay time.Duration
+
+	// RetryRateLimited enables retrying requests rejected with 429 Too Many
+	// Requests. The client waits for the duration in the Retry-After header,
+	// or the backoff if there is none, within the bounds of the request
+	// context.
+	RetryRateLimited bool
+}
+
+// retry reports whether a request should be sent again after attempt
+// resulted in resp or err.
+func (p *RetryPolicy) retry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return p.RetryRateLimited
+	}
+	if !idempotent(req) {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil && transient(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns the delay before retrying after attempt resulted in resp,
+// which may be nil.
+func (p *RetryPolicy) delay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if d := retryAfter(resp.Header, time.Now()); d > 0 {
+			return d
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// backoff returns the delay before retrying after attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	base, max := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+	if max <= 0 {
+		max = defaultRetryMaxDelay
+	}
+
+	d := base
+	for i := 1; i < attempt && d < max; i++ {
+		if d > max/2 {
+			d = max
+			break
+		}
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// idempotent reports whether req can be retried safely.
+func idempotent(req *http.Request) bool {
+	if req.Header.Get(idempotencyKeyHeader) != "" {
+		return true
+	}
+	return req.Method != http.MethodPost && req.Method != http.MethodPatch
+}
+
+// transient reports whether err is a network error that may not occur again.
+func transient(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// do sends req, retrying it according to the client's RetryPolicy, and
+// returns the response along with its body.
+func (c *Client) do(req *http.Request) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		if err := c.CircuitBreaker.allow(); err != nil {
+			return nil, nil, err
+		}
+		if err := c.RateLimiter.wait(req.Context(), req); err != nil {
+			c.CircuitBreaker.release()
+			return nil, nil, err
+		}
+		resp, body, err := c.send(req, attempt)
+		c.CircuitBreaker.record(resp, err)
+		if !c.RetryPolicy.retry(req, resp, err, attempt) {
+			return resp, body, err
+		}
+
+		delay := c.RetryPolicy.delay(resp, attempt)
+		if c.DebugLog != nil {
+			c.DebugLog.Printf("HTTP RETRY: attempt %d failed, retrying in %s", attempt, delay)
+		}
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, nil, err
+		}
+
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+}
+
+// send sends req once as the given attempt and reads the response body.
+func (c *Client) send(req *http.Request, attempt int) (*http.Response, []byte, error) {
+	if c.LogRequest == nil && c.ObserveRequest == nil {
+		return c.roundTrip(req)
+	}
+	start := time.Now()
+	resp, body, err := c.roundTrip(req)
+	latency := time.Since(start)
+	if c.LogRequest != nil {
+		c.logRequest(req.Context(), req, resp, body, err, latency)
+	}
+	if c.ObserveRequest != nil {
+		c.observeRequest(req.Context(), req, resp, err, latency, attempt)
+	}
+	return resp, body, err
+}
+
+// roundTrip sends req and reads the response body.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.Doer().Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if c.OnRateLimit != nil {
+		if rl, ok := parseRateLimit(resp, time.Now()); ok {
+			c.OnRateLimit(rl)
+		}
+	}
+
+	body, err := c.readBody(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// sleep waits for d, or returns early with the context's error when ctx is
+// done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,90 @@
+package messagebird
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var cases = []struct {
+		name     string
+		method   string
+		statuses []int
+		attempts int
+		e        bool
+	}{
+		{
+			name:     "Retries until success",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusNoContent},
+			attempts: 3,
+			e:        true,
+		},
+		{
+			name:     "Gives up after max attempts",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusNoContent},
+			attempts: 3,
+			e:        false,
+		},
+		{
+			name:     "Does not retry POST",
+			method:   http.MethodPost,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusNoContent},
+			attempts: 1,
+			e:        false,
+		},
+		{
+			name:     "Does not retry client errors",
+			method:   http.MethodDelete,
+			statuses: []int{http.StatusNotFound, http.StatusNoContent},
+			attempts: 1,
+			e:        false,
+		},
+	}
+
+	for _, tt := range cases {
+		attempts := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.statuses[attempts])
+			w.Write([]byte(`{"errors":[]}`))
+			attempts++
+		}))
+
+		c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+		c.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+		err := c.Request(nil, tt.method, ts.URL, nil)
+		ts.Close()
+
+		if (err == nil) != tt.e {
+			t.Errorf("got %v, expected success %v, test case: %s", err, tt.e, tt.name)
+		}
+		if attempts != tt.attempts {
+			t.Errorf("got %d attempts, expected %d, test case: %s", attempts, tt.attempts, tt.name)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	var cases = []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{attempt: 1, max: time.Second},
+		{attempt: 2, max: 2 * time.Second},
+		{attempt: 4, max: 8 * time.Second},
+		{attempt: 5, max: 10 * time.Second},
+		{attempt: 1000, max: 10 * time.Second},
+	}
+
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	for _, tt := range cases {
+		for i := 0; i < 100; i++ {
+			if d := p.backoff(tt.attempt); d < 0 || d > tt.max {
+				t.Fatalf("got backoff %s for attempt %d, expected at most %s", d, tt.attempt, tt.max)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// redacted is printed in place of secrets.
+const redacted = "[REDACTED]"
+
+// Secret holds sensitive configuration such as a signing key. It is redacted
+// when formatted with the fmt package, so a Config can be logged safely. It is
+// marshalled to JSON as is, as the value must survive distribution to workers.
+type Secret string
+
+// String implements fmt.Stringer.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString implements fmt.GoStringer, which is used by the %#v verb.
+func (s Secret) GoString() string {
+	return fmt.Sprintf("%q", s.String())
+}
+
+// Config is the serializable configuration of a Validator. It can be shared
+// as JSON between instances so each of them reconstructs an identical
+// validator with ValidatorFromConfig.
+type Config struct {
+	SigningKey  Secret   `json:"signingKey"`
+	SigningKeys []Secret `json:"signingKeys,omitempty"`
+
+	// ValidityWindow is a duration as accepted by time.ParseDuration, e.g.
+	// "5s". The package level ValidityWindow is used when empty.
+	ValidityWindow string `json:"validityWindow,omitempty"`
+
+	MaxBodyBytes int64 `json:"maxBodyBytes,omitempty"`
+
+	// QueryMode is "canonical", "raw", or empty to accept both.
+	QueryMode string `json:"queryMode,omitempty"`
+
+	// TrustedCIDRs and TrustForwardedFor correspond to the WithTrustedCIDRs
+	// and WithTrustForwardedFor options.
+	TrustedCIDRs      []string `json:"trustedCIDRs,omitempty"`
+	TrustForwardedFor bool     `json:"trustForwardedFor,omitempty"`
+
+	// TimestampHeader, SignatureHeader and SkipValidation correspond to the
+	// WithHeaders and WithSkipValidation options.
+	TimestampHeader string `json:"timestampHeader,omitempty"`
+	SignatureHeader string `json:"signatureHeader,omitempty"`
+	SkipValidation  bool   `json:"skipValidation,omitempty"`
+}
+
+// ValidatorFromConfig creates a validator from the provided configuration.
+func ValidatorFromConfig(cfg Config) (*Validator, error) {
+	if cfg.SigningKey == "" {
+		return nil, errors.New("signing key is required")
+	}
+
+	for _, cidr := range cfg.TrustedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("invalid trusted CIDR: %v", err)
+		}
+	}
+
+	v := NewValidator(string(cfg.SigningKey), WithTrustedCIDRs(cfg.TrustedCIDRs...))
+	v.trustForwardedFor = cfg.TrustForwardedFor
+	v.timestampHeader = cfg.TimestampHeader
+	v.signatureHeader = cfg.SignatureHeader
+	v.skipValidation = cfg.SkipValidation
+	v.MaxBodyBytes = cfg.MaxBodyBytes
+	qm, err := parseQueryMode(cfg.QueryMode)
+	if err != nil {
+		return nil, err
+	}
+	v.QueryMode = qm
+	for _, key := range cfg.SigningKeys {
+		v.SigningKeys = append(v.SigningKeys, string(key))
+	}
+
+	if cfg.ValidityWindow != "" {
+		w, err := time.ParseDuration(cfg.ValidityWindow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validity window: %v", err)
+		}
+		if w <= 0 {
+			return nil, fmt.Errorf("validity window must be positive, got %s", w)
+		}
+		v.ValidityWindow = w
+	}
+
+	return v, nil
+}
+
+// Config returns the configuration of the validator, which can be passed to
+// ValidatorFromConfig to create an identical validator.
+func (v *Validator) Config() Config {
+	cfg := Config{
+		SigningKey: Secret(v.SigningKey),
+	}
+	for _, key := range v.SigningKeys {
+		cfg.SigningKeys = append(cfg.SigningKeys, Secret(key))
+	}
+	if v.ValidityWindow != 0 {
+		cfg.ValidityWindow = v.ValidityWindow.String()
+	}
+	for _, n := range v.trustedNets {
+		cfg.TrustedCIDRs = append(cfg.TrustedCIDRs, n.String())
+	}
+	cfg.TrustForwardedFor = v.trustForwardedFor
+	cfg.TimestampHeader = v.timestampHeader
+	cfg.SignatureHeader = v.signatureHeader
+	cfg.SkipValidation = v.skipValidation
+	cfg.MaxBodyBytes = v.MaxBodyBytes
+	cfg.QueryMode = v.QueryMode.String()
+	return cfg
+}
@@ -0,0 +1,92 @@
+package signature
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	v := NewValidator(testKey, WithTrustedCIDRs("10.0.0.0/8"), WithTrustForwardedFor(), WithHeaders("X-Timestamp", "X-Signature"))
+	v.ValidityWindow = 30 * time.Second
+	v.SigningKeys = []string{"old-secret"}
+	v.MaxBodyBytes = 1024
+
+	b, err := json.Marshal(v.Config())
+	if err != nil {
+		t.Fatalf("unexpected error marshalling config: %s", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		t.Fatalf("unexpected error unmarshalling config: %s", err)
+	}
+
+	got, err := ValidatorFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error creating validator: %s", err)
+	}
+
+	if got.SigningKey != testKey {
+		t.Errorf("got signing key %q, expected %q", got.SigningKey, testKey)
+	}
+	if len(got.SigningKeys) != 1 || got.SigningKeys[0] != "old-secret" {
+		t.Errorf("got signing keys %q, expected [old-secret]", got.SigningKeys)
+	}
+	if got.ValidityWindow != 30*time.Second {
+		t.Errorf("got validity window %s, expected 30s", got.ValidityWindow)
+	}
+	if got.MaxBodyBytes != 1024 {
+		t.Errorf("got max body bytes %d, expected 1024", got.MaxBodyBytes)
+	}
+	if len(got.trustedNets) != 1 || got.trustedNets[0].String() != "10.0.0.0/8" || !got.trustForwardedFor {
+		t.Errorf("got trusted nets %v and forwarded for %v, expected 10.0.0.0/8 and true", got.trustedNets, got.trustForwardedFor)
+	}
+	if tsh, sh := got.headers(); tsh != "X-Timestamp" || sh != "X-Signature" {
+		t.Errorf("got headers %s and %s, expected X-Timestamp and X-Signature", tsh, sh)
+	}
+}
+
+func TestConfigRedactsSigningKey(t *testing.T) {
+	v := NewValidator(testKey)
+	v.SigningKeys = []string{"old-secret"}
+	cfg := v.Config()
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		if s := fmt.Sprintf(format, cfg); strings.Contains(s, testKey) || strings.Contains(s, "old-secret") {
+			t.Errorf("format %s leaked the signing key: %s", format, s)
+		}
+	}
+}
+
+func TestValidatorFromConfigErrors(t *testing.T) {
+	var cases = []struct {
+		name string
+		cfg  Config
+	}{
+		{
+			name: "Missing signing key",
+			cfg:  Config{},
+		},
+		{
+			name: "Invalid validity window",
+			cfg:  Config{SigningKey: testKey, ValidityWindow: "five seconds"},
+		},
+		{
+			name: "Invalid trusted CIDR",
+			cfg:  Config{SigningKey: testKey, TrustedCIDRs: []string{"10.0.0.0"}},
+		},
+		{
+			name: "Negative validity window",
+			cfg:  Config{SigningKey: testKey, ValidityWindow: "-5s"},
+		},
+	}
+
+	for _, tt := range cases {
+		if _, err := ValidatorFromConfig(tt.cfg); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
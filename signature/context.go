@@ -0,0 +1,47 @@
+package signature
+
+import (
+	"context"
+	"time"
+)
+
+// Scheme identifies how a webhook was signed.
+type Scheme string
+
+const (
+	// SchemeHMAC is used for the MessageBird-Signature header.
+	SchemeHMAC Scheme = "hmac"
+
+	// SchemeJWT is used for the MessageBird-Signature-JWT header.
+	SchemeJWT Scheme = "jwt"
+)
+
+// Metadata describes a successfully validated request.
+type Metadata struct {
+	Scheme Scheme
+
+	// Timestamp is the time MessageBird signed the request, taken from the
+	// MessageBird-Request-Timestamp header or the JWT issued at claim.
+	Timestamp time.Time
+
+	// KeyIndex identifies the key that produced the signature: 0 for
+	// SigningKey, followed by SigningKeys and the keys of the KeyProvider in
+	// order.
+	KeyIndex int
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying md.
+func NewContext(ctx context.Context, md *Metadata) context.Context {
+	return context.WithValue(ctx, contextKey{}, md)
+}
+
+// FromContext returns the metadata attached to the request context by the
+// Validate handler wrappers. It is not set for requests that skipped
+// validation because they came from a trusted source or validation is
+// disabled.
+func FromContext(ctx context.Context) (*Metadata, bool) {
+	md, ok := ctx.Value(contextKey{}).(*Metadata)
+	return md, ok
+}
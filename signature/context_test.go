@@ -0,0 +1,59 @@
+package signature
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	now := time.Unix(1544544948, 0)
+	s := NewSigner("old-secret")
+	s.Now = func() time.Time { return now }
+
+	v := NewValidator(testKey)
+	v.SigningKeys = []string{"old-secret"}
+	v.Now = s.Now
+
+	var md *Metadata
+	h := v.Validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md, _ = FromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+	if err := s.Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	if md == nil {
+		t.Fatalf("expected metadata in request context")
+	}
+	if md.Scheme != SchemeHMAC || md.KeyIndex != 1 || !md.Timestamp.Equal(now) {
+		t.Errorf("got %+v, expected scheme %s, key index 1 and timestamp %s", md, SchemeHMAC, now)
+	}
+}
+
+func TestJWTValidateMetadata(t *testing.T) {
+	now := time.Unix(1544544948, 0)
+	v := NewJWTValidator(testKey)
+	v.Now = func() time.Time { return now }
+
+	var md *Metadata
+	h := v.Validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md, _ = FromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, testURL, strings.NewReader(testBody))
+	r.Header.Set(jwtHeader, testJWT(t, testKey, "HS256", testClaims(now, testURL, testBody)))
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	if md == nil {
+		t.Fatalf("expected metadata in request context")
+	}
+	if md.Scheme != SchemeJWT || md.KeyIndex != 0 || !md.Timestamp.Equal(now) {
+		t.Errorf("got %+v, expected scheme %s, key index 0 and timestamp %s", md, SchemeJWT, now)
+	}
+}
@@ -0,0 +1,157 @@
+package signature
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Diagnosis reports the outcome of each check performed while validating a
+// captured webhook request. The timestamp is not checked against the validity
+// window, as captured requests are replayed after the fact.
+type Diagnosis struct {
+	Timestamp string // The MessageBird-Request-Timestamp header.
+	Signature string // The MessageBird-Signature header.
+
+	HeadersPresent   bool // Both signature headers are set.
+	TimestampParses  bool // The timestamp is a valid Unix timestamp.
+	QueryParses      bool // The query string can be prepared for signing.
+	SignatureDecodes bool // The signature is valid base64.
+	SignatureMatches bool // The signature matches the expected signature.
+}
+
+// Err returns the error describing the first check that failed, or nil if the
+// request passed all checks. The error matches the exported Err values.
+func (d *Diagnosis) Err() error {
+	switch {
+	case !d.HeadersPresent:
+		return ErrMissingSignature
+	case !d.TimestampParses:
+		return ErrMalformedTimestamp
+	case !d.QueryParses:
+		return fmt.Errorf("%w: malformed query string", ErrMalformedSignature)
+	case !d.SignatureDecodes:
+		return fmt.Errorf("%w: invalid base64", ErrMalformedSignature)
+	case !d.SignatureMatches:
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// DiagnoseDump parses a raw HTTP request dump, i.e. the request line, headers
+// and body as sent over the wire, and reports which signature checks it passes.
+// An error is only returned if the dump can not be parsed.
+func (v *Validator) DiagnoseDump(dump io.Reader) (*Diagnosis, error) {
+	r, err := http.ReadRequest(bufio.NewReader(dump))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.diagnose(r, b), nil
+}
+
+// diagnose runs the individual validation checks on r with body b.
+func (v *Validator) diagnose(r *http.Request, b []byte) *Diagnosis {
+	tsh, sh := v.headers()
+	d := &Diagnosis{
+		Timestamp: r.Header.Get(tsh),
+		Signature: r.Header.Get(sh),
+	}
+
+	if d.HeadersPresent = d.Timestamp != "" && d.Signature != ""; !d.HeadersPresent {
+		return d
+	}
+
+	if _, err := stringToTime(d.Timestamp); err != nil {
+		return d
+	}
+	d.TimestampParses = true
+
+	qps, err := signedQueries(v.QueryMode, r.URL.RawQuery)
+	if err != nil {
+		return d
+	}
+	d.QueryParses = true
+
+	drs, err := base64.StdEncoding.DecodeString(d.Signature)
+	if err != nil {
+		return d
+	}
+	d.SignatureDecodes = true
+
+	bh := sha256.Sum256(b)
+	for _, qp := range qps {
+		if v.matchingKey(d.Timestamp, qp, bh[:], drs) >= 0 {
+			d.SignatureMatches = true
+			break
+		}
+	}
+
+	return d
+}
+
+// SigningString is the input of the HMAC signature of a request, with the
+// signatures the validator computes from it.
+type SigningString struct {
+	Timestamp string
+	Query     string // The query as signed, e.g. sorted and re-encoded.
+	BodyHash  string // The hex encoded SHA-256 sum of the body.
+
+	// Signatures are the base64 encoded signatures for each non-empty signing
+	// key, in the order of SigningKey, SigningKeys and the keys of
+	// KeyProvider. A request is valid if its signature equals one of them.
+	Signatures []string
+}
+
+// String returns the signed string, with the body hash hex encoded for
+// display. The signed bytes contain the raw sum instead.
+func (s *SigningString) String() string {
+	return s.Timestamp + "\n" + s.Query + "\n" + s.BodyHash
+}
+
+// Inspect returns the signing strings the validator checks the signature of
+// r against, to debug signature mismatches. There are two with QueryAuto
+// if the canonical query differs from the raw query. The timestamp header is
+// used as is, also when it is missing or malformed. The body is read and
+// restored, like ValidRequest does.
+func (v *Validator) Inspect(r *http.Request) ([]*SigningString, error) {
+	tsh, _ := v.headers()
+	ts := r.Header.Get(tsh)
+
+	_, bh, err := readBody(r, v.MaxBodyBytes)
+	if err != nil {
+		return nil, err
+	}
+	qps, err := signedQueries(v.QueryMode, r.URL.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed query string", ErrMalformedSignature)
+	}
+
+	var signing []*SigningString
+	for _, qp := range qps {
+		s := &SigningString{Timestamp: ts, Query: qp, BodyHash: hex.EncodeToString(bh)}
+		for _, key := range v.keys() {
+			if key == "" {
+				continue
+			}
+			sig, err := hmacSignature(key, ts, qp, bh)
+			if err != nil {
+				return nil, err
+			}
+			s.Signatures = append(s.Signatures, base64.StdEncoding.EncodeToString(sig))
+		}
+		signing = append(signing, s)
+	}
+	return signing, nil
+}
@@ -0,0 +1,104 @@
+package signature
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testDump(ts, s, qp, body string) string {
+	return fmt.Sprintf("POST /webhook?%s HTTP/1.1\r\nHost: example.com\r\n%s: %s\r\n%s: %s\r\nContent-Length: %d\r\n\r\n%s",
+		qp, tsHeader, ts, sHeader, s, len(body), body)
+}
+
+func TestDiagnoseDump(t *testing.T) {
+	var cases = []struct {
+		name string
+		dump string
+		e    error
+	}{
+		{
+			name: "Succesful",
+			dump: testDump(testTs, testSignature, testQp, testBody),
+			e:    nil,
+		},
+		{
+			name: "Missing headers",
+			dump: "POST /webhook HTTP/1.1\r\nHost: example.com\r\n\r\n",
+			e:    ErrMissingSignature,
+		},
+		{
+			name: "Malformed timestamp",
+			dump: testDump("wrongTs", testSignature, testQp, testBody),
+			e:    ErrMalformedTimestamp,
+		},
+		{
+			name: "Malformed signature",
+			dump: testDump(testTs, "wrong signature", testQp, testBody),
+			e:    ErrMalformedSignature,
+		},
+		{
+			name: "Modified body",
+			dump: testDump(testTs, testSignature, testQp, `{"a key":"other value"}`),
+			e:    ErrSignatureMismatch,
+		},
+	}
+
+	v := NewValidator(testKey)
+	for _, tt := range cases {
+		d, err := v.DiagnoseDump(strings.NewReader(tt.dump))
+		if err != nil {
+			t.Fatalf("unexpected error parsing dump: %s, test case: %s", err, tt.name)
+		}
+
+		if err := d.Err(); !errors.Is(err, tt.e) {
+			t.Errorf("got %v, expected %v, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+func TestDiagnoseDumpMalformed(t *testing.T) {
+	v := NewValidator(testKey)
+	if _, err := v.DiagnoseDump(strings.NewReader("not a request")); err == nil {
+		t.Errorf("expected error parsing malformed dump, got nil")
+	}
+}
+
+func TestInspect(t *testing.T) {
+	r, err := http.ReadRequest(bufio.NewReader(strings.NewReader(testDump(testTs, testSignature, "def=bar&abc=foo", testBody))))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	v := NewValidator("old-secret")
+	v.SigningKeys = []string{testKey}
+	signing, err := v.Inspect(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(signing) != 2 || signing[0].Query != testQp || signing[1].Query != "def=bar&abc=foo" {
+		t.Fatalf("got %v, expected the canonical and the raw query", signing)
+	}
+
+	s := signing[0]
+	bh := sha256.Sum256([]byte(testBody))
+	if s.Timestamp != testTs || s.BodyHash != hex.EncodeToString(bh[:]) {
+		t.Errorf("got timestamp %s and body hash %s", s.Timestamp, s.BodyHash)
+	}
+	if e := testTs + "\n" + testQp + "\n" + s.BodyHash; s.String() != e {
+		t.Errorf("got signing string %q, expected %q", s.String(), e)
+	}
+	if len(s.Signatures) != 2 || s.Signatures[1] != testSignature {
+		t.Errorf("got signatures %v, expected %s for the second key", s.Signatures, testSignature)
+	}
+
+	if b, err := ioutil.ReadAll(r.Body); err != nil || string(b) != testBody {
+		t.Errorf("got body %q, %v, expected it to be restored", b, err)
+	}
+}
@@ -0,0 +1,71 @@
+package signature
+
+import "errors"
+
+// Errors returned when validating requests. They may be wrapped to provide
+// additional details, so compare them using errors.Is.
+var (
+	// ErrMissingSignature is returned when the request does not carry the
+	// signature or timestamp header.
+	ErrMissingSignature = errors.New("signature: missing signature")
+
+	// ErrMalformedTimestamp is returned when the request timestamp is not a
+	// valid Unix timestamp.
+	ErrMalformedTimestamp = errors.New("signature: malformed timestamp")
+
+	// ErrTimestampExpired is returned when the request timestamp lies outside
+	// of the validity window.
+	ErrTimestampExpired = errors.New("signature: timestamp outside of validity window")
+
+	// ErrMalformedSignature is returned when the signature can not be decoded
+	// or the request can not be canonicalized for verification.
+	ErrMalformedSignature = errors.New("signature: malformed signature")
+
+	// ErrSignatureMismatch is returned when the signature was not created
+	// with any of the accepted signing keys for this request.
+	ErrSignatureMismatch = errors.New("signature: signature mismatch")
+
+	// ErrBodyRead matches the BodyReadError returned when the request body
+	// could not be read.
+	ErrBodyRead = errors.New("signature: could not read body")
+
+	// ErrBodyTooLarge is returned when the request body exceeds the
+	// configured MaxBodyBytes.
+	ErrBodyTooLarge = errors.New("signature: body too large")
+
+	// ErrLegacySignature is returned by a SchemeValidator requiring JWT
+	// signatures for requests signed with the MessageBird-Signature header.
+	ErrLegacySignature = errors.New("signature: legacy signature not accepted")
+
+	// ErrReplayed is returned when a request with the same timestamp and
+	// signature was already validated within the validity window.
+	ErrReplayed = errors.New("signature: request replayed")
+
+	// ErrReplayCheck is returned when the ReplayStore failed. Requests are
+	// rejected in that case, as replays can not be ruled out.
+	ErrReplayCheck = errors.New("signature: could not check for replay")
+
+	// ErrInvalidTrustedCIDR is returned for all requests when a range passed
+	// to WithTrustedCIDRs could not be parsed.
+	ErrInvalidTrustedCIDR = errors.New("signature: invalid trusted CIDR")
+)
+
+// BodyReadError is returned when the request body could not be read. It
+// matches ErrBodyRead and unwraps to the error returned by the body.
+type BodyReadError struct {
+	Err error
+}
+
+func (e *BodyReadError) Error() string {
+	return ErrBodyRead.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the body.
+func (e *BodyReadError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrBodyRead.
+func (e *BodyReadError) Is(target error) bool {
+	return target == ErrBodyRead
+}
@@ -0,0 +1,262 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const jwtHeader = "MessageBird-Signature-JWT"
+
+// jwtIssuer is the issuer set by MessageBird in all webhook signatures.
+const jwtIssuer = "MessageBird"
+
+// jwtAlgorithms maps the supported JWT signing algorithms to their hash.
+var jwtAlgorithms = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+// JWTValidator validates the MessageBird-Signature-JWT header of incoming
+// webhooks. The header contains a JSON Web Token signed with your signing
+// key, which includes hashes of the URL and payload of the request:
+//
+//	validator := signature.NewJWTValidator("your signing key")
+//	http.Handle("/path", validator.Validate(YourHandler))
+type JWTValidator struct {
+	SigningKey string // Signing Key provided by MessageBird.
+
+	// SigningKeys and KeyProvider provide additional accepted keys, as they
+	// do for the Validator.
+	SigningKeys []string
+	KeyProvider func() []string
+
+	// BaseURL is the scheme and host the webhook was sent to, e.g.
+	// https://example.com. The URL is part of the signature, so this must be
+	// set if it can not be derived from the request, e.g. behind a proxy.
+	BaseURL string
+
+	// MaxBodyBytes limits the size of accepted request bodies, as it does for
+	// the Validator.
+	MaxBodyBytes int64
+
+	// Leeway is the clock skew tolerated when checking the token's issued
+	// at, not before and expiry claims.
+	Leeway time.Duration
+
+	// Now returns the current time used to check the token's claims. It
+	// defaults to time.Now.
+	Now func() time.Time
+
+	// OnReject is called by Validate with each rejected request, as it is
+	// for the Validator.
+	OnReject func(r *http.Request, err error)
+}
+
+// NewJWTValidator returns a JWT signature validator object.
+func NewJWTValidator(signingKey string) *JWTValidator {
+	return &JWTValidator{
+		SigningKey: signingKey,
+		Leeway:     time.Second,
+	}
+}
+
+// jwtClaims holds the claims MessageBird sets in webhook signatures.
+type jwtClaims struct {
+	Issuer      string `json:"iss"`
+	IssuedAt    *int64 `json:"iat"`
+	NotBefore   *int64 `json:"nbf"`
+	Expiry      *int64 `json:"exp"`
+	URLHash     string `json:"url_hash"`
+	PayloadHash string `json:"payload_hash"`
+}
+
+// ValidRequest validates the MessageBird-Signature-JWT header of r. The body
+// is read and restored, also when validation fails, so it can be used by
+// subsequent handlers.
+func (v *JWTValidator) ValidRequest(r *http.Request) error {
+	_, err := v.validRequest(r)
+	return err
+}
+
+// validRequest is like ValidRequest, but returns the metadata of valid
+// requests.
+func (v *JWTValidator) validRequest(r *http.Request) (*Metadata, error) {
+	token := r.Header.Get(jwtHeader)
+	if token == "" {
+		return nil, ErrMissingSignature
+	}
+
+	b, bh, err := readBody(r, v.MaxBodyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	return v.checkToken(token, v.requestURL(r), len(b) == 0, bh, now())
+}
+
+// Validate is a handler wrapper that takes care of the signature validation of
+// incoming requests and rejects them if invalid or pass them on to your handler
+// otherwise. The Metadata of valid requests is available to your handler
+// through FromContext.
+func (v *JWTValidator) Validate(h http.Handler) http.Handler {
+	return validate(h, v.validRequest, v.OnReject)
+}
+
+// Check validates r like Validate; see Validator.Check.
+func (v *JWTValidator) Check(r *http.Request) (*http.Request, error) {
+	return check(r, v.validRequest, v.OnReject)
+}
+
+// requestURL reconstructs the absolute URL MessageBird sent the request to.
+func (v *JWTValidator) requestURL(r *http.Request) string {
+	if v.BaseURL != "" {
+		return strings.TrimSuffix(v.BaseURL, "/") + r.URL.RequestURI()
+	}
+	if r.URL.IsAbs() {
+		return r.URL.String()
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// validToken verifies the token's signature and its claims against the
+// request URL u and body SHA-256 sum bh at time now.
+func (v *JWTValidator) validToken(token, u string, empty bool, bh []byte, now time.Time) error {
+	_, err := v.checkToken(token, u, empty, bh, now)
+	return err
+}
+
+// checkToken is like validToken, but returns the metadata of valid tokens.
+func (v *JWTValidator) checkToken(token, u string, empty bool, bh []byte, now time.Time) (*Metadata, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed JWT", ErrMalformedSignature)
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed JWT header: %v", ErrMalformedSignature, err)
+	}
+	newHash, ok := jwtAlgorithms[header.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported JWT algorithm %q", ErrMalformedSignature, header.Algorithm)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed JWT signature: %v", ErrMalformedSignature, err)
+	}
+	i := v.matchingKey(newHash, parts[0]+"."+parts[1], sig)
+	if i < 0 {
+		return nil, ErrSignatureMismatch
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed JWT claims: %v", ErrMalformedSignature, err)
+	}
+
+	if err := v.validClaims(&claims, u, empty, bh, now); err != nil {
+		return nil, err
+	}
+
+	return &Metadata{Scheme: SchemeJWT, Timestamp: time.Unix(*claims.IssuedAt, 0), KeyIndex: i}, nil
+}
+
+// keys returns all signing keys accepted by the validator.
+func (v *JWTValidator) keys() []string {
+	return signingKeys(v.SigningKey, v.SigningKeys, v.KeyProvider)
+}
+
+// matchingKey returns the index in keys() of the key that produces signature
+// sig for the signing input, or -1 if no key does.
+func (v *JWTValidator) matchingKey(newHash func() hash.Hash, input string, sig []byte) int {
+	for i, key := range v.keys() {
+		if key == "" {
+			continue
+		}
+		mac := hmac.New(newHash, []byte(key))
+		mac.Write([]byte(input))
+		if hmac.Equal(sig, mac.Sum(nil)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// validClaims checks the registered and MessageBird specific claims.
+func (v *JWTValidator) validClaims(c *jwtClaims, u string, empty bool, bh []byte, now time.Time) error {
+	if c.Issuer != jwtIssuer {
+		return fmt.Errorf("%w: unexpected JWT issuer %q", ErrSignatureMismatch, c.Issuer)
+	}
+
+	if c.IssuedAt == nil {
+		return fmt.Errorf("%w: missing JWT issued at claim", ErrMalformedSignature)
+	}
+	if time.Unix(*c.IssuedAt, 0).After(now.Add(v.Leeway)) {
+		return fmt.Errorf("%w: JWT issued in the future", ErrTimestampExpired)
+	}
+	if c.NotBefore != nil && time.Unix(*c.NotBefore, 0).After(now.Add(v.Leeway)) {
+		return fmt.Errorf("%w: JWT not valid yet", ErrTimestampExpired)
+	}
+	if c.Expiry == nil {
+		return fmt.Errorf("%w: missing JWT expiry claim", ErrMalformedSignature)
+	}
+	if !time.Unix(*c.Expiry, 0).After(now.Add(-v.Leeway)) {
+		return fmt.Errorf("%w: JWT expired", ErrTimestampExpired)
+	}
+
+	uh := sha256.Sum256([]byte(u))
+	if !equalHash(c.URLHash, uh[:]) {
+		return fmt.Errorf("%w: JWT url hash", ErrSignatureMismatch)
+	}
+
+	// The payload hash is only set for requests with a body.
+	if empty {
+		if c.PayloadHash != "" {
+			return fmt.Errorf("%w: JWT payload hash set for empty body", ErrSignatureMismatch)
+		}
+		return nil
+	}
+	if !equalHash(c.PayloadHash, bh) {
+		return fmt.Errorf("%w: JWT payload hash", ErrSignatureMismatch)
+	}
+
+	return nil
+}
+
+// equalHash reports whether hexHash is the hex encoding of sum.
+func equalHash(hexHash string, sum []byte) bool {
+	h, err := hex.DecodeString(hexHash)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(h, sum)
+}
+
+func decodeJWTSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,247 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testURL = "https://example.com/webhook?" + testQp
+
+func testJWT(t *testing.T, key, alg string, claims map[string]interface{}) string {
+	enc := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("unexpected error marshalling JWT segment: %s", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	unsigned := enc(map[string]string{"alg": alg, "typ": "JWT"}) + "." + enc(claims)
+	mac := hmac.New(jwtAlgorithms[alg], []byte(key))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func testClaims(now time.Time, u, body string) map[string]interface{} {
+	claims := map[string]interface{}{
+		"iss":      jwtIssuer,
+		"iat":      now.Unix(),
+		"nbf":      now.Unix(),
+		"exp":      now.Add(5 * time.Minute).Unix(),
+		"jti":      "some-id",
+		"url_hash": sha256Hex(u),
+	}
+	if body != "" {
+		claims["payload_hash"] = sha256Hex(body)
+	}
+	return claims
+}
+
+func TestJWTValidToken(t *testing.T) {
+	now := time.Now()
+	with := func(key string, value interface{}) map[string]interface{} {
+		c := testClaims(now, testURL, testBody)
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+
+	var cases = []struct {
+		name  string
+		token string
+		u     string
+		b     string
+		e     bool
+	}{
+		{
+			name:  "Succesful",
+			token: testJWT(t, testKey, "HS256", testClaims(now, testURL, testBody)),
+			b:     testBody,
+			e:     true,
+		},
+		{
+			name:  "Succesful HS512",
+			token: testJWT(t, testKey, "HS512", testClaims(now, testURL, testBody)),
+			b:     testBody,
+			e:     true,
+		},
+		{
+			name:  "Empty body",
+			token: testJWT(t, testKey, "HS256", testClaims(now, testURL, "")),
+			b:     "",
+			e:     true,
+		},
+		{
+			name:  "Wrong key",
+			token: testJWT(t, "secret", "HS256", testClaims(now, testURL, testBody)),
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Unsupported algorithm",
+			token: strings.Replace(testJWT(t, testKey, "HS256", testClaims(now, testURL, testBody)), "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9", "eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0", 1),
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Malformed token",
+			token: "not.a-token",
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Wrong issuer",
+			token: testJWT(t, testKey, "HS256", with("iss", "someone")),
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Expired",
+			token: testJWT(t, testKey, "HS256", with("exp", now.Add(-time.Minute).Unix())),
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Missing expiry",
+			token: testJWT(t, testKey, "HS256", with("exp", nil)),
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Not valid yet",
+			token: testJWT(t, testKey, "HS256", with("nbf", now.Add(time.Minute).Unix())),
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Issued in the future",
+			token: testJWT(t, testKey, "HS256", with("iat", now.Add(time.Minute).Unix())),
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Different URL",
+			token: testJWT(t, testKey, "HS256", testClaims(now, testURL, testBody)),
+			u:     "https://example.com/other",
+			b:     testBody,
+			e:     false,
+		},
+		{
+			name:  "Different body",
+			token: testJWT(t, testKey, "HS256", testClaims(now, testURL, testBody)),
+			b:     `{"a key":"other value"}`,
+			e:     false,
+		},
+		{
+			name:  "Payload hash for empty body",
+			token: testJWT(t, testKey, "HS256", testClaims(now, testURL, testBody)),
+			b:     "",
+			e:     false,
+		},
+	}
+
+	v := NewJWTValidator(testKey)
+	for _, tt := range cases {
+		u := testURL
+		if tt.u != "" {
+			u = tt.u
+		}
+		bh := sha256.Sum256([]byte(tt.b))
+		err := v.validToken(tt.token, u, tt.b == "", bh[:], now)
+		if (err == nil) != tt.e {
+			t.Errorf("got %v, expected valid %v, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+func TestJWTValidate(t *testing.T) {
+	v := NewJWTValidator(testKey)
+	v.BaseURL = "https://example.com"
+	ts := httptest.NewServer(v.Validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+	defer ts.Close()
+
+	var cases = []struct {
+		name  string
+		token string
+		e     int
+	}{
+		{
+			name:  "Succesful",
+			token: testJWT(t, testKey, "HS256", testClaims(time.Now(), testURL, testBody)),
+			e:     http.StatusOK,
+		},
+		{
+			name:  "Missing header",
+			token: "",
+			e:     http.StatusUnauthorized,
+		},
+		{
+			name:  "Wrong key",
+			token: testJWT(t, "secret", "HS256", testClaims(time.Now(), testURL, testBody)),
+			e:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range cases {
+		req, _ := http.NewRequest("POST", ts.URL+"/webhook?"+testQp, strings.NewReader(testBody))
+		if tt.token != "" {
+			req.Header.Set(jwtHeader, tt.token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != tt.e {
+			t.Errorf("Unexpected response code: %s, test case: %s", res.Status, tt.name)
+		}
+	}
+}
+
+func TestJWTKeyRotation(t *testing.T) {
+	now := time.Now()
+	token := testJWT(t, testKey, "HS256", testClaims(now, testURL, testBody))
+
+	bh := sha256.Sum256([]byte(testBody))
+
+	v := NewJWTValidator("new-secret")
+	if err := v.validToken(token, testURL, false, bh[:], now); err == nil {
+		t.Fatalf("expected error validating with a different key, got nil")
+	}
+
+	v.SigningKeys = []string{testKey}
+	if err := v.validToken(token, testURL, false, bh[:], now); err != nil {
+		t.Errorf("unexpected error validating with the old key: %s", err)
+	}
+}
+
+func TestJWTValidRequestWithClock(t *testing.T) {
+	recorded := time.Unix(1544544948, 0)
+	token := testJWT(t, testKey, "HS256", testClaims(recorded, testURL, testBody))
+
+	v := NewJWTValidator(testKey)
+	v.Now = func() time.Time { return recorded }
+
+	r := httptest.NewRequest(http.MethodPost, testURL, strings.NewReader(testBody))
+	r.Header.Set(jwtHeader, token)
+	if err := v.ValidRequest(r); err != nil {
+		t.Errorf("unexpected error validating recorded request: %s", err)
+	}
+}
@@ -0,0 +1,153 @@
+package signature
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Option configures a Validator created by NewValidator.
+type Option func(*Validator)
+
+// WithTrustedCIDRs skips signature validation for requests originating from
+// the provided CIDR ranges, e.g. "10.0.0.0/8".
+//
+// This is a security sensitive escape hatch meant for internal relays that
+// are authenticated by other means (e.g. mTLS at the edge) and can not carry
+// a MessageBird signature. Anyone able to send requests from these ranges
+// bypasses validation. Each bypass is logged to the validator's Logger.
+//
+// The client IP is taken from r.RemoteAddr, unless WithTrustForwardedFor is
+// used as well. If a range can not be parsed, the validator rejects all
+// requests with an error matching ErrInvalidTrustedCIDR.
+func WithTrustedCIDRs(cidrs ...string) Option {
+	nets, err := parseCIDRs(cidrs)
+	return func(v *Validator) {
+		if err != nil {
+			v.optionErr = err
+			return
+		}
+		v.trustedNets = append(v.trustedNets, nets...)
+	}
+}
+
+// MustTrustedCIDRs is like WithTrustedCIDRs, but panics if a range can not be
+// parsed.
+func MustTrustedCIDRs(cidrs ...string) Option {
+	if _, err := parseCIDRs(cidrs); err != nil {
+		panic(err.Error())
+	}
+	return WithTrustedCIDRs(cidrs...)
+}
+
+// parseCIDRs parses the ranges passed to WithTrustedCIDRs.
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidTrustedCIDR, cidr, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// WithTrustForwardedFor makes WithTrustedCIDRs determine the client IP from
+// the last address in the X-Forwarded-For header, i.e. the one added by the
+// proxy closest to this server. Only use it when all traffic passes through
+// a proxy that sets this header, as clients can otherwise spoof it.
+func WithTrustForwardedFor() Option {
+	return func(v *Validator) {
+		v.trustForwardedFor = true
+	}
+}
+
+// WithHeaders makes the validator read the timestamp and signature from the
+// provided headers instead of MessageBird-Request-Timestamp and
+// MessageBird-Signature, e.g. when a proxy renames them.
+func WithHeaders(timestamp, signature string) Option {
+	return func(v *Validator) {
+		v.timestampHeader = timestamp
+		v.signatureHeader = signature
+	}
+}
+
+// WithSkipValidation accepts all requests without validating their signature.
+// It is meant for local development environments that send unsigned test
+// webhooks and must never be used in production. Each skipped request is
+// logged to the validator's Logger.
+func WithSkipValidation() Option {
+	return func(v *Validator) {
+		v.skipValidation = true
+	}
+}
+
+// headers returns the names of the timestamp and signature headers.
+func (v *Validator) headers() (string, string) {
+	ts, s := tsHeader, sHeader
+	if v.timestampHeader != "" {
+		ts = v.timestampHeader
+	}
+	if v.signatureHeader != "" {
+		s = v.signatureHeader
+	}
+	return ts, s
+}
+
+// skipped reports whether validation is disabled for r.
+func (v *Validator) skipped(r *http.Request) bool {
+	if v.skipValidation {
+		v.logf("signature: skipping validation for %s %s, validation is disabled", r.Method, r.URL.Path)
+		return true
+	}
+	return v.trustedSource(r)
+}
+
+// trustedSource reports whether r originates from a trusted CIDR range and
+// can skip signature validation.
+func (v *Validator) trustedSource(r *http.Request) bool {
+	if len(v.trustedNets) == 0 {
+		return false
+	}
+
+	ip := v.clientIP(r)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range v.trustedNets {
+		if n.Contains(ip) {
+			v.logf("signature: skipping validation for %s %s from trusted address %s", r.Method, r.URL.Path, ip)
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the IP address the request originates from, or nil if it
+// can not be determined.
+func (v *Validator) clientIP(r *http.Request) net.IP {
+	if v.trustForwardedFor {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			addrs := strings.Split(xff, ",")
+			return net.ParseIP(strings.TrimSpace(addrs[len(addrs)-1]))
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
+
+func (v *Validator) logf(format string, args ...interface{}) {
+	if v.Logger != nil {
+		v.Logger.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
@@ -0,0 +1,140 @@
+package signature
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithTrustedCIDRs(t *testing.T) {
+	var cases = []struct {
+		name       string
+		opts       []Option
+		remoteAddr string
+		xff        string
+		e          bool
+	}{
+		{
+			name:       "In range",
+			opts:       []Option{WithTrustedCIDRs("10.0.0.0/8")},
+			remoteAddr: "10.1.2.3:1234",
+			e:          true,
+		},
+		{
+			name:       "Out of range",
+			opts:       []Option{WithTrustedCIDRs("10.0.0.0/8")},
+			remoteAddr: "192.168.1.1:1234",
+			e:          false,
+		},
+		{
+			name:       "IPv6 in range",
+			opts:       []Option{WithTrustedCIDRs("fd00::/8")},
+			remoteAddr: "[fd00::1]:1234",
+			e:          true,
+		},
+		{
+			name:       "Forwarded for ignored by default",
+			opts:       []Option{WithTrustedCIDRs("10.0.0.0/8")},
+			remoteAddr: "192.168.1.1:1234",
+			xff:        "10.1.2.3",
+			e:          false,
+		},
+		{
+			name:       "Forwarded for trusted",
+			opts:       []Option{WithTrustedCIDRs("10.0.0.0/8"), WithTrustForwardedFor()},
+			remoteAddr: "192.168.1.1:1234",
+			xff:        "203.0.113.1, 10.1.2.3",
+			e:          true,
+		},
+		{
+			name:       "Spoofed forwarded for",
+			opts:       []Option{WithTrustedCIDRs("10.0.0.0/8"), WithTrustForwardedFor()},
+			remoteAddr: "192.168.1.1:1234",
+			xff:        "10.1.2.3, 203.0.113.1",
+			e:          false,
+		},
+		{
+			name:       "No trusted ranges",
+			remoteAddr: "10.1.2.3:1234",
+			e:          false,
+		},
+	}
+
+	for _, tt := range cases {
+		var logs bytes.Buffer
+		v := NewValidator(testKey, tt.opts...)
+		v.Logger = log.New(&logs, "", 0)
+
+		r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+		r.RemoteAddr = tt.remoteAddr
+		if tt.xff != "" {
+			r.Header.Set("X-Forwarded-For", tt.xff)
+		}
+
+		err := v.ValidRequest(r)
+		if (err == nil) != tt.e {
+			t.Errorf("got %v, expected bypass %v, test case: %s", err, tt.e, tt.name)
+		}
+		if tt.e && logs.Len() == 0 {
+			t.Errorf("expected bypass to be logged, test case: %s", tt.name)
+		}
+	}
+}
+
+func TestWithTrustedCIDRsInvalidRange(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+	r.RemoteAddr = "10.1.2.3:1234"
+	if err := NewSigner(testKey).Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+
+	v := NewValidator(testKey, WithTrustedCIDRs("10.0.0.0/33"))
+	if err := v.ValidRequest(r); !errors.Is(err, ErrInvalidTrustedCIDR) {
+		t.Errorf("got %v, expected %v", err, ErrInvalidTrustedCIDR)
+	}
+}
+
+func TestMustTrustedCIDRsPanicsOnInvalidRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for invalid CIDR")
+		}
+	}()
+	MustTrustedCIDRs("10.0.0.0/33")
+}
+
+func TestWithHeaders(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+	if err := NewSigner(testKey).Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+	r.Header.Set("X-Timestamp", r.Header.Get(tsHeader))
+	r.Header.Set("X-Signature", r.Header.Get(sHeader))
+	r.Header.Del(tsHeader)
+	r.Header.Del(sHeader)
+
+	if err := NewValidator(testKey).ValidRequest(r); err != ErrMissingSignature {
+		t.Errorf("got %v, expected %v", err, ErrMissingSignature)
+	}
+	if err := NewValidator(testKey, WithHeaders("X-Timestamp", "X-Signature")).ValidRequest(r); err != nil {
+		t.Errorf("unexpected error validating with custom headers: %s", err)
+	}
+}
+
+func TestWithSkipValidation(t *testing.T) {
+	var logs bytes.Buffer
+	v := NewValidator(testKey, WithSkipValidation())
+	v.Logger = log.New(&logs, "", 0)
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+	if err := v.ValidRequest(r); err != nil {
+		t.Errorf("unexpected error with validation disabled: %s", err)
+	}
+	if logs.Len() == 0 {
+		t.Errorf("expected skipped validation to be logged")
+	}
+}
@@ -0,0 +1,62 @@
+package signature
+
+import "fmt"
+
+// QueryMode determines how the query string is included in the signature.
+type QueryMode int
+
+const (
+	// QueryAuto accepts signatures over both the canonical and the raw query
+	// string. Signers use the canonical query string.
+	QueryAuto QueryMode = iota
+
+	// QueryCanonical signs the query string with its parameters sorted and
+	// re-encoded, which is what MessageBird does.
+	QueryCanonical
+
+	// QueryRaw signs the query string exactly as it appears in the URL.
+	QueryRaw
+)
+
+// String returns the name of the mode as used in Config.
+func (m QueryMode) String() string {
+	switch m {
+	case QueryCanonical:
+		return "canonical"
+	case QueryRaw:
+		return "raw"
+	}
+	return ""
+}
+
+// parseQueryMode is the inverse of QueryMode.String.
+func parseQueryMode(s string) (QueryMode, error) {
+	switch s {
+	case "":
+		return QueryAuto, nil
+	case "canonical":
+		return QueryCanonical, nil
+	case "raw":
+		return QueryRaw, nil
+	}
+	return QueryAuto, fmt.Errorf("unknown query mode %q", s)
+}
+
+// signedQueries returns the query strings a signature over the raw query rqp
+// may have been calculated with in mode m, in the order they should be tried.
+func signedQueries(m QueryMode, rqp string) ([]string, error) {
+	if m == QueryRaw {
+		return []string{rqp}, nil
+	}
+	qp, err := canonicalQuery(rqp)
+	if err != nil {
+		if m == QueryAuto {
+			return []string{rqp}, nil
+		}
+		return nil, err
+	}
+	if m == QueryAuto && qp != rqp {
+		return []string{qp, rqp}, nil
+	}
+	return []string{qp}, nil
+}
@@ -0,0 +1,50 @@
+package signature
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestQueryMode(t *testing.T) {
+	var cases = []struct {
+		name   string
+		signer QueryMode
+		mode   QueryMode
+		query  string
+		e      bool
+	}{
+		{name: "Auto accepts canonical", signer: QueryCanonical, mode: QueryAuto, query: "b=2&a=1", e: true},
+		{name: "Auto accepts raw", signer: QueryRaw, mode: QueryAuto, query: "b=2&a=1", e: true},
+		{name: "Auto accepts unparseable raw", signer: QueryRaw, mode: QueryAuto, query: "a=%zz", e: true},
+		{name: "Canonical rejects raw", signer: QueryRaw, mode: QueryCanonical, query: "b=2&a=1", e: false},
+		{name: "Raw rejects canonical", signer: QueryCanonical, mode: QueryRaw, query: "b=2&a=1", e: false},
+		{name: "Raw accepts raw", signer: QueryRaw, mode: QueryRaw, query: "b=2&a=%41", e: true},
+	}
+
+	for _, tt := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/webhook?"+tt.query, nil)
+		s := NewSigner(testKey)
+		s.QueryMode = tt.signer
+		if err := s.Sign(r); err != nil {
+			t.Fatalf("unexpected error signing request: %s, test case: %s", err, tt.name)
+		}
+
+		v := NewValidator(testKey)
+		v.QueryMode = tt.mode
+		if err := v.ValidRequest(r); (err == nil) != tt.e {
+			t.Errorf("got %v, expected valid %v, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+func TestParseQueryMode(t *testing.T) {
+	for _, m := range []QueryMode{QueryAuto, QueryCanonical, QueryRaw} {
+		if got, err := parseQueryMode(m.String()); err != nil || got != m {
+			t.Errorf("got %v, %v parsing %q, expected %v", got, err, m.String(), m)
+		}
+	}
+	if _, err := parseQueryMode("sorted"); err == nil {
+		t.Errorf("expected error for unknown query mode, got nil")
+	}
+}
@@ -0,0 +1,70 @@
+package signature
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ReplayStore records the signatures of validated requests so replayed
+// requests can be rejected. Implementations must be safe for concurrent use.
+// In deployments with multiple instances the store should be shared between
+// them, e.g. by implementing Seen with Redis' SET NX PX.
+type ReplayStore interface {
+	// Seen records key for at least ttl and reports whether it was already
+	// recorded and has not expired yet.
+	Seen(key string, ttl time.Duration) (bool, error)
+}
+
+// MemoryReplayStore is an in-memory ReplayStore for single instance
+// deployments.
+type MemoryReplayStore struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+// NewMemoryReplayStore returns an empty in-memory replay store.
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{
+		expires: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// Seen implements ReplayStore. Expired keys are removed while recording new
+// ones, so the store does not grow beyond the keys seen within ttl.
+func (s *MemoryReplayStore) Seen(key string, ttl time.Duration) (bool, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if exp, ok := s.expires[key]; ok && now.Before(exp) {
+		return true, nil
+	}
+	for k, exp := range s.expires {
+		if !now.Before(exp) {
+			delete(s.expires, k)
+		}
+	}
+	s.expires[key] = now.Add(ttl)
+	return false, nil
+}
+
+// checkReplay reports ErrReplayed if the request with timestamp ts and
+// signature rs was validated before. Requests are remembered for the validity
+// window, after which their timestamp is rejected anyway.
+func (v *Validator) checkReplay(ts, rs string) error {
+	if v.ReplayStore == nil {
+		return nil
+	}
+	seen, err := v.ReplayStore.Seen(ts+":"+rs, v.validityWindow())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrReplayCheck, err)
+	}
+	if seen {
+		return ErrReplayed
+	}
+	return nil
+}
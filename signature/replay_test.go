@@ -0,0 +1,68 @@
+package signature
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type failingReplayStore struct{}
+
+func (failingReplayStore) Seen(string, time.Duration) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestValidRequestReplay(t *testing.T) {
+	s := NewSigner(testKey)
+	v := NewValidator(testKey)
+	v.ReplayStore = NewMemoryReplayStore()
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+	if err := s.Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+	replay := func() *http.Request {
+		rr := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+		rr.Header = r.Header.Clone()
+		return rr
+	}
+
+	if err := v.ValidRequest(r); err != nil {
+		t.Fatalf("unexpected error validating request: %s", err)
+	}
+	if err := v.ValidRequest(replay()); !errors.Is(err, ErrReplayed) {
+		t.Errorf("got %v, expected %v", err, ErrReplayed)
+	}
+
+	v.ReplayStore = failingReplayStore{}
+	if err := v.ValidRequest(replay()); !errors.Is(err, ErrReplayCheck) {
+		t.Errorf("got %v, expected %v", err, ErrReplayCheck)
+	}
+}
+
+func TestMemoryReplayStoreExpiry(t *testing.T) {
+	now := time.Unix(1544544948, 0)
+	s := NewMemoryReplayStore()
+	s.now = func() time.Time { return now }
+
+	if seen, _ := s.Seen("a", time.Second); seen {
+		t.Fatalf("expected new key not to be seen")
+	}
+	if seen, _ := s.Seen("a", time.Second); !seen {
+		t.Errorf("expected key to be seen within ttl")
+	}
+
+	now = now.Add(time.Second)
+	if seen, _ := s.Seen("b", time.Second); seen {
+		t.Fatalf("expected new key not to be seen")
+	}
+	if _, ok := s.expires["a"]; ok {
+		t.Errorf("expected expired key to be removed")
+	}
+	if seen, _ := s.Seen("a", time.Second); seen {
+		t.Errorf("expected key not to be seen after ttl")
+	}
+}
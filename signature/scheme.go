@@ -0,0 +1,78 @@
+package signature
+
+import "net/http"
+
+// Policy determines which signature schemes a SchemeValidator accepts.
+type Policy int
+
+const (
+	// AllowLegacy accepts both MessageBird-Signature-JWT and legacy
+	// MessageBird-Signature requests.
+	AllowLegacy Policy = iota
+
+	// RequireJWT only accepts MessageBird-Signature-JWT requests.
+	RequireJWT
+)
+
+// SchemeValidator validates requests signed with either scheme, depending on
+// the signature header present. It allows migrating from the legacy
+// MessageBird-Signature header to MessageBird-Signature-JWT gradually:
+// start with AllowLegacy and switch to RequireJWT once all webhooks are
+// signed with JWTs.
+//
+//	validator := signature.NewSchemeValidator("your signing key")
+//	http.Handle("/path", validator.Validate(YourHandler))
+type SchemeValidator struct {
+	HMAC *Validator
+	JWT  *JWTValidator
+
+	Policy Policy
+
+	// OnReject is called by Validate with each rejected request, as it is
+	// for the Validator.
+	OnReject func(r *http.Request, err error)
+}
+
+// NewSchemeValidator returns a validator accepting both schemes with the
+// provided signing key.
+func NewSchemeValidator(signingKey string) *SchemeValidator {
+	return &SchemeValidator{
+		HMAC: NewValidator(signingKey),
+		JWT:  NewJWTValidator(signingKey),
+	}
+}
+
+// ValidRequest validates r using the scheme of the signature header present.
+// Requests carrying a JWT are always validated as such.
+func (v *SchemeValidator) ValidRequest(r *http.Request) error {
+	_, err := v.validRequest(r)
+	return err
+}
+
+// validRequest is like ValidRequest, but returns the metadata of valid
+// requests.
+func (v *SchemeValidator) validRequest(r *http.Request) (*Metadata, error) {
+	if r.Header.Get(jwtHeader) != "" {
+		return v.JWT.validRequest(r)
+	}
+	if v.Policy == RequireJWT {
+		if tsh, sh := v.HMAC.headers(); r.Header.Get(tsh) != "" || r.Header.Get(sh) != "" {
+			return nil, ErrLegacySignature
+		}
+		return nil, ErrMissingSignature
+	}
+	return v.HMAC.validRequest(r)
+}
+
+// Validate is a handler wrapper that takes care of the signature validation of
+// incoming requests and rejects them if invalid or pass them on to your handler
+// otherwise. The Metadata of valid requests is available to your handler
+// through FromContext.
+func (v *SchemeValidator) Validate(h http.Handler) http.Handler {
+	return validate(h, v.validRequest, v.OnReject)
+}
+
+// Check validates r like Validate; see Validator.Check.
+func (v *SchemeValidator) Check(r *http.Request) (*http.Request, error) {
+	return check(r, v.validRequest, v.OnReject)
+}
@@ -0,0 +1,76 @@
+package signature
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSchemeValidator(t *testing.T) {
+	now := time.Now()
+	token := testJWT(t, testKey, "HS256", testClaims(now, testURL, testBody))
+
+	var cases = []struct {
+		name   string
+		policy Policy
+		sign   func(r *http.Request)
+		scheme Scheme
+		e      error
+	}{
+		{
+			name:   "JWT",
+			policy: AllowLegacy,
+			sign:   func(r *http.Request) { r.Header.Set(jwtHeader, token) },
+			scheme: SchemeJWT,
+		},
+		{
+			name:   "Legacy",
+			policy: AllowLegacy,
+			sign:   func(r *http.Request) { NewSigner(testKey).Sign(r) },
+			scheme: SchemeHMAC,
+		},
+		{
+			name:   "JWT required",
+			policy: RequireJWT,
+			sign:   func(r *http.Request) { r.Header.Set(jwtHeader, token) },
+			scheme: SchemeJWT,
+		},
+		{
+			name:   "Legacy with JWT required",
+			policy: RequireJWT,
+			sign:   func(r *http.Request) { NewSigner(testKey).Sign(r) },
+			e:      ErrLegacySignature,
+		},
+		{
+			name:   "Unsigned",
+			policy: AllowLegacy,
+			sign:   func(r *http.Request) {},
+			e:      ErrMissingSignature,
+		},
+		{
+			name:   "Unsigned with JWT required",
+			policy: RequireJWT,
+			sign:   func(r *http.Request) {},
+			e:      ErrMissingSignature,
+		},
+	}
+
+	for _, tt := range cases {
+		v := NewSchemeValidator(testKey)
+		v.Policy = tt.policy
+
+		r := httptest.NewRequest(http.MethodPost, testURL, strings.NewReader(testBody))
+		tt.sign(r)
+		md, err := v.validRequest(r)
+		if !errors.Is(err, tt.e) {
+			t.Errorf("got %v, expected %v, test case: %s", err, tt.e, tt.name)
+			continue
+		}
+		if err == nil && md.Scheme != tt.scheme {
+			t.Errorf("got scheme %s, expected %s, test case: %s", md.Scheme, tt.scheme, tt.name)
+		}
+	}
+}
@@ -4,10 +4,10 @@ Package signature implements signature verification for MessageBird webhooks.
 To use define a new validator using your MessageBird Signing key.  You can use the
 ValidRequest method, just pass the request as a parameter:
 
-    validator := signature.NewValidator("your signing key")
-    if err := validator.ValidRequest(r); err != nil {
-        // handle error
-    }
+	validator := signature.NewValidator("your signing key")
+	if err := validator.ValidRequest(r); err != nil {
+	    // handle error
+	}
 
 Or use the handler as a middleware for your server:
 
@@ -17,7 +17,38 @@ It will reject the requests that contain invalid signatures.
 The validator uses a 5ms seconds window to accept requests as valid, to change
 this value, set the ValidityWindow to the disired duration.
 Take into account that the validity window works around the current time:
+
 	[now - ValidityWindow/2, now + ValidityWindow/2]
+
+Webhooks signed with the MessageBird-Signature-JWT header are validated by a
+JWTValidator, which is used the same way:
+
+	validator := signature.NewJWTValidator("your signing key")
+	http.Handle("/path", validator.Validate(YourHandler))
+
+A SchemeValidator accepts both, picking the scheme by the header present,
+which allows migrating to JWT signatures gradually.
+
+All validators implement RequestValidator. Their Validate method has the
+middleware signature used by chi, and can be adapted to other frameworks
+with their own helpers:
+
+	r.Use(validator.Validate)                           // chi
+	e.Use(echo.WrapMiddleware(validator.Validate))      // echo
+	app.Use(adaptor.HTTPMiddleware(validator.Validate)) // fiber
+
+Frameworks without an adapter for net/http middleware, such as gin, can use
+Check, which validates like Validate and returns the request with the
+Metadata attached:
+
+	router.Use(func(c *gin.Context) {
+		r, err := validator.Check(c.Request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Request = r
+	})
 */
 package signature
 
@@ -26,8 +57,9 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
-	"fmt"
-	"io/ioutil"
+	"io"
+	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -54,37 +86,138 @@ func stringToTime(s string) (time.Time, error) {
 // Validator type represents a MessageBird signature validator.
 type Validator struct {
 	SigningKey string // Signing Key provided by MessageBird.
+
+	// SigningKeys are accepted in addition to SigningKey. During a key
+	// rotation, both the old and the new key can be accepted by setting
+	// SigningKey to the new key and SigningKeys to the old one.
+	SigningKeys []string
+
+	// KeyProvider is called on each validation when set, and the keys it
+	// returns are accepted as well. This allows rotating keys without
+	// reconfiguring the validator, e.g. by reading them from a secret store.
+	KeyProvider func() []string
+
+	// ValidityWindow overrides the package level ValidityWindow for this
+	// validator when set to a non-zero duration.
+	ValidityWindow time.Duration
+
+	// OnClockDrift is called on each validation with the difference between
+	// the local clock and the request timestamp. A positive drift means the
+	// timestamp lies in the past. It can be used to raise an alarm before the
+	// drift approaches the validity window and requests start being rejected.
+	OnClockDrift func(drift time.Duration)
+
+	// Now returns the current time used to check request timestamps. It
+	// defaults to time.Now and can be replaced to validate recorded requests
+	// in tests, or to compensate for a skewed clock.
+	Now func() time.Time
+
+	// MaxBodyBytes limits the size of request bodies that are accepted, as
+	// bodies are buffered in memory while validating. Requests with larger
+	// bodies are rejected with ErrBodyTooLarge. There is no limit when zero.
+	MaxBodyBytes int64
+
+	// QueryMode determines how the query string is signed. By default both
+	// the canonical and the raw query string are accepted, so requests from
+	// senders hashing the raw query string are not rejected.
+	QueryMode QueryMode
+
+	// OnReject is called by Validate with each rejected request and the
+	// reason it was rejected, e.g. to record metrics or log the failure.
+	OnReject func(r *http.Request, err error)
+
+	// Logger is used to report security sensitive events, such as requests
+	// bypassing validation. The standard logger is used when nil.
+	Logger *log.Logger
+
+	// ReplayStore enables replay protection when set. Requests whose
+	// timestamp and signature were seen before within the validity window are
+	// rejected with ErrReplayed.
+	ReplayStore ReplayStore
+
+	trustedNets       []*net.IPNet
+	trustForwardedFor bool
+	timestampHeader   string
+	signatureHeader   string
+	skipValidation    bool
+	optionErr         error
 }
 
 // NewValidator returns a signature validator object.
-func NewValidator(signingKey string) *Validator {
-	return &Validator{
+func NewValidator(signingKey string, opts ...Option) *Validator {
+	v := &Validator{
 		SigningKey: signingKey,
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // validTimestamp validates if the MessageBird-Request-Timestamp is a valid
 // date and if the request is older than the validator Period.
 func (v *Validator) validTimestamp(ts string) bool {
+	return v.checkTimestamp(ts) == nil
+}
+
+// checkTimestamp is like validTimestamp, but returns an error describing why
+// the timestamp is invalid.
+func (v *Validator) checkTimestamp(ts string) error {
 	t, err := stringToTime(ts)
 	if err != nil {
-		return false
+		return ErrMalformedTimestamp
 	}
-	diff := time.Now().Add(ValidityWindow / 2).Sub(t)
-	return diff < ValidityWindow && diff > 0
+	now := v.now()
+	if v.OnClockDrift != nil {
+		v.OnClockDrift(now.Sub(t))
+	}
+	w := v.validityWindow()
+	diff := now.Add(w / 2).Sub(t)
+	if diff >= w || diff <= 0 {
+		return ErrTimestampExpired
+	}
+	return nil
+}
+
+// now returns the current time according to the validator's clock.
+func (v *Validator) now() time.Time {
+	if v.Now != nil {
+		return v.Now()
+	}
+	return time.Now()
+}
+
+// validityWindow returns the window configured on the validator, falling back
+// to the package level ValidityWindow.
+func (v *Validator) validityWindow() time.Duration {
+	if v.ValidityWindow != 0 {
+		return v.ValidityWindow
+	}
+	return ValidityWindow
 }
 
 // calculateSignature calculates the MessageBird-Signature using HMAC_SHA_256
 // encoding and the timestamp, query params and body from the request:
 // signature = HMAC_SHA_256(
+//
 //	TIMESTAMP + \n + QUERY_PARAMS + \n + SHA_256_SUM(BODY),
 //	signing_key)
 func (v *Validator) calculateSignature(ts, qp string, b []byte) ([]byte, error) {
-	var m bytes.Buffer
 	bh := sha256.Sum256(b)
-	fmt.Fprintf(&m, "%s\n%s\n%s", ts, qp, bh[:])
-	mac := hmac.New(sha256.New, []byte(v.SigningKey))
-	if _, err := mac.Write(m.Bytes()); err != nil {
+	return hmacSignature(v.SigningKey, ts, qp, bh[:])
+}
+
+// hmacSignature calculates the signature as described for calculateSignature
+// using the provided signing key and SHA-256 sum of the body bh.
+func hmacSignature(key, ts, qp string, bh []byte) ([]byte, error) {
+	m := make([]byte, 0, len(ts)+len(qp)+2+sha256.Size)
+	m = append(m, ts...)
+	m = append(m, '\n')
+	m = append(m, qp...)
+	m = append(m, '\n')
+	m = append(m, bh...)
+	mac := hmac.New(sha256.New, []byte(key))
+	if _, err := mac.Write(m); err != nil {
 		return nil, err
 	}
 	return mac.Sum(nil), nil
@@ -93,46 +226,225 @@ func (v *Validator) calculateSignature(ts, qp string, b []byte) ([]byte, error)
 // validSignature takes the timestamp, query params and body from the request,
 // calculates the expected signature and compares it to the one sent by MessageBird.
 func (v *Validator) validSignature(ts, rqp string, b []byte, rs string) bool {
-	uqp, err := url.Parse("?" + rqp)
+	bh := sha256.Sum256(b)
+	_, err := v.checkSignature(ts, rqp, bh[:], rs)
+	return err == nil
+}
+
+// checkSignature is like validSignature, but takes the SHA-256 sum of the body
+// and returns the index of the matching key, or an error describing why the
+// signature is invalid.
+func (v *Validator) checkSignature(ts, rqp string, bh []byte, rs string) (int, error) {
+	qps, err := signedQueries(v.QueryMode, rqp)
 	if err != nil {
-		return false
+		return -1, ErrMalformedSignature
 	}
-	es, err := v.calculateSignature(ts, uqp.Query().Encode(), b)
+	drs, err := base64.StdEncoding.DecodeString(rs)
 	if err != nil {
-		return false
+		return -1, ErrMalformedSignature
 	}
-	drs, err := base64.StdEncoding.DecodeString(rs)
+	for _, qp := range qps {
+		if i := v.matchingKey(ts, qp, bh, drs); i >= 0 {
+			return i, nil
+		}
+	}
+	return -1, ErrSignatureMismatch
+}
+
+// keys returns all signing keys accepted by the validator.
+func (v *Validator) keys() []string {
+	return signingKeys(v.SigningKey, v.SigningKeys, v.KeyProvider)
+}
+
+// signingKeys combines a primary key, additional keys and the keys returned by
+// an optional provider.
+func signingKeys(key string, keys []string, provider func() []string) []string {
+	all := make([]string, 0, 1+len(keys))
+	all = append(all, key)
+	all = append(all, keys...)
+	if provider != nil {
+		all = append(all, provider()...)
+	}
+	return all
+}
+
+// matchingKey returns the index in keys() of the key that produces signature
+// drs for the canonical query qp and body sum bh, or -1 if no key does.
+func (v *Validator) matchingKey(ts, qp string, bh, drs []byte) int {
+	for i, key := range v.keys() {
+		if key == "" {
+			continue
+		}
+		es, err := hmacSignature(key, ts, qp, bh)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(drs, es) {
+			return i
+		}
+	}
+	return -1
+}
+
+// canonicalQuery sorts and re-encodes the raw query string the way MessageBird
+// does before signing. Empty queries, the common case for webhooks, are
+// returned without parsing.
+func canonicalQuery(rqp string) (string, error) {
+	if rqp == "" {
+		return "", nil
+	}
+	uqp, err := url.Parse("?" + rqp)
 	if err != nil {
-		return false
+		return "", err
 	}
-	return hmac.Equal(drs, es)
+	return uqp.Query().Encode(), nil
 }
 
 // ValidRequest is a method that takes care of the signature validation of
-// incoming requests.
+// incoming requests. The returned error can be compared to the exported Err
+// values using errors.Is to determine why validation failed. The body is read
+// and restored, also when validation fails, so it can be used by subsequent
+// handlers.
 func (v *Validator) ValidRequest(r *http.Request) error {
-	ts := r.Header.Get(tsHeader)
-	rs := r.Header.Get(sHeader)
+	_, err := v.validRequest(r)
+	return err
+}
+
+// validRequest is like ValidRequest, but returns the metadata of valid
+// requests. The metadata is nil for requests that skipped validation.
+func (v *Validator) validRequest(r *http.Request) (*Metadata, error) {
+	if v.optionErr != nil {
+		return nil, v.optionErr
+	}
+	if v.skipped(r) {
+		return nil, nil
+	}
+	tsh, sh := v.headers()
+	ts := r.Header.Get(tsh)
+	rs := r.Header.Get(sh)
 	if ts == "" || rs == "" {
-		return fmt.Errorf("Unknown host: %s", r.Host)
+		return nil, ErrMissingSignature
+	}
+	_, bh, err := readBody(r, v.MaxBodyBytes)
+	if err != nil {
+		return nil, err
 	}
-	b, _ := ioutil.ReadAll(r.Body)
-	if v.validTimestamp(ts) == false || v.validSignature(ts, r.URL.RawQuery, b, rs) == false {
-		return fmt.Errorf("Unknown host: %s", r.Host)
+	if err := v.checkTimestamp(ts); err != nil {
+		return nil, err
 	}
-	r.Body = ioutil.NopCloser(bytes.NewBuffer(b))
-	return nil
+	i, err := v.checkSignature(ts, r.URL.RawQuery, bh, rs)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.checkReplay(ts, rs); err != nil {
+		return nil, err
+	}
+
+	t, _ := stringToTime(ts)
+	return &Metadata{Scheme: SchemeHMAC, Timestamp: t, KeyIndex: i}, nil
+}
+
+// readBody reads the request body, which may be at most max bytes if max is
+// positive, and returns it along with its SHA-256 sum. The sum is calculated
+// while reading, so the body is not traversed twice. The request body is
+// restored whether reading succeeds or not, so r remains usable by subsequent
+// handlers.
+func readBody(r *http.Request, max int64) ([]byte, []byte, error) {
+	if r.Body == nil {
+		bh := sha256.Sum256(nil)
+		return nil, bh[:], nil
+	}
+
+	var body io.Reader = r.Body
+	if max > 0 {
+		// Read one more byte than allowed to detect bodies exceeding max.
+		body = io.LimitReader(r.Body, max+1)
+	}
+
+	var buf bytes.Buffer
+	h := sha256.New()
+	n, err := io.Copy(&buf, io.TeeReader(body, h))
+	r.Body = &restoredBody{
+		Reader: io.MultiReader(bytes.NewReader(buf.Bytes()), r.Body),
+		Closer: r.Body,
+	}
+	if err != nil {
+		return nil, nil, &BodyReadError{Err: err}
+	}
+	if max > 0 && n > max {
+		return nil, nil, ErrBodyTooLarge
+	}
+	return buf.Bytes(), h.Sum(nil), nil
+}
+
+// restoredBody replays the bytes read from a request body before continuing
+// with the remainder of the original body.
+type restoredBody struct {
+	io.Reader
+	io.Closer
 }
 
 // Validate is a handler wrapper that takes care of the signature validation of
 // incoming requests and rejects them if invalid or pass them on to your handler
-// otherwise.
+// otherwise. The Metadata of valid requests is available to your handler
+// through FromContext.
 func (v *Validator) Validate(h http.Handler) http.Handler {
+	return validate(h, v.validRequest, v.OnReject)
+}
+
+// Check validates r like Validate, for frameworks whose middleware is not
+// net/http middleware. It returns r with the Metadata of a valid request
+// attached to its context, or the error of an invalid one after passing it
+// to OnReject.
+func (v *Validator) Check(r *http.Request) (*http.Request, error) {
+	return check(r, v.validRequest, v.OnReject)
+}
+
+// RequestValidator is implemented by Validator, JWTValidator and
+// SchemeValidator, so code can accept any of them.
+type RequestValidator interface {
+	// ValidRequest checks the signature of r.
+	ValidRequest(r *http.Request) error
+
+	// Check checks the signature of r like Validate, and returns r with the
+	// Metadata of a valid request attached.
+	Check(r *http.Request) (*http.Request, error)
+
+	// Validate wraps h, rejecting requests with an invalid signature.
+	Validate(h http.Handler) http.Handler
+}
+
+var (
+	_ RequestValidator = (*Validator)(nil)
+	_ RequestValidator = (*JWTValidator)(nil)
+	_ RequestValidator = (*SchemeValidator)(nil)
+)
+
+// validate wraps h, rejecting requests for which validRequest fails and
+// passing on the others with their metadata attached to the context.
+func validate(h http.Handler, validRequest func(*http.Request) (*Metadata, error), onReject func(*http.Request, error)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if err := v.ValidRequest(r); err != nil {
+		r, err := check(r, validRequest, onReject)
+		if err != nil {
 			http.Error(w, "", http.StatusUnauthorized)
 			return
 		}
 		h.ServeHTTP(w, r)
 	})
 }
+
+// check validates r with validRequest, passing rejected requests to onReject
+// and attaching the metadata of valid ones to the context.
+func check(r *http.Request, validRequest func(*http.Request) (*Metadata, error), onReject func(*http.Request, error)) (*http.Request, error) {
+	md, err := validRequest(r)
+	if err != nil {
+		if onReject != nil {
+			onReject(r, err)
+		}
+		return nil, err
+	}
+	if md != nil {
+		r = r.WithContext(NewContext(r.Context(), md))
+	}
+	return r, nil
+}
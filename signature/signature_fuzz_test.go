@@ -0,0 +1,133 @@
+//go:build go1.18
+// +build go1.18
+
+package signature
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// slowSignature is the reference implementation of the signature calculation,
+// which always parses the query and formats the message through a buffer.
+func slowSignature(key, ts, rqp string, b []byte) ([]byte, bool) {
+	uqp, err := url.Parse("?" + rqp)
+	if err != nil {
+		return nil, false
+	}
+	var m bytes.Buffer
+	bh := sha256.Sum256(b)
+	fmt.Fprintf(&m, "%s\n%s\n%s", ts, uqp.Query().Encode(), bh[:])
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(m.Bytes())
+	return mac.Sum(nil), true
+}
+
+func FuzzSignatureFastPath(f *testing.F) {
+	f.Add(testTs, testQp, []byte(testBody))
+	f.Add(testTs, "", []byte(testBody))
+	f.Add(testTs, "", []byte(""))
+	f.Add("", "a=%zz", []byte{0xff})
+
+	v := NewValidator(testKey)
+	f.Fuzz(func(t *testing.T, ts, rqp string, b []byte) {
+		expected, ok := slowSignature(testKey, ts, rqp, b)
+
+		qp, err := canonicalQuery(rqp)
+		if (err == nil) != ok {
+			t.Fatalf("fast path parse error %v, slow path ok %v", err, ok)
+		}
+		if !ok {
+			return
+		}
+
+		got, err := v.calculateSignature(ts, qp, b)
+		if err != nil {
+			t.Fatalf("unexpected error calculating signature: %s", err)
+		}
+		if !hmac.Equal(got, expected) {
+			t.Fatalf("fast path signature %x differs from slow path %x", got, expected)
+		}
+	})
+}
+
+func FuzzValidRequest(f *testing.F) {
+	f.Add(testTs, testSignature, testQp, []byte(testBody))
+	f.Add(testTs, "", "", []byte(""))
+	f.Add("", testSignature, "a=%zz&&;b", []byte{0})
+	f.Add("-1", "not base64!", testQp, []byte(testBody))
+	f.Add("99999999999999999999", "====", "", []byte(testBody))
+
+	// The reference implementation only signs the canonical query string.
+	v := NewValidator(testKey)
+	v.QueryMode = QueryCanonical
+	testTime, _ := stringToTime(testTs)
+	v.ValidityWindow = time.Since(testTime)*2 + time.Hour
+
+	f.Fuzz(func(t *testing.T, ts, s, rqp string, b []byte) {
+		r, err := http.NewRequest(http.MethodPost, "https://example.com/webhook", bytes.NewReader(b))
+		if err != nil {
+			t.Skip()
+		}
+		r.URL.RawQuery = rqp
+		r.Header.Set(tsHeader, ts)
+		r.Header.Set(sHeader, s)
+
+		err = v.ValidRequest(r)
+
+		// Downstream handlers must be able to read the body, whether the
+		// request was accepted or not.
+		rb, rerr := ioutil.ReadAll(r.Body)
+		if rerr != nil || !bytes.Equal(rb, b) {
+			t.Fatalf("body not restored after validation: %q, %v", rb, rerr)
+		}
+		if err != nil {
+			return
+		}
+
+		// The request was accepted, so it must carry the expected signature.
+		expected, ok := slowSignature(testKey, ts, rqp, b)
+		if !ok {
+			t.Fatalf("accepted request with unparseable query %q", rqp)
+		}
+		if base64.StdEncoding.EncodeToString(expected) != s {
+			t.Fatalf("accepted non-matching signature %q", s)
+		}
+	})
+}
+
+func FuzzSignValidRequest(f *testing.F) {
+	f.Add(testQp, []byte(testBody))
+	f.Add("", []byte(""))
+	f.Add("b=%20&a=1&a=0", []byte{0xff, 0})
+
+	s := NewSigner(testKey)
+	v := NewValidator(testKey)
+	f.Fuzz(func(t *testing.T, rqp string, b []byte) {
+		r, err := http.NewRequest(http.MethodPost, "https://example.com/webhook", bytes.NewReader(b))
+		if err != nil {
+			t.Skip()
+		}
+		r.URL.RawQuery = rqp
+
+		if err := s.Sign(r); err != nil {
+			// Only queries that can not be canonicalized can not be signed.
+			if _, qerr := canonicalQuery(rqp); qerr == nil {
+				t.Fatalf("unexpected error signing request: %s", err)
+			}
+			return
+		}
+
+		if err := v.ValidRequest(r); err != nil {
+			t.Fatalf("signed request is not valid: %s", err)
+		}
+	})
+}
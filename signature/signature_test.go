@@ -3,7 +3,9 @@ package signature
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -260,3 +262,321 @@ func TestValidate(t *testing.T) {
 	}
 
 }
+
+func TestOnClockDrift(t *testing.T) {
+	var got []time.Duration
+	v := NewValidator(testKey)
+	v.OnClockDrift = func(drift time.Duration) {
+		got = append(got, drift)
+	}
+
+	v.validTimestamp(fmt.Sprintf("%d", time.Now().Add(-time.Hour).Unix()))
+	v.validTimestamp("wrongTs")
+
+	if len(got) != 1 {
+		t.Fatalf("got %d drift callbacks, expected 1", len(got))
+	}
+	if got[0] < time.Hour || got[0] > time.Hour+2*time.Second {
+		t.Errorf("got drift %s, expected about 1h", got[0])
+	}
+}
+
+func BenchmarkValidSignature(b *testing.B) {
+	v := NewValidator(testKey)
+	body := []byte(testBody)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.validSignature(testTs, "", body, testSignature)
+	}
+}
+
+func TestValidSignatureKeyRotation(t *testing.T) {
+	var cases = []struct {
+		name     string
+		key      string
+		keys     []string
+		provider func() []string
+		e        bool
+	}{
+		{
+			name: "Old key in signing keys",
+			key:  "new-secret",
+			keys: []string{testKey},
+			e:    true,
+		},
+		{
+			name:     "Key from provider",
+			key:      "new-secret",
+			provider: func() []string { return []string{"", testKey} },
+			e:        true,
+		},
+		{
+			name: "No matching key",
+			key:  "new-secret",
+			keys: []string{"older-secret"},
+			e:    false,
+		},
+	}
+
+	for _, tt := range cases {
+		v := NewValidator(tt.key)
+		v.SigningKeys = tt.keys
+		v.KeyProvider = tt.provider
+		r := v.validSignature(testTs, testQp, []byte(testBody), testSignature)
+		if r != tt.e {
+			t.Errorf("Unexpected result validating signature: %v, test case: %s", r, tt.name)
+		}
+	}
+}
+
+func TestValidRequestErrors(t *testing.T) {
+	testTime, _ := stringToTime(testTs)
+	window := time.Since(testTime)*2 + time.Hour
+
+	var cases = []struct {
+		name   string
+		ts     string
+		s      string
+		window time.Duration
+		e      error
+	}{
+		{
+			name:   "Succesful",
+			ts:     testTs,
+			s:      testSignature,
+			window: window,
+			e:      nil,
+		},
+		{
+			name:   "Missing signature",
+			ts:     testTs,
+			window: window,
+			e:      ErrMissingSignature,
+		},
+		{
+			name:   "Malformed timestamp",
+			ts:     "wrongTs",
+			s:      testSignature,
+			window: window,
+			e:      ErrMalformedTimestamp,
+		},
+		{
+			name:   "Expired timestamp",
+			ts:     testTs,
+			s:      testSignature,
+			window: time.Second,
+			e:      ErrTimestampExpired,
+		},
+		{
+			name:   "Malformed signature",
+			ts:     testTs,
+			s:      "wrong signature",
+			window: window,
+			e:      ErrMalformedSignature,
+		},
+		{
+			name:   "Signature mismatch",
+			ts:     testTs,
+			s:      "LISw4Je7n0/MkYDgVSzTJm8dW6BkytKTXMZZk1IElMs=",
+			window: window,
+			e:      ErrSignatureMismatch,
+		},
+	}
+
+	for _, tt := range cases {
+		v := NewValidator(testKey)
+		v.ValidityWindow = tt.window
+		r := httptest.NewRequest(http.MethodPost, "/webhook?"+testQp, strings.NewReader(testBody))
+		r.Header.Set(tsHeader, tt.ts)
+		r.Header.Set(sHeader, tt.s)
+		if err := v.ValidRequest(r); !errors.Is(err, tt.e) {
+			t.Errorf("got %v, expected %v, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestValidRequestBodyReadError(t *testing.T) {
+	v := NewValidator(testKey)
+	r := httptest.NewRequest(http.MethodPost, "/webhook", errReader{})
+	r.Header.Set(tsHeader, testTs)
+	r.Header.Set(sHeader, testSignature)
+	err := v.ValidRequest(r)
+	if !errors.Is(err, ErrBodyRead) {
+		t.Errorf("got %v, expected %v", err, ErrBodyRead)
+	}
+	var bre *BodyReadError
+	if !errors.As(err, &bre) || bre.Err.Error() != "connection reset" {
+		t.Errorf("got %v, expected a BodyReadError wrapping the read error", err)
+	}
+}
+
+func TestValidRequestRestoresBody(t *testing.T) {
+	var cases = []struct {
+		name string
+		s    string
+		max  int64
+	}{
+		{
+			name: "Signature mismatch",
+			s:    testSignature,
+		},
+		{
+			name: "Body too large",
+			s:    testSignature,
+			max:  2,
+		},
+		{
+			name: "Malformed signature",
+			s:    "not base64!",
+		},
+	}
+
+	for _, tt := range cases {
+		v := NewValidator(testKey)
+		v.MaxBodyBytes = tt.max
+		r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+		r.Header.Set(tsHeader, testTs)
+		r.Header.Set(sHeader, tt.s)
+		if err := v.ValidRequest(r); err == nil {
+			t.Fatalf("expected error, got nil, test case: %s", tt.name)
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil || string(b) != testBody {
+			t.Errorf("got body %q, %v, expected %q, test case: %s", b, err, testBody, tt.name)
+		}
+	}
+}
+
+func TestValidRequestMaxBodyBytes(t *testing.T) {
+	testTime, _ := stringToTime(testTs)
+
+	var cases = []struct {
+		name string
+		max  int64
+		e    error
+	}{
+		{
+			name: "No limit",
+			max:  0,
+			e:    nil,
+		},
+		{
+			name: "Body at limit",
+			max:  int64(len(testBody)),
+			e:    nil,
+		},
+		{
+			name: "Body over limit",
+			max:  int64(len(testBody)) - 1,
+			e:    ErrBodyTooLarge,
+		},
+	}
+
+	for _, tt := range cases {
+		v := NewValidator(testKey)
+		v.ValidityWindow = time.Since(testTime)*2 + time.Hour
+		v.MaxBodyBytes = tt.max
+		r := httptest.NewRequest(http.MethodPost, "/webhook?"+testQp, strings.NewReader(testBody))
+		r.Header.Set(tsHeader, testTs)
+		r.Header.Set(sHeader, testSignature)
+		if err := v.ValidRequest(r); !errors.Is(err, tt.e) {
+			t.Errorf("got %v, expected %v, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+func TestValidRequestWithClock(t *testing.T) {
+	testTime, _ := stringToTime(testTs)
+
+	var cases = []struct {
+		name string
+		now  time.Time
+		e    error
+	}{
+		{
+			name: "Recorded request at its own time",
+			now:  testTime,
+			e:    nil,
+		},
+		{
+			name: "Recorded request a minute later",
+			now:  testTime.Add(time.Minute),
+			e:    ErrTimestampExpired,
+		},
+	}
+
+	for _, tt := range cases {
+		v := NewValidator(testKey)
+		v.ValidityWindow = 5 * time.Second
+		now := tt.now
+		v.Now = func() time.Time { return now }
+		r := httptest.NewRequest(http.MethodPost, "/webhook?"+testQp, strings.NewReader(testBody))
+		r.Header.Set(tsHeader, testTs)
+		r.Header.Set(sHeader, testSignature)
+		if err := v.ValidRequest(r); !errors.Is(err, tt.e) {
+			t.Errorf("got %v, expected %v, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var rejected error
+	v := NewValidator(testKey)
+	v.OnReject = func(r *http.Request, err error) {
+		rejected = err
+	}
+
+	if _, err := v.Check(httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))); err != ErrMissingSignature || rejected != ErrMissingSignature {
+		t.Errorf("got %v and rejected %v, expected %v", err, rejected, ErrMissingSignature)
+	}
+
+	rejected = nil
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+	if err := NewSigner(testKey).Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+	r, err := v.Check(r)
+	if err != nil || rejected != nil {
+		t.Fatalf("got %v and rejected %v, expected a valid request", err, rejected)
+	}
+	if md, ok := FromContext(r.Context()); !ok || md.Scheme != SchemeHMAC {
+		t.Errorf("got metadata %+v, expected the HMAC scheme", md)
+	}
+}
+
+func TestValidateOnReject(t *testing.T) {
+	var rejected error
+	v := NewValidator(testKey)
+	v.OnReject = func(r *http.Request, err error) {
+		rejected = err
+	}
+	h := v.Validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody)))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusUnauthorized)
+	}
+	if rejected != ErrMissingSignature {
+		t.Errorf("got %v, expected OnReject to be called with %v", rejected, ErrMissingSignature)
+	}
+
+	rejected = nil
+	r := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testBody))
+	if err := NewSigner(testKey).Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if rejected != nil {
+		t.Errorf("got %v, expected OnReject not to be called for valid requests", rejected)
+	}
+}
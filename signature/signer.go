@@ -0,0 +1,67 @@
+package signature
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Signer signs requests the way MessageBird signs webhooks, so they are
+// accepted by a Validator using the same signing key. It can be used to build
+// integration tests and webhook simulators:
+//
+//	signer := signature.NewSigner("your signing key")
+//	if err := signer.Sign(r); err != nil {
+//	    // handle error
+//	}
+type Signer struct {
+	SigningKey string // Signing Key provided by MessageBird.
+
+	// QueryMode determines how the query string is signed. The canonical
+	// query string is signed unless it is set to QueryRaw.
+	QueryMode QueryMode
+
+	// Now returns the time used as request timestamp. It defaults to
+	// time.Now.
+	Now func() time.Time
+}
+
+// NewSigner returns a signer object.
+func NewSigner(signingKey string) *Signer {
+	return &Signer{
+		SigningKey: signingKey,
+	}
+}
+
+// Sign sets the MessageBird-Request-Timestamp and MessageBird-Signature headers
+// of r. The body is read and restored, so the request can be sent afterwards.
+func (s *Signer) Sign(r *http.Request) error {
+	_, bh, err := readBody(r, 0)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	ts := strconv.FormatInt(now().Unix(), 10)
+
+	qp := r.URL.RawQuery
+	if s.QueryMode != QueryRaw {
+		var err error
+		if qp, err = canonicalQuery(qp); err != nil {
+			return fmt.Errorf("could not canonicalize query: %v", err)
+		}
+	}
+	sig, err := hmacSignature(s.SigningKey, ts, qp, bh)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set(tsHeader, ts)
+	r.Header.Set(sHeader, base64.StdEncoding.EncodeToString(sig))
+	return nil
+}
@@ -0,0 +1,44 @@
+package signature
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	testTime, _ := stringToTime(testTs)
+	s := NewSigner(testKey)
+	s.Now = func() time.Time { return testTime }
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook?def=bar&abc=foo", strings.NewReader(testBody))
+	if err := s.Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+
+	if ts := r.Header.Get(tsHeader); ts != testTs {
+		t.Errorf("got timestamp %s, expected %s", ts, testTs)
+	}
+	if sig := r.Header.Get(sHeader); sig != testSignature {
+		t.Errorf("got signature %s, expected %s", sig, testSignature)
+	}
+
+	b, _ := ioutil.ReadAll(r.Body)
+	if string(b) != testBody {
+		t.Errorf("got body %s, expected %s", b, testBody)
+	}
+}
+
+func TestSignValidates(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/webhook", nil)
+	if err := NewSigner(testKey).Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+
+	if err := NewValidator(testKey).ValidRequest(r); err != nil {
+		t.Errorf("unexpected error validating signed request: %s", err)
+	}
+}
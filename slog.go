@@ -0,0 +1,41 @@
+//go:build go1.21
+// +build go1.21
+
+package messagebird
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithSlog logs each request to l: successful ones at debug level, and
+// failed ones and those with an error status at warning level.
+func WithSlog(l *slog.Logger) Option {
+	return func(c *Client) {
+		c.LogRequest = func(ctx context.Context, rl RequestLog) {
+			level := slog.LevelDebug
+			if rl.Err != nil || rl.Status >= 400 {
+				level = slog.LevelWarn
+			}
+			attrs := []slog.Attr{
+				slog.String("method", rl.Method),
+				slog.String("url", rl.URL),
+				slog.Int("status", rl.Status),
+				slog.Duration("latency", rl.Latency),
+			}
+			if rl.RequestID != "" {
+				attrs = append(attrs, slog.String("request_id", rl.RequestID))
+			}
+			if rl.RequestBody != "" {
+				attrs = append(attrs, slog.String("request_body", rl.RequestBody))
+			}
+			if rl.ResponseBody != "" {
+				attrs = append(attrs, slog.String("response_body", rl.ResponseBody))
+			}
+			if rl.Err != nil {
+				attrs = append(attrs, slog.String("error", rl.Err.Error()))
+			}
+			l.LogAttrs(ctx, level, "messagebird request", attrs...)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+//go:build go1.21
+// +build go1.21
+
+package messagebird
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithSlog(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":[{"code":2,"description":"Request not allowed"}]}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, nil))
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithSlog(l))
+	c.Request(nil, http.MethodGet, ts.URL+"?access_key=test_gshuPaZoeEG6ovbc8M79w0QyM", nil)
+
+	out := buf.String()
+	if !strings.Contains(out, `"level":"WARN"`) || !strings.Contains(out, `"status":401`) {
+		t.Errorf("expected failed request to be logged at warning level, got %s", out)
+	}
+	if strings.Contains(out, "test_gshuPaZoeEG6ovbc8M79w0QyM") {
+		t.Errorf("access key leaked in log: %s", out)
+	}
+}
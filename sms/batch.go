@@ -0,0 +1,80 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// batchPath represents the path to the batch endpoint of the Message
+// resource.
+const batchPath = path + "/batches"
+
+// MaxBatchSize is the maximum number of messages in a batch.
+const MaxBatchSize = 100
+
+// BatchMessage is a message in a batch. Each message in a batch can have its
+// own originator, recipients, body and params.
+type BatchMessage struct {
+	Originator string
+	Recipients []string
+	Body       string
+	Params     *Params
+}
+
+// BatchItem is the result of a message in a batch. Either the Message is set
+// or the Errors that prevented it from being created.
+type BatchItem struct {
+	Message *Message
+	Errors  []messagebird.Error
+}
+
+// Err returns the first error of the item, or nil if its message was created.
+func (i *BatchItem) Err() error {
+	if len(i.Errors) == 0 {
+		return nil
+	}
+	return i.Errors[0]
+}
+
+type batchRequest struct {
+	Messages []*messageRequest `json:"messages"`
+}
+
+type batchResponse struct {
+	Items []*BatchItem
+}
+
+// CreateBatch creates several distinct messages in a single request. The
+// returned items are in the order of messages. A failed message does not
+// prevent the others from being created, so check the Err of each item. An
+// error is only returned if the batch as a whole could not be sent.
+func CreateBatch(c *messagebird.Client, messages []*BatchMessage) ([]*BatchItem, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("at least 1 message is required")
+	}
+	if len(messages) > MaxBatchSize {
+		return nil, fmt.Errorf("at most %d messages are allowed in a batch, got %d", MaxBatchSize, len(messages))
+	}
+
+	request := &batchRequest{Messages: make([]*messageRequest, len(messages))}
+	for i, m := range messages {
+		requestData, err := requestDataForMessage(m.Originator, m.Recipients, m.Body, m.Params)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %v", i, err)
+		}
+		request.Messages[i] = requestData
+	}
+
+	response := &batchResponse{}
+	if err := c.Request(response, http.MethodPost, batchPath, request); err != nil {
+		return nil, err
+	}
+	if len(response.Items) != len(messages) {
+		return nil, fmt.Errorf("got %d results for a batch of %d messages", len(response.Items), len(messages))
+	}
+
+	return response.Items, nil
+}
@@ -0,0 +1,56 @@
+package sms
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestCreateBatch(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "batchResponseObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	items, err := CreateBatch(client, []*BatchMessage{
+		{Originator: "TestName", Recipients: []string{"31612345678"}, Body: "Hello Jane"},
+		{Originator: "TestName", Recipients: []string{"invalid"}, Body: "Hello John", Params: &Params{Reference: "john"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating batch: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/messages/batches")
+	if body := string(mbtest.Request.Body); !strings.HasPrefix(body, `{"messages":[{"originator":"TestName","body":"Hello Jane"`) || !strings.Contains(body, `"reference":"john"`) {
+		t.Errorf("unexpected request body: %s", body)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("got %d items, expected 2", len(items))
+	}
+	if items[0].Err() != nil || items[0].Message == nil || items[0].Message.Body != "Hello Jane" {
+		t.Errorf("got item %+v, expected the created message", items[0])
+	}
+	if err := items[1].Err(); err == nil || err.Error() != "no (correct) recipients found" {
+		t.Errorf("got error %v, expected no (correct) recipients found", err)
+	}
+}
+
+func TestCreateBatchInvalid(t *testing.T) {
+	client := mbtest.Client(t)
+
+	var cases = []struct {
+		name     string
+		messages []*BatchMessage
+	}{
+		{name: "Empty batch", messages: nil},
+		{name: "Too many messages", messages: make([]*BatchMessage, MaxBatchSize+1)},
+		{name: "Invalid message", messages: []*BatchMessage{{Originator: "TestName", Body: "Hello"}}},
+	}
+
+	for _, tt := range cases {
+		if _, err := CreateBatch(client, tt.messages); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
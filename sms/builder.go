@@ -0,0 +1,180 @@
+package sms
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// MessageBuilder builds a message step by step, as an alternative to passing
+// Params to Create:
+//
+//	msg, err := sms.New("TestSender", "Your code is 123456", "31612345678").
+//		WithReference("otp-42").
+//		WithValidity(5 * time.Minute).
+//		Send(client)
+//
+// Invalid options and combinations, such as a flash message that is also
+// binary, are reported by Build and Send before a request is made. The first
+// invalid option is reported.
+type MessageBuilder struct {
+	originator string
+	body       string
+	recipients []string
+	params     Params
+	err        error // The first invalid option.
+}
+
+// New starts building a message with body from originator to recipients.
+func New(originator, body string, recipients ...string) *MessageBuilder {
+	return &MessageBuilder{
+		originator: originator,
+		body:       body,
+		recipients: recipients,
+	}
+}
+
+// WithRecipients adds recipients to the message.
+func (b *MessageBuilder) WithRecipients(recipients ...string) *MessageBuilder {
+	b.recipients = append(b.recipients, recipients...)
+	return b
+}
+
+// WithReference sets the client reference, which is included in status
+// reports.
+func (b *MessageBuilder) WithReference(reference string) *MessageBuilder {
+	b.params.Reference = reference
+	return b
+}
+
+// WithReportURL sets the URL status reports of the message are sent to.
+func (b *MessageBuilder) WithReportURL(reportURL string) *MessageBuilder {
+	b.params.ReportURL = reportURL
+	return b
+}
+
+// WithValidity sets the period the message can be delivered in. It is sent
+// in whole seconds.
+func (b *MessageBuilder) WithValidity(validity time.Duration) *MessageBuilder {
+	if validity < time.Second {
+		b.fail(fmt.Errorf("validity must be at least 1s, got %s", validity))
+		return b
+	}
+	b.params.Validity = int(validity / time.Second)
+	return b
+}
+
+// WithGateway sets the SMS route the message is sent through.
+func (b *MessageBuilder) WithGateway(gateway int) *MessageBuilder {
+	b.params.Gateway = gateway
+	return b
+}
+
+// WithDataCoding sets the encoding of the body: DataCodingPlain,
+// DataCodingUnicode or DataCodingAuto.
+func (b *MessageBuilder) WithDataCoding(dataCoding string) *MessageBuilder {
+	switch dataCoding {
+	case DataCodingPlain, DataCodingUnicode, DataCodingAuto:
+		b.params.DataCoding = dataCoding
+	default:
+		b.fail(fmt.Errorf("unknown data coding %q", dataCoding))
+	}
+	return b
+}
+
+// ScheduledAt schedules the message to be sent at t.
+func (b *MessageBuilder) ScheduledAt(t time.Time) *MessageBuilder {
+	b.params.ScheduledDatetime = t
+	return b
+}
+
+// AsFlash makes the message a flash message, which is displayed immediately
+// and not stored by the phone.
+func (b *MessageBuilder) AsFlash() *MessageBuilder {
+	return b.withType(TypeFlash, nil)
+}
+
+// AsBinary makes the message a binary message with the user data header of
+// details. The body must then be hex encoded.
+func (b *MessageBuilder) AsBinary(details BinaryDetails) *MessageBuilder {
+	return b.withType(TypeBinary, details.TypeDetails())
+}
+
+// AsPremium makes the message a premium message billed to the recipient.
+func (b *MessageBuilder) AsPremium(details PremiumDetails) *MessageBuilder {
+	return b.withType(TypePremium, details.TypeDetails())
+}
+
+// withType sets the type of the message, which can only be set once.
+func (b *MessageBuilder) withType(typ string, details TypeDetails) *MessageBuilder {
+	if b.params.Type != "" && b.params.Type != typ {
+		b.fail(fmt.Errorf("a %s message can not also be %s", b.params.Type, typ))
+		return b
+	}
+	b.params.Type = typ
+	b.params.TypeDetails = details
+	return b
+}
+
+// fail records err, unless an earlier option was invalid.
+func (b *MessageBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Build validates the message and returns it, e.g. to send it in a batch
+// with CreateBatch.
+func (b *MessageBuilder) Build() (*BatchMessage, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	params := b.params
+	return &BatchMessage{
+		Originator: b.originator,
+		Recipients: append([]string(nil), b.recipients...),
+		Body:       b.body,
+		Params:     &params,
+	}, nil
+}
+
+// Send validates and creates the message.
+func (b *MessageBuilder) Send(c *messagebird.Client) (*Message, error) {
+	m, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return Create(c, m.Originator, m.Recipients, m.Body, m.Params)
+}
+
+// validate returns the first error of the options, or of their
+// combination.
+func (b *MessageBuilder) validate() error {
+	if b.err != nil {
+		return b.err
+	}
+
+	switch b.params.Type {
+	case TypeBinary:
+		if b.params.DataCoding != "" {
+			return errors.New("binary messages have no data coding")
+		}
+		if _, err := hex.DecodeString(b.params.TypeDetails["udh"].(string)); err != nil {
+			return fmt.Errorf("udh must be hex encoded: %v", err)
+		}
+		if _, err := hex.DecodeString(b.body); err != nil {
+			return fmt.Errorf("body of binary messages must be hex encoded: %v", err)
+		}
+	case TypePremium:
+		if b.params.TypeDetails["shortcode"] == 0 || b.params.TypeDetails["keyword"] == "" {
+			return errors.New("premium messages require a shortcode and keyword")
+		}
+	}
+
+	_, err := requestDataForMessage(b.originator, b.recipients, b.body, &b.params)
+	return err
+}
@@ -0,0 +1,73 @@
+package sms
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
+)
+
+func TestBuilderSend(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	message, err := New("TestName", "Hello World", "31612345678").
+		WithReference("otp-42").
+		WithValidity(5 * time.Minute).
+		WithDataCoding(DataCodingAuto).
+		AsFlash().
+		Send(client)
+	if err != nil {
+		t.Fatalf("unexpected error creating message: %s", err)
+	}
+	assertMessageObject(t, message)
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/messages")
+	var req messageRequest
+	if err := json.Unmarshal(mbtest.Request.Body, &req); err != nil {
+		t.Fatalf("unexpected error decoding request: %s", err)
+	}
+	if req.Reference != "otp-42" || req.Validity != 300 || req.DataCoding != DataCodingAuto || req.Type != TypeFlash || req.MClass != 0 {
+		t.Errorf("got request %+v, expected the builder options", req)
+	}
+}
+
+func TestBuilderBuild(t *testing.T) {
+	m, err := New("TestName", "0b0a", "31612345678").
+		WithRecipients("31687654321").
+		AsBinary(BinaryDetails{UDH: "050003340201"}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error building message: %s", err)
+	}
+	if len(m.Recipients) != 2 || m.Params.Type != TypeBinary || m.Params.TypeDetails["udh"] != "050003340201" {
+		t.Errorf("got message %+v with params %+v, expected two recipients and binary details", m, m.Params)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	var cases = []struct {
+		name string
+		b    *MessageBuilder
+	}{
+		{name: "Missing recipient", b: New("TestName", "Hello World")},
+		{name: "Missing body", b: New("TestName", "", "31612345678")},
+		{name: "Flash and binary", b: New("TestName", "0b0a", "31612345678").AsFlash().AsBinary(BinaryDetails{UDH: "0500"})},
+		{name: "Binary with data coding", b: New("TestName", "0b0a", "31612345678").AsBinary(BinaryDetails{UDH: "0500"}).WithDataCoding(DataCodingUnicode)},
+		{name: "Binary body not hex", b: New("TestName", "Hello World", "31612345678").AsBinary(BinaryDetails{UDH: "0500"})},
+		{name: "Premium without keyword", b: New("TestName", "Hello World", "31612345678").AsPremium(PremiumDetails{Tariff: 150, Shortcode: 1008})},
+		{name: "Validity too short", b: New("TestName", "Hello World", "31612345678").WithValidity(time.Millisecond)},
+		{name: "Unknown data coding", b: New("TestName", "Hello World", "31612345678").WithDataCoding("utf-8")},
+	}
+
+	for _, tt := range cases {
+		if _, err := tt.b.Build(); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+		if _, err := tt.b.Send(mbtest.Client(t)); err == nil {
+			t.Errorf("expected error sending, got nil, test case: %s", tt.name)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package sms
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// MaxRecipients is the maximum number of recipients of a message created
+// with a single request.
+const MaxRecipients = 50
+
+// defaultChunkConcurrency is the number of chunks created at the same time
+// when ChunkOptions.Concurrency is not set.
+const defaultChunkConcurrency = 4
+
+// ChunkOptions configure CreateChunked.
+type ChunkOptions struct {
+	// Size is the number of recipients per request. It defaults to, and can
+	// not exceed, MaxRecipients.
+	Size int
+
+	// Concurrency is the maximum number of requests sent at the same time.
+	// It defaults to 4.
+	Concurrency int
+
+	// PrefixRates paces the recipients of each MSISDN prefix; see
+	// WithPerPrefixRate.
+	PrefixRates *PrefixRateLimiter
+}
+
+// ChunkResult is the result of creating the message for a chunk of
+// recipients. Either Message or Err is set.
+type ChunkResult struct {
+	Recipients []string
+	Message    *Message
+	Err        error
+}
+
+// ChunkResults are the results of CreateChunked, in the order of the
+// recipients unless they are grouped by prefix.
+type ChunkResults []*ChunkResult
+
+// Messages returns the messages that were created.
+func (rs ChunkResults) Messages() []*Message {
+	var messages []*Message
+	for _, r := range rs {
+		if r.Err == nil {
+			messages = append(messages, r.Message)
+		}
+	}
+	return messages
+}
+
+// Failed returns the recipients of the chunks that could not be created, and
+// the errors they failed with.
+func (rs ChunkResults) Failed() map[string]error {
+	failed := make(map[string]error)
+	for _, r := range rs {
+		if r.Err == nil {
+			continue
+		}
+		for _, recipient := range r.Recipients {
+			failed[recipient] = r.Err
+		}
+	}
+	return failed
+}
+
+// Err returns the error of the first chunk that failed, or nil if all
+// messages were created.
+func (rs ChunkResults) Err() error {
+	for _, r := range rs {
+		if r.Err != nil {
+			return r.Err
+		}
+	}
+	return nil
+}
+
+// CreateChunked creates a message for recipients like Create, but splits
+// them into chunks that fit a single request. This results in one message
+// per chunk. The chunks are created concurrently, and a failed chunk does
+// not prevent the others from being created, so check the Err of the
+// results. An error is only returned if the message is invalid. Options may
+// be nil.
+//
+// With PrefixRates set, recipients are grouped by their longest matching
+// prefix, chunks hold at most one second's worth of recipients of their
+// prefix, and they are paced to each prefix's rate independently. The
+// results are then ordered by prefix.
+//
+// Chunks that failed can be retried by passing the recipients of their
+// results to CreateChunked again. If c has an idempotency key, each chunk is
+// sent with a key derived from it and the chunk's recipients.
+func CreateChunked(c *messagebird.Client, originator string, recipients []string, body string, msgParams *Params, options *ChunkOptions) (ChunkResults, error) {
+	if err := validateContent(originator, recipients, body, msgParams); err != nil {
+		return nil, err
+	}
+
+	size, concurrency := MaxRecipients, defaultChunkConcurrency
+	var limiter *PrefixRateLimiter
+	if options != nil {
+		if options.Size > MaxRecipients {
+			return nil, errors.New("chunk size can not exceed MaxRecipients")
+		}
+		if options.Size > 0 {
+			size = options.Size
+		}
+		if options.Concurrency > 0 {
+			concurrency = options.Concurrency
+		}
+		limiter = options.PrefixRates
+	}
+
+	var results ChunkResults
+	var prefixes []string
+	for _, g := range groupByPrefix(limiter, recipients) {
+		n := size
+		if rate := limiter.Rate(g.prefix); rate > 0 && rate < n {
+			n = rate
+		}
+		for rs := g.recipients; len(rs) > 0; {
+			if n > len(rs) {
+				n = len(rs)
+			}
+			results = append(results, &ChunkResult{Recipients: rs[:n:n]})
+			prefixes = append(prefixes, g.prefix)
+			rs = rs[n:]
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, r := range results {
+		wg.Add(1)
+		go func(r *ChunkResult, prefix string) {
+			defer wg.Done()
+			// Chunks wait for the rate of their prefix before taking a slot,
+			// so throttled prefixes do not hold up the others.
+			if err := limiter.Wait(c.Context(), prefix, len(r.Recipients)); err != nil {
+				r.Err = err
+				return
+			}
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			// Chunks must not share the client's idempotency key, or the API
+			// returns the message of the first chunk for all of them. The key
+			// is derived from the recipients, so retried chunks reuse it.
+			cc := c.WithDerivedIdempotencyKey(strings.Join(r.Recipients, ","))
+			r.Message, r.Err = Create(cc, originator, r.Recipients, body, msgParams)
+		}(r, prefixes[i])
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// prefixGroup holds the recipients matching a prefix of a PrefixRateLimiter.
+type prefixGroup struct {
+	prefix     string
+	recipients []string
+}
+
+// groupByPrefix groups recipients by the prefixes of limiter, in the order
+// the prefixes first occur. Without a limiter, all recipients are in one
+// group with the empty prefix.
+func groupByPrefix(limiter *PrefixRateLimiter, recipients []string) []prefixGroup {
+	if limiter == nil {
+		return []prefixGroup{{recipients: recipients}}
+	}
+
+	var groups []prefixGroup
+	index := make(map[string]int)
+	for _, r := range recipients {
+		prefix := limiter.Prefix(r)
+		i, ok := index[prefix]
+		if !ok {
+			i = len(groups)
+			index[prefix] = i
+			groups = append(groups, prefixGroup{prefix: prefix})
+		}
+		groups[i].recipients = append(groups[i].recipients, r)
+	}
+	return groups
+}
@@ -0,0 +1,169 @@
+package sms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+func TestCreateChunked(t *testing.T) {
+	var mu sync.Mutex
+	var active, maxActive int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			active--
+			mu.Unlock()
+		}()
+
+		var req messageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error decoding request: %s", err)
+		}
+		if len(req.Recipients) > 2 {
+			t.Errorf("got %d recipients, expected at most 2", len(req.Recipients))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.Recipients[0] == "31600000004" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"errors":[{"code":9,"description":"no (correct) recipients found","parameter":"recipients"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":"msg-%s","recipients":{"totalCount":%d}}`, req.Recipients[0], len(req.Recipients))
+	}))
+	defer ts.Close()
+
+	var recipients []string
+	for i := 0; i < 7; i++ {
+		recipients = append(recipients, "3160000000"+strconv.Itoa(i))
+	}
+
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	results, err := CreateChunked(c, "TestName", recipients, "Hello World", nil, &ChunkOptions{Size: 2, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("got %d results, expected 4", len(results))
+	}
+	for i, r := range results {
+		if r.Recipients[0] != recipients[i*2] {
+			t.Errorf("got chunk %d starting with %s, expected %s", i, r.Recipients[0], recipients[i*2])
+		}
+	}
+	if messages := results.Messages(); len(messages) != 3 || messages[2].ID != "msg-31600000006" || messages[2].Recipients.TotalCount != 1 {
+		t.Errorf("got messages %+v, expected 3 with the last for one recipient", messages)
+	}
+
+	failed := results.Failed()
+	if len(failed) != 2 || failed["31600000004"] == nil || failed["31600000005"] == nil {
+		t.Errorf("got failed recipients %v, expected 31600000004 and 31600000005", failed)
+	}
+	var errResp messagebird.ErrorResponse
+	if err := results.Err(); err == nil || !errors.As(err, &errResp) {
+		t.Errorf("got %v, expected the error response of the failed chunk", err)
+	}
+	if maxActive > 2 {
+		t.Errorf("got %d concurrent requests, expected at most 2", maxActive)
+	}
+}
+
+func TestCreateChunkedIdempotencyKeys(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req messageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		keys[req.Recipients[0]] = r.Header.Get("Idempotency-Key")
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":"msg-%s"}`, req.Recipients[0])
+	}))
+	defer ts.Close()
+
+	recipients := []string{"31600000000", "31600000001", "31600000002", "31600000003"}
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	c = c.WithIdempotencyKey("notification-1")
+
+	send := func() map[string]string {
+		if _, err := CreateChunked(c, "TestName", recipients, "Hello World", nil, &ChunkOptions{Size: 2}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		sent := keys
+		keys = make(map[string]string)
+		return sent
+	}
+
+	first := send()
+	if len(first) != 2 || first["31600000000"] == "" || first["31600000000"] == first["31600000002"] || first["31600000000"] == "notification-1" {
+		t.Errorf("got idempotency keys %v, expected a distinct derived key per chunk", first)
+	}
+	if again := send(); !reflect.DeepEqual(again, first) {
+		t.Errorf("got idempotency keys %v when sending again, expected %v", again, first)
+	}
+}
+
+func TestCreateChunkedPerPrefixRate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req messageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":"msg-%s"}`, req.Recipients[0])
+	}))
+	defer ts.Close()
+
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	options := &ChunkOptions{PrefixRates: WithPerPrefixRate(map[string]int{"234": 2, "+2348": 3})}
+
+	recipients := []string{"2348000000001", "31600000001", "2347000000001", "2348000000002", "2347000000002", "2347000000003", "31600000002"}
+	results, err := CreateChunked(c, "TestName", recipients, "Hello World", nil, options)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := results.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var chunks [][]string
+	for _, r := range results {
+		chunks = append(chunks, r.Recipients)
+	}
+	expected := [][]string{
+		{"2348000000001", "2348000000002"},
+		{"31600000001", "31600000002"},
+		{"2347000000001", "2347000000002"},
+		{"2347000000003"},
+	}
+	if !reflect.DeepEqual(chunks, expected) {
+		t.Errorf("got chunks %v, expected %v", chunks, expected)
+	}
+}
+
+func TestCreateChunkedErrors(t *testing.T) {
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+
+	if _, err := CreateChunked(c, "TestName", nil, "Hello World", nil, nil); err == nil {
+		t.Errorf("expected error without recipients, got nil")
+	}
+	if _, err := CreateChunked(c, "TestName", []string{"31612345678"}, "Hello World", nil, &ChunkOptions{Size: MaxRecipients + 1}); err == nil {
+		t.Errorf("expected error for too large chunks, got nil")
+	}
+}
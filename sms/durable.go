@@ -0,0 +1,275 @@
+package sms
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// DurableQueueOptions configure a DurableQueue.
+type DurableQueueOptions struct {
+	// OnResult is called with the result of each message that was created,
+	// or that failed permanently and was dropped from the store.
+	OnResult func(*QueueResult)
+
+	// OnError is called when the store fails or a message could not be
+	// created because of an outage, before it is retried. It is also called
+	// with a *CorruptMessagesError for stored messages that can not be read,
+	// which are skipped.
+	OnError func(error)
+
+	// MinRetryDelay and MaxRetryDelay bound the delay before retrying after
+	// an outage, which doubles with each failed attempt. They default to 1
+	// second and 1 minute.
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// DurableQueue sends messages in the background like Queue, but keeps them
+// in a QueueStore until they are created, so no message is lost when the
+// API is unavailable or the process restarts:
+//
+//	store, err := sms.NewFileStore("/var/lib/alerts/sms")
+//	q := sms.NewDurableQueue(client, store, &sms.DurableQueueOptions{
+//	    OnResult: func(r *sms.QueueResult) { ... },
+//	})
+//	defer q.Close()
+//
+//	err = q.Enqueue(ctx, &sms.BatchMessage{Originator: "Alerts", Recipients: oncall, Body: body})
+//
+// Messages stored by an earlier process are sent when the queue is created.
+// They are sent one at a time, in the order they were enqueued. During an
+// outage, i.e. on network errors, 5xx responses, rate limiting and an open
+// circuit breaker, and on authorization errors, the queue waits and retries
+// the oldest message. Other errors, e.g. invalid recipients or a 4xx HTML
+// page of a proxy, are permanent: the message is removed and reported to
+// OnResult. So are responses exceeding the client's size or JSON depth
+// limits and successful responses that can not be decoded, as they fail the
+// same way when sent again; the message may have been created in that case.
+//
+// Each message is sent with an idempotency key derived from its ID, so a
+// message that was created but not yet removed from the store when the
+// process stopped is not created twice.
+type DurableQueue struct {
+	c     *messagebird.Client
+	store QueueStore
+	opts  DurableQueueOptions
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	closeOnce sync.Once
+}
+
+// NewDurableQueue starts a queue sending the messages in store with c.
+// Options may be nil. The caller must call Close to stop sending.
+func NewDurableQueue(c *messagebird.Client, store QueueStore, options *DurableQueueOptions) *DurableQueue {
+	var o DurableQueueOptions
+	if options != nil {
+		o = *options
+	}
+	if o.MinRetryDelay <= 0 {
+		o.MinRetryDelay = time.Second
+	}
+	if o.MaxRetryDelay <= 0 {
+		o.MaxRetryDelay = time.Minute
+	}
+
+	ctx, cancel := context.WithCancel(c.Context())
+	q := &DurableQueue{
+		c:      c,
+		store:  store,
+		opts:   o,
+		wake:   make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go q.work()
+	return q
+}
+
+// Enqueue validates m and adds it to the store. The message is only
+// enqueued once it is stored, so it is not lost if Enqueue returns nil.
+func (q *DurableQueue) Enqueue(ctx context.Context, m *BatchMessage) error {
+	if m == nil {
+		return errors.New("message is required")
+	}
+	if _, err := requestDataForMessage(m.Originator, m.Recipients, m.Body, m.Params); err != nil {
+		return err
+	}
+	if q.ctx.Err() != nil {
+		return ErrQueueClosed
+	}
+
+	id, err := newStoredID()
+	if err != nil {
+		return err
+	}
+	if err := q.store.Put(ctx, &StoredMessage{ID: id, Message: m, EnqueuedAt: time.Now()}); err != nil {
+		return err
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Close stops sending and waits for the message being sent, if any. The
+// messages that were not sent remain in the store.
+func (q *DurableQueue) Close() {
+	q.closeOnce.Do(q.cancel)
+	<-q.done
+}
+
+func (q *DurableQueue) work() {
+	defer close(q.done)
+
+	delay := time.Duration(0)
+	for {
+		sent, err := q.drain()
+		switch {
+		case q.ctx.Err() != nil:
+			return
+		case err != nil:
+			q.onError(err)
+			if delay *= 2; delay < q.opts.MinRetryDelay {
+				delay = q.opts.MinRetryDelay
+			}
+			if delay > q.opts.MaxRetryDelay {
+				delay = q.opts.MaxRetryDelay
+			}
+			if !q.sleep(delay) {
+				return
+			}
+			continue
+		case sent:
+			delay = 0
+		}
+
+		// The store is empty until a message is enqueued.
+		select {
+		case <-q.wake:
+		case <-q.ctx.Done():
+			return
+		}
+	}
+}
+
+// drain sends the stored messages until the store is empty or an attempt
+// fails with a temporary error, which is returned. It reports whether a
+// message was sent.
+func (q *DurableQueue) drain() (bool, error) {
+	sent := false
+	for {
+		messages, err := q.store.List(q.ctx)
+		var corrupt *CorruptMessagesError
+		if errors.As(err, &corrupt) {
+			// The readable messages are still sent.
+			q.onError(err)
+			err = nil
+		}
+		if err != nil || len(messages) == 0 {
+			return sent, err
+		}
+		for _, sm := range messages {
+			if err := q.send(sm); err != nil {
+				return sent, err
+			}
+			sent = true
+		}
+	}
+}
+
+// send creates sm and removes it from the store, unless it fails with a
+// temporary error.
+func (q *DurableQueue) send(sm *StoredMessage) error {
+	m := sm.Message
+	var created *Message
+	var err error
+	if m == nil {
+		err = errors.New("message is required")
+	} else if _, err = requestDataForMessage(m.Originator, m.Recipients, m.Body, m.Params); err == nil {
+		c := q.c.WithContext(q.ctx).WithIdempotencyKey(messagebird.IdempotencyKey(sm.ID))
+		created, err = Create(c, m.Originator, m.Recipients, m.Body, m.Params)
+		if err != nil && temporary(err) {
+			return err
+		}
+	}
+
+	if err := q.store.Delete(q.ctx, sm.ID); err != nil {
+		return err
+	}
+	if q.opts.OnResult != nil {
+		q.opts.OnResult(&QueueResult{Message: m, Created: created, Err: err})
+	}
+	return nil
+}
+
+// temporary reports whether creating a valid message failed because of an
+// outage or a misconfigured client, so it may succeed later. 4xx responses
+// other than rate limiting and authorization errors are permanent, as are
+// responses that exceed the client's limits or can not be decoded; network
+// errors, 5xx responses and an open circuit breaker are not.
+func temporary(err error) bool {
+	var resp messagebird.ErrorResponse
+	var status messagebird.StatusError
+	switch {
+	case errors.As(err, &resp):
+		return temporaryStatus(err, resp.StatusCode)
+	case errors.As(err, &status):
+		return temporaryStatus(err, status.StatusCode)
+	}
+
+	var tooLarge *messagebird.ResponseTooLargeError
+	var depth *messagebird.JSONDepthError
+	var syntax *json.SyntaxError
+	var typ *json.UnmarshalTypeError
+	return !errors.As(err, &tooLarge) && !errors.As(err, &depth) &&
+		!errors.As(err, &syntax) && !errors.As(err, &typ)
+}
+
+// temporaryStatus reports whether an error response with status code may
+// succeed later.
+func temporaryStatus(err error, code int) bool {
+	return code >= http.StatusInternalServerError ||
+		errors.Is(err, messagebird.ErrRateLimited) ||
+		errors.Is(err, messagebird.ErrUnauthorized)
+}
+
+func (q *DurableQueue) onError(err error) {
+	if q.opts.OnError != nil {
+		q.opts.OnError(err)
+	}
+}
+
+// sleep waits for d and reports whether the queue is still open.
+func (q *DurableQueue) sleep(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-q.ctx.Done():
+		return false
+	}
+}
+
+func newStoredID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,281 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// durableServer fails the first outages requests with 503, and then responds
+// like queueServer. It records the idempotency keys of the requests by body.
+func durableServer(t *testing.T, outages int) (*messagebird.Client, map[string][]string, func()) {
+	var mu sync.Mutex
+	keys := make(map[string][]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req messageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error decoding request: %s", err)
+		}
+		mu.Lock()
+		keys[req.Body] = append(keys[req.Body], r.Header.Get("Idempotency-Key"))
+		outage := outages > 0
+		outages--
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case outage:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"errors":[{"code":0,"description":"Service unavailable"}]}`)
+		case req.Body == "fail":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"errors":[{"code":9,"description":"no (correct) recipients found","parameter":"recipients"}]}`)
+		case req.Body == "html":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<html><body>404 Not Found</body></html>")
+		case req.Body == "garbled":
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":`)
+		default:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id":"msg-%s","body":%q}`, req.Body, req.Body)
+		}
+	}))
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	return c, keys, ts.Close
+}
+
+func tempStore(t *testing.T) (*FileStore, func()) {
+	dir, err := ioutil.TempDir("", "sms-queue")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return s, func() { os.RemoveAll(dir) }
+}
+
+func TestDurableQueue(t *testing.T) {
+	c, keys, stop := durableServer(t, 2)
+	defer stop()
+	store, remove := tempStore(t)
+	defer remove()
+
+	results := make(chan *QueueResult, 3)
+	var errs int
+	q := NewDurableQueue(c, store, &DurableQueueOptions{
+		OnResult:      func(r *QueueResult) { results <- r },
+		OnError:       func(error) { errs++ },
+		MinRetryDelay: time.Millisecond,
+	})
+	defer q.Close()
+
+	for _, body := range []string{"Hello", "fail", "World"} {
+		m := &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: body}
+		if err := q.Enqueue(context.Background(), m); err != nil {
+			t.Fatalf("unexpected error enqueueing: %s", err)
+		}
+	}
+	if err := q.Enqueue(context.Background(), &BatchMessage{Originator: "TestName"}); err == nil {
+		t.Errorf("expected an error enqueueing an invalid message")
+	}
+
+	for _, expected := range []string{"Hello", "fail", "World"} {
+		r := <-results
+		if r.Message.Body != expected {
+			t.Fatalf("got result for %s, expected %s", r.Message.Body, expected)
+		}
+		if expected == "fail" {
+			if r.Err == nil {
+				t.Errorf("expected the failing message to fail")
+			}
+		} else if r.Err != nil || r.Created.ID != "msg-"+expected {
+			t.Errorf("got %v for %s, expected it to be created", r.Err, expected)
+		}
+	}
+	q.Close()
+
+	if errs != 2 {
+		t.Errorf("got %d errors, expected one for each outage", errs)
+	}
+	if k := keys["Hello"]; len(k) != 3 || k[0] == "" || k[0] != k[1] || k[1] != k[2] {
+		t.Errorf("got idempotency keys %v, expected the same key for each attempt", k)
+	}
+	if messages, err := store.List(context.Background()); err != nil || len(messages) != 0 {
+		t.Errorf("got %d stored messages, expected none", len(messages))
+	}
+}
+
+func TestDurableQueuePermanentErrors(t *testing.T) {
+	c, keys, stop := durableServer(t, 0)
+	defer stop()
+	store, remove := tempStore(t)
+	defer remove()
+
+	results := make(chan *QueueResult, 3)
+	q := NewDurableQueue(c, store, &DurableQueueOptions{
+		OnResult:      func(r *QueueResult) { results <- r },
+		MinRetryDelay: time.Millisecond,
+	})
+	defer q.Close()
+
+	bodies := []string{"html", "garbled", "World"}
+	for _, body := range bodies {
+		m := &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: body}
+		if err := q.Enqueue(context.Background(), m); err != nil {
+			t.Fatalf("unexpected error enqueueing: %s", err)
+		}
+	}
+
+	for _, expected := range bodies {
+		select {
+		case r := <-results:
+			if r.Message.Body != expected {
+				t.Fatalf("got result for %s, expected %s", r.Message.Body, expected)
+			}
+			if (r.Err != nil) != (expected != "World") {
+				t.Errorf("got %v for %s", r.Err, expected)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected a result for %s, the queue is blocked", expected)
+		}
+	}
+	for _, body := range bodies {
+		if len(keys[body]) != 1 {
+			t.Errorf("got %d attempts for %s, expected 1", len(keys[body]), body)
+		}
+	}
+}
+
+func TestTemporary(t *testing.T) {
+	var cases = []struct {
+		name string
+		err  error
+		e    bool
+	}{
+		{name: "Network error", err: errors.New("connection refused"), e: true},
+		{name: "JSON 422", err: messagebird.ErrorResponse{StatusCode: http.StatusUnprocessableEntity}},
+		{name: "JSON 503", err: messagebird.ErrorResponse{StatusCode: http.StatusServiceUnavailable}, e: true},
+		{name: "HTML 404", err: messagebird.StatusError{StatusCode: http.StatusNotFound}},
+		{name: "HTML 413", err: messagebird.StatusError{StatusCode: http.StatusRequestEntityTooLarge}},
+		{name: "HTML 429", err: messagebird.StatusError{StatusCode: http.StatusTooManyRequests}, e: true},
+		{name: "HTML 401", err: messagebird.StatusError{StatusCode: http.StatusUnauthorized}, e: true},
+		{name: "HTML 403", err: messagebird.StatusError{StatusCode: http.StatusForbidden}, e: true},
+		{name: "HTML 502", err: messagebird.StatusError{StatusCode: http.StatusBadGateway}, e: true},
+		{name: "Response too large", err: &messagebird.ResponseTooLargeError{Limit: 10}},
+		{name: "JSON too deep", err: &messagebird.JSONDepthError{Limit: 64}},
+		{name: "Undecodable response", err: fmt.Errorf("could not decode response JSON: %w", json.Unmarshal([]byte(`{"id":`), &struct{}{}))},
+	}
+
+	for _, tt := range cases {
+		if got := temporary(tt.err); got != tt.e {
+			t.Errorf("got temporary %t, expected %t, test case: %s", got, tt.e, tt.name)
+		}
+	}
+}
+
+func TestDurableQueueRestart(t *testing.T) {
+	c, keys, stop := durableServer(t, 0)
+	defer stop()
+	store, remove := tempStore(t)
+	defer remove()
+
+	// A message left by an earlier process is sent without enqueueing it.
+	m := &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: "Pending"}
+	if err := store.Put(context.Background(), &StoredMessage{ID: "pending", Message: m, EnqueuedAt: time.Now()}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	results := make(chan *QueueResult, 1)
+	q := NewDurableQueue(c, store, &DurableQueueOptions{OnResult: func(r *QueueResult) { results <- r }})
+	defer q.Close()
+
+	if r := <-results; r.Err != nil || r.Created.ID != "msg-Pending" {
+		t.Errorf("got %v, expected the pending message to be created", r.Err)
+	}
+	if k := keys["Pending"]; len(k) != 1 || k[0] != messagebird.IdempotencyKey("pending") {
+		t.Errorf("got idempotency keys %v, expected the key of the stored message", k)
+	}
+
+	q.Close()
+	if err := q.Enqueue(context.Background(), m); err != ErrQueueClosed {
+		t.Errorf("got %v, expected %v", err, ErrQueueClosed)
+	}
+}
+
+// fakeRedis is an in-memory RedisHashClient.
+type fakeRedis map[string]map[string]string
+
+func (r fakeRedis) HSet(_ context.Context, key, field, value string) error {
+	if r[key] == nil {
+		r[key] = make(map[string]string)
+	}
+	r[key][field] = value
+	return nil
+}
+
+func (r fakeRedis) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	return r[key], nil
+}
+
+func (r fakeRedis) HDel(_ context.Context, key, field string) error {
+	delete(r[key], field)
+	return nil
+}
+
+func TestQueueStores(t *testing.T) {
+	fileStore, remove := tempStore(t)
+	defer remove()
+	sqlStore, closeDB := sqlStore(t)
+	defer closeDB()
+
+	tt := []struct {
+		name  string
+		store QueueStore
+	}{
+		{"file", fileStore},
+		{"redis", NewRedisStore(fakeRedis{}, "sms")},
+		{"sql", sqlStore},
+	}
+
+	now := time.Now()
+	for _, tc := range tt {
+		ctx := context.Background()
+		for i, id := range []string{"c", "a", "b"} {
+			m := &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: id}
+			if err := tc.store.Put(ctx, &StoredMessage{ID: id, Message: m, EnqueuedAt: now.Add(time.Duration(i) * time.Second)}); err != nil {
+				t.Fatalf("unexpected error: %s, test case: %s", err, tc.name)
+			}
+		}
+		if err := tc.store.Delete(ctx, "a"); err != nil {
+			t.Fatalf("unexpected error: %s, test case: %s", err, tc.name)
+		}
+		if err := tc.store.Delete(ctx, "unknown"); err != nil {
+			t.Errorf("unexpected error deleting an unknown message: %s, test case: %s", err, tc.name)
+		}
+
+		messages, err := tc.store.List(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s, test case: %s", err, tc.name)
+		}
+		if len(messages) != 2 || messages[0].ID != "c" || messages[1].ID != "b" {
+			t.Fatalf("got %d messages, expected c and b in order, test case: %s", len(messages), tc.name)
+		}
+		if messages[1].Message.Body != "b" || !messages[1].EnqueuedAt.Equal(now.Add(2*time.Second)) {
+			t.Errorf("got %v, expected the stored message, test case: %s", messages[1], tc.name)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package sms
+
+import "unicode/utf8"
+
+// The number of characters, in septets for DataCodingPlain and UTF-16 code
+// units for DataCodingUnicode, that fit a single message and each part of a
+// concatenated message. Concatenated messages are shorter, as each part
+// starts with a header.
+const (
+	PlainPartLength         = 160
+	PlainConcatPartLength   = 153
+	UnicodePartLength       = 70
+	UnicodeConcatPartLength = 67
+)
+
+// gsm7Basic is the GSM 03.38 basic character set, excluding the escape to
+// the extension table.
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extension is the GSM 03.38 extension table. Its characters take two
+// septets: the escape and the character.
+const gsm7Extension = "\f^{}\\[~]|€"
+
+var gsm7Septets = func() map[rune]int {
+	m := make(map[rune]int)
+	for _, r := range gsm7Basic {
+		m[r] = 1
+	}
+	for _, r := range gsm7Extension {
+		m[r] = 2
+	}
+	return m
+}()
+
+// gsm7Replacements are GSM 03.38 lookalikes of characters that are often
+// pasted into messages by accident, e.g. from word processors.
+var gsm7Replacements = map[rune]string{
+	'‘':      "'",
+	'’':      "'",
+	'‚':      ",",
+	'“':      "\"",
+	'”':      "\"",
+	'„':      "\"",
+	'–':      "-",
+	'—':      "-",
+	'…':      "...",
+	'\u00a0': " ",
+	'\t':     " ",
+	'ç':      "Ç",
+	'•':      "-",
+}
+
+// Segments describes how a body is sent: the encoding and the number of
+// message parts it's split into, which is how messages are billed.
+type Segments struct {
+	DataCoding string // DataCodingPlain or DataCodingUnicode.
+
+	// Length is the number of septets for DataCodingPlain, or UTF-16 code
+	// units for DataCodingUnicode.
+	Length int
+	Parts  int
+
+	// Remaining is the number of septets or code units that still fit the
+	// last part.
+	Remaining int
+}
+
+// IsGSM7 reports whether body only contains characters of the GSM 03.38
+// character set, so it can be sent with DataCodingPlain.
+func IsGSM7(body string) bool {
+	for _, r := range body {
+		if gsm7Septets[r] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CountSegments returns the segments body is sent in with dataCoding. An
+// empty dataCoding or DataCodingAuto is resolved as the API does, so it
+// results in DataCodingUnicode only if the body is not GSM-7. With
+// DataCodingPlain, characters outside the GSM 03.38 character set are
+// counted as the single character they're replaced with.
+func CountSegments(body, dataCoding string) Segments {
+	if dataCoding == "" || dataCoding == DataCodingAuto {
+		dataCoding = DataCodingPlain
+		if !IsGSM7(body) {
+			dataCoding = DataCodingUnicode
+		}
+	}
+
+	single, concat := PlainPartLength, PlainConcatPartLength
+	units := septets
+	if dataCoding == DataCodingUnicode {
+		single, concat = UnicodePartLength, UnicodeConcatPartLength
+		units = utf16Units
+	}
+
+	s := Segments{DataCoding: dataCoding}
+	for _, r := range body {
+		s.Length += units(r)
+	}
+	switch {
+	case s.Length == 0:
+		s.Remaining = single
+	case s.Length <= single:
+		s.Parts, s.Remaining = 1, single-s.Length
+	default:
+		// A character spanning two units is never split across parts.
+		used := 0
+		s.Parts = 1
+		for _, r := range body {
+			n := units(r)
+			if used+n > concat {
+				s.Parts++
+				used = 0
+			}
+			used += n
+		}
+		s.Remaining = concat - used
+	}
+	return s
+}
+
+// UnicodeCharacter is a character that forces a body to be sent with
+// DataCodingUnicode, which reduces the length of each part to less than
+// half.
+type UnicodeCharacter struct {
+	Char   rune
+	Offset int // The byte offset in the body.
+
+	// Replacement is a GSM-7 lookalike of Char, or empty if there is none.
+	Replacement string
+}
+
+// UnicodeCharacters returns the characters of body outside the GSM 03.38
+// character set, e.g. to warn that a typographic quote makes a message
+// twice as expensive.
+func UnicodeCharacters(body string) []UnicodeCharacter {
+	var chars []UnicodeCharacter
+	for i, r := range body {
+		if gsm7Septets[r] == 0 {
+			chars = append(chars, UnicodeCharacter{Char: r, Offset: i, Replacement: gsm7Replacements[r]})
+		}
+	}
+	return chars
+}
+
+func septets(r rune) int {
+	if n := gsm7Septets[r]; n > 0 {
+		return n
+	}
+	return 1
+}
+
+func utf16Units(r rune) int {
+	if r > 0xFFFF && r <= utf8.MaxRune {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,113 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsGSM7(t *testing.T) {
+	var cases = []struct {
+		body string
+		e    bool
+	}{
+		{"Hello World", true},
+		{"Prijs: €5 {korting} @ café", true},
+		{"Ça coûte 5€", false},
+		{"It’s here", false},
+		{"Hi 👋", false},
+		{"", true},
+	}
+
+	for _, tt := range cases {
+		if got := IsGSM7(tt.body); got != tt.e {
+			t.Errorf("got %v, expected %v, test case: %s", got, tt.e, tt.body)
+		}
+	}
+}
+
+func TestCountSegments(t *testing.T) {
+	var cases = []struct {
+		name       string
+		body       string
+		dataCoding string
+		e          Segments
+	}{
+		{
+			name: "Empty",
+			body: "",
+			e:    Segments{DataCoding: DataCodingPlain, Remaining: 160},
+		},
+		{
+			name: "Single plain part",
+			body: strings.Repeat("a", 160),
+			e:    Segments{DataCoding: DataCodingPlain, Length: 160, Parts: 1},
+		},
+		{
+			name: "Concatenated plain",
+			body: strings.Repeat("a", 161),
+			e:    Segments{DataCoding: DataCodingPlain, Length: 161, Parts: 2, Remaining: 145},
+		},
+		{
+			name: "Extension characters take two septets",
+			body: strings.Repeat("€", 80),
+			e:    Segments{DataCoding: DataCodingPlain, Length: 160, Parts: 1},
+		},
+		{
+			name: "Extension character not split",
+			body: strings.Repeat("a", 152) + "€" + strings.Repeat("a", 10),
+			e:    Segments{DataCoding: DataCodingPlain, Length: 164, Parts: 2, Remaining: 141},
+		},
+		{
+			name: "Auto unicode",
+			body: "It’s " + strings.Repeat("a", 65),
+			e:    Segments{DataCoding: DataCodingUnicode, Length: 70, Parts: 1},
+		},
+		{
+			name: "Concatenated unicode",
+			body: "It’s " + strings.Repeat("a", 66),
+			e:    Segments{DataCoding: DataCodingUnicode, Length: 71, Parts: 2, Remaining: 63},
+		},
+		{
+			name: "Surrogate pair not split",
+			body: strings.Repeat("a", 66) + "👋" + "a",
+			e:    Segments{DataCoding: DataCodingUnicode, Length: 69, Parts: 1, Remaining: 1},
+		},
+		{
+			name: "Surrogate pair at part boundary",
+			body: strings.Repeat("a", 66) + "👋" + strings.Repeat("a", 3),
+			e:    Segments{DataCoding: DataCodingUnicode, Length: 71, Parts: 2, Remaining: 62},
+		},
+		{
+			name:       "Forced plain",
+			body:       "It’s here",
+			dataCoding: DataCodingPlain,
+			e:          Segments{DataCoding: DataCodingPlain, Length: 9, Parts: 1, Remaining: 151},
+		},
+		{
+			name:       "Forced unicode",
+			body:       "Hello",
+			dataCoding: DataCodingUnicode,
+			e:          Segments{DataCoding: DataCodingUnicode, Length: 5, Parts: 1, Remaining: 65},
+		},
+	}
+
+	for _, tt := range cases {
+		if got := CountSegments(tt.body, tt.dataCoding); got != tt.e {
+			t.Errorf("got %+v, expected %+v, test case: %s", got, tt.e, tt.name)
+		}
+	}
+}
+
+func TestUnicodeCharacters(t *testing.T) {
+	chars := UnicodeCharacters("It’s 5€ 👋")
+
+	if len(chars) != 2 {
+		t.Fatalf("got %d characters, expected 2", len(chars))
+	}
+	if chars[0].Char != '’' || chars[0].Offset != 2 || chars[0].Replacement != "'" {
+		t.Errorf("got %+v, expected the quote at offset 2 with replacement '", chars[0])
+	}
+	if chars[1].Char != '👋' || chars[1].Replacement != "" {
+		t.Errorf("got %+v, expected the emoji without replacement", chars[1])
+	}
+}
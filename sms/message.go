@@ -1,7 +1,7 @@
 package sms
 
 import (
-	"errors"
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -14,6 +14,44 @@ import (
 // Is only used when a binary or premium message is sent.
 type TypeDetails map[string]interface{}
 
+// The types of messages.
+const (
+	TypeSMS     = "sms"
+	TypeBinary  = "binary"
+	TypeFlash   = "flash"
+	TypePremium = "premium"
+)
+
+// The encodings of message bodies. DataCodingAuto picks unicode only if the
+// body contains characters outside the GSM 03.38 character set.
+const (
+	DataCodingPlain   = "plain"
+	DataCodingUnicode = "unicode"
+	DataCodingAuto    = "auto"
+)
+
+// BinaryDetails are the type details of a binary message.
+type BinaryDetails struct {
+	UDH string // The hex encoded user data header.
+}
+
+// TypeDetails returns d as the TypeDetails of Params.
+func (d BinaryDetails) TypeDetails() TypeDetails {
+	return TypeDetails{"udh": d.UDH}
+}
+
+// PremiumDetails are the type details of a premium message.
+type PremiumDetails struct {
+	Tariff    int // The tariff in cents.
+	Shortcode int
+	Keyword   string
+}
+
+// TypeDetails returns d as the TypeDetails of Params.
+func (d PremiumDetails) TypeDetails() TypeDetails {
+	return TypeDetails{"tariff": d.Tariff, "shortcode": d.Shortcode, "keyword": d.Keyword}
+}
+
 // Message struct represents a message at MessageBird.com
 type Message struct {
 	ID                string
@@ -34,6 +72,23 @@ type Message struct {
 	Recipients        messagebird.Recipients
 }
 
+// TotalParts returns the number of message parts sent across all recipients,
+// which is what sending the message is billed by. The encoding the parts were
+// sent with is available in DataCoding.
+func (m *Message) TotalParts() int {
+	total := 0
+	for _, r := range m.Recipients.Items {
+		total += r.MessagePartCount
+	}
+	return total
+}
+
+// FailedRecipients returns the recipients m could not be delivered to. Their
+// StatusReason and StatusErrorCode tell why.
+func (m *Message) FailedRecipients() []messagebird.Recipient {
+	return m.Recipients.Failed()
+}
+
 // MessageList represents a list of Messages.
 type MessageList struct {
 	Offset     int
@@ -45,6 +100,10 @@ type MessageList struct {
 }
 
 // Params provide additional message send options and used in URL as params.
+//
+// ScheduledDatetime is sent with its UTC offset, so it is interpreted in the
+// time zone of its location. Validity is the number of seconds the message
+// can be delivered in.
 type Params struct {
 	Type              string
 	Reference         string
@@ -59,12 +118,39 @@ type Params struct {
 // ListParams provides additional message list options.
 type ListParams struct {
 	Originator string
-	Direction  string
+	Recipient  string
+	Direction  string // DirectionSent or DirectionReceived.
 	Type       string
-	Limit      int
-	Offset     int
+	Status     string // A status, e.g. StatusScheduled or StatusDelivered.
+	ContactID  string
+
+	// From and Until limit the list to messages created in that period.
+	From  time.Time
+	Until time.Time
+
+	Limit  int
+	Offset int
 }
 
+// StatusScheduled is the status of messages scheduled to be sent later.
+const StatusScheduled = "scheduled"
+
+// The statuses of a message to a recipient. Delivered, delivery failed and
+// expired are final.
+const (
+	StatusSent           = "sent"
+	StatusBuffered       = "buffered"
+	StatusDelivered      = "delivered"
+	StatusDeliveryFailed = "delivery_failed"
+	StatusExpired        = "expired"
+)
+
+// The directions of messages, used to filter message lists.
+const (
+	DirectionSent     = "mt"
+	DirectionReceived = "mo"
+)
+
 type messageRequest struct {
 	Originator        string      `json:"originator"`
 	Body              string      `json:"body"`
@@ -93,7 +179,8 @@ func Read(c *messagebird.Client, id string) (*Message, error) {
 	return message, nil
 }
 
-// Cancel sending Scheduled Sms
+// Delete deletes an existing Message, which cancels sending it if it is
+// scheduled.
 func Delete(c *messagebird.Client, id string) (*Message, error) {
 	message := &Message{}
 	if err := c.Request(message, http.MethodDelete, path+"/"+id, nil); err != nil {
@@ -118,6 +205,65 @@ func List(c *messagebird.Client, msgListParams *ListParams) (*MessageList, error
 	return messageList, nil
 }
 
+// ListScheduled retrieves the messages that are scheduled but not sent yet.
+// The Status of params is ignored. Use Delete to cancel them.
+func ListScheduled(c *messagebird.Client, params *ListParams) (*MessageList, error) {
+	var p ListParams
+	if params != nil {
+		p = *params
+	}
+	p.Status = StatusScheduled
+	return List(c, &p)
+}
+
+// ListReceived retrieves the mobile originated messages received on your
+// virtual numbers, e.g. filtered by Recipient and From. The Direction of
+// params is ignored. Use Read to fetch a single received message.
+func ListReceived(c *messagebird.Client, params *ListParams) (*MessageList, error) {
+	var p ListParams
+	if params != nil {
+		p = *params
+	}
+	p.Direction = DirectionReceived
+	return List(c, &p)
+}
+
+// Iterate returns an iterator over all messages matching msgListParams,
+// which may be nil to iterate over all messages.
+func Iterate(c *messagebird.Client, msgListParams *ListParams) *messagebird.Iterator[Message] {
+	var params ListParams
+	if msgListParams != nil {
+		params = *msgListParams
+	}
+	return messagebird.NewOffsetIterator(params.Offset, func(ctx context.Context, offset int) ([]Message, int, error) {
+		params.Offset = offset
+		list, err := List(c.WithContext(ctx), &params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
+// IterateConcurrent returns an iterator over all messages matching
+// msgListParams like Iterate, which fetches up to concurrency pages at the
+// same time. Use it to export large numbers of messages.
+func IterateConcurrent(c *messagebird.Client, msgListParams *ListParams, concurrency int) *messagebird.Iterator[Message] {
+	var params ListParams
+	if msgListParams != nil {
+		params = *msgListParams
+	}
+	return messagebird.NewConcurrentOffsetIterator(params.Offset, concurrency, func(ctx context.Context, offset int) ([]Message, int, error) {
+		p := params
+		p.Offset = offset
+		list, err := List(c.WithContext(ctx), &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		return list.Items, list.TotalCount, nil
+	})
+}
+
 // Create creates a new message for one or more recipients.
 func Create(c *messagebird.Client, originator string, recipients []string, body string, msgParams *Params) (*Message, error) {
 	requestData, err := requestDataForMessage(originator, recipients, body, msgParams)
@@ -134,14 +280,8 @@ func Create(c *messagebird.Client, originator string, recipients []string, body
 }
 
 func requestDataForMessage(originator string, recipients []string, body string, params *Params) (*messageRequest, error) {
-	if originator == "" {
-		return nil, errors.New("originator is required")
-	}
-	if len(recipients) == 0 {
-		return nil, errors.New("at least 1 recipient is required")
-	}
-	if body == "" {
-		return nil, errors.New("body is required")
+	if err := validateMessage(originator, recipients, body, params); err != nil {
+		return nil, err
 	}
 
 	request := &messageRequest{
@@ -155,7 +295,7 @@ func requestDataForMessage(originator string, recipients []string, body string,
 	}
 
 	request.Type = params.Type
-	if request.Type == "flash" {
+	if request.Type == TypeFlash {
 		request.MClass = 0
 	} else {
 		request.MClass = 1
@@ -190,6 +330,24 @@ func paramsForMessageList(params *ListParams) (*url.Values, error) {
 	if params.Originator != "" {
 		urlParams.Set("originator", params.Originator)
 	}
+	if params.Recipient != "" {
+		urlParams.Set("recipient", params.Recipient)
+	}
+	if params.Type != "" {
+		urlParams.Set("type", params.Type)
+	}
+	if params.Status != "" {
+		urlParams.Set("status", params.Status)
+	}
+	if params.ContactID != "" {
+		urlParams.Set("contact_id", params.ContactID)
+	}
+	if !params.From.IsZero() {
+		urlParams.Set("from", params.From.Format(time.RFC3339))
+	}
+	if !params.Until.IsZero() {
+		urlParams.Set("until", params.Until.Format(time.RFC3339))
+	}
 	if params.Limit != 0 {
 		urlParams.Set("limit", strconv.Itoa(params.Limit))
 	}
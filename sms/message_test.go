@@ -91,6 +91,31 @@ func assertMessageObject(t *testing.T, message *Message) {
 	}
 }
 
+func TestFailedRecipients(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageObjectWithFailedRecipients.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	message, err := Read(client, "6fe65f90454aa61536e6a88b88972670")
+	if err != nil {
+		t.Fatalf("Didn't expect error while reading message: %s", err)
+	}
+
+	if message.Recipients.Items[0].StatusReason != "successfully delivered" || message.Recipients.Items[0].StatusErrorCode != 0 {
+		t.Errorf("Unexpected status of delivered recipient: %+v", message.Recipients.Items[0])
+	}
+
+	failed := message.FailedRecipients()
+	if len(failed) != 2 {
+		t.Fatalf("Unexpected number of failed recipients: %d, expected: 2", len(failed))
+	}
+	if failed[0].Recipient != 31600000000 || failed[0].StatusReason != "unknown subscriber" || failed[0].StatusErrorCode != 1 {
+		t.Errorf("Unexpected failed recipient: %+v", failed[0])
+	}
+	if failed[1].Recipient != 31687654321 || failed[1].Status != StatusExpired || failed[1].StatusErrorCode != 27 {
+		t.Errorf("Unexpected failed recipient: %+v", failed[1])
+	}
+}
+
 func TestCreate(t *testing.T) {
 	mbtest.WillReturnTestdata(t, "messageObject.json", http.StatusOK)
 	client := mbtest.Client(t)
@@ -243,6 +268,28 @@ func TestCreateWithFlashType(t *testing.T) {
 	}
 }
 
+func TestCreateMultipartUnicode(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "multipartUnicodeMessageObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	message, err := Create(client, "TestName", []string{"31612345678", "31687654321"}, "Привет!", &Params{DataCoding: "auto"})
+	if err != nil {
+		t.Fatalf("Didn't expect error while creating a new message: %s", err)
+	}
+
+	if message.DataCoding != "unicode" {
+		t.Errorf("Unexpected message datacoding: %s, expected: unicode", message.DataCoding)
+	}
+
+	if message.Recipients.Items[0].MessagePartCount != 3 {
+		t.Errorf("Unexpected message part count: %d, expected: 3", message.Recipients.Items[0].MessagePartCount)
+	}
+
+	if message.TotalParts() != 6 {
+		t.Errorf("Unexpected total message parts: %d, expected: 6", message.TotalParts())
+	}
+}
+
 func TestCreateWithScheduledDatetime(t *testing.T) {
 	mbtest.WillReturnTestdata(t, "messageObjectWithCreatedDatetime.json", http.StatusOK)
 	client := mbtest.Client(t)
@@ -357,3 +404,72 @@ func TestRequestDataForMessage(t *testing.T) {
 	}
 
 }
+
+func TestParamsForMessageList(t *testing.T) {
+	from := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	params, err := paramsForMessageList(&ListParams{
+		Originator: "TestName",
+		Recipient:  "31612345678",
+		Direction:  DirectionSent,
+		Type:       "sms",
+		Status:     "delivered",
+		ContactID:  "contact-id",
+		From:       from,
+		Until:      from.Add(24 * time.Hour),
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "contact_id=contact-id&direction=mt&from=2020-01-02T03%3A04%3A05%2B01%3A00&limit=10&offset=0&originator=TestName&recipient=31612345678&status=delivered&type=sms&until=2020-01-03T03%3A04%3A05%2B01%3A00"
+	if q := params.Encode(); q != expected {
+		t.Errorf("got query %s, expected %s", q, expected)
+	}
+}
+
+func TestListScheduled(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := ListScheduled(client, &ListParams{Status: "delivered", Limit: 5}); err != nil {
+		t.Fatalf("Didn't expect an error while requesting scheduled Messages: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/messages")
+	if q := mbtest.Request.URL.Query(); q.Get("status") != StatusScheduled || q.Get("limit") != "5" {
+		t.Errorf("Unexpected query: %s, expected status=scheduled and limit=5", mbtest.Request.URL.RawQuery)
+	}
+}
+
+func TestRequestDataForBinaryMessage(t *testing.T) {
+	request, err := requestDataForMessage("TestName", []string{"31612345678"}, "0B05040B8423F0", &Params{
+		Type:        TypeBinary,
+		DataCoding:  DataCodingPlain,
+		TypeDetails: BinaryDetails{UDH: "050003340201"}.TypeDetails(),
+	})
+	if err != nil {
+		t.Fatalf("Didn't expect error while getting request data: %s", err)
+	}
+
+	if request.Type != "binary" || request.DataCoding != "plain" || request.MClass != 1 {
+		t.Errorf("Unexpected type %s, datacoding %s or mclass %d", request.Type, request.DataCoding, request.MClass)
+	}
+	if request.TypeDetails["udh"] != "050003340201" {
+		t.Errorf("Unexpected 'udh' value in request typedetails: %v, expected: 050003340201", request.TypeDetails["udh"])
+	}
+}
+
+func TestListReceived(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "messageListObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	if _, err := ListReceived(client, &ListParams{Direction: DirectionSent, Recipient: "31612345678"}); err != nil {
+		t.Fatalf("Didn't expect an error while requesting received Messages: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/messages")
+	if q := mbtest.Request.URL.Query(); q.Get("direction") != DirectionReceived || q.Get("recipient") != "31612345678" {
+		t.Errorf("Unexpected query: %s, expected direction=mo and the recipient", mbtest.Request.URL.RawQuery)
+	}
+}
@@ -0,0 +1,127 @@
+package sms
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync"
+)
+
+// ErrNoHealthySender is returned by SenderPool when all of its senders are
+// marked unhealthy.
+var ErrNoHealthySender = errors.New("sms: no healthy sender")
+
+// SenderPool picks the originator of messages from a set of owned numbers
+// or sender IDs. Each recipient is consistently sent from the same
+// originator, which helps deliverability and lets recipients reply to a
+// number they know:
+//
+//	pool := sms.NewSenderPool("31970102030", "31970102031", "31970102032")
+//	originator, err := pool.Sender(recipient)
+//
+// Originators can be marked unhealthy, e.g. when they are blocked by an
+// operator. Only the recipients of an unhealthy originator move to another
+// one, and they return once it is marked healthy again. Recipients must be
+// written the same way each time, e.g. as normalized by the phonenumber
+// package. A SenderPool is safe for concurrent use.
+type SenderPool struct {
+	senders []string
+
+	mu        sync.RWMutex
+	unhealthy map[string]bool
+}
+
+// NewSenderPool returns a pool of senders. Duplicate senders are ignored.
+func NewSenderPool(senders ...string) *SenderPool {
+	p := &SenderPool{unhealthy: make(map[string]bool)}
+	seen := make(map[string]bool)
+	for _, s := range senders {
+		if !seen[s] {
+			seen[s] = true
+			p.senders = append(p.senders, s)
+		}
+	}
+	return p
+}
+
+// Sender returns the originator to send messages to recipient from.
+func (p *SenderPool) Sender(recipient string) (string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// Rendezvous hashing: the healthy sender with the highest score for the
+	// recipient wins, so removing a sender only affects its own recipients.
+	var best string
+	var bestScore uint64
+	found := false
+	for _, s := range p.senders {
+		if p.unhealthy[s] {
+			continue
+		}
+		if score := rendezvousScore(s, recipient); !found || score > bestScore {
+			best, bestScore, found = s, score, true
+		}
+	}
+	if !found {
+		return "", ErrNoHealthySender
+	}
+	return best, nil
+}
+
+// Group returns recipients grouped by the originator Sender returns for
+// them, to create one message per originator. The recipients keep their
+// order within each group.
+func (p *SenderPool) Group(recipients []string) (map[string][]string, error) {
+	groups := make(map[string][]string)
+	for _, r := range recipients {
+		s, err := p.Sender(r)
+		if err != nil {
+			return nil, err
+		}
+		groups[s] = append(groups[s], r)
+	}
+	return groups, nil
+}
+
+// MarkUnhealthy stops sending from sender until MarkHealthy is called.
+func (p *SenderPool) MarkUnhealthy(sender string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.unhealthy[sender] = true
+}
+
+// MarkHealthy resumes sending from sender.
+func (p *SenderPool) MarkHealthy(sender string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.unhealthy, sender)
+}
+
+// Healthy returns the senders that are not marked unhealthy.
+func (p *SenderPool) Healthy() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var healthy []string
+	for _, s := range p.senders {
+		if !p.unhealthy[s] {
+			healthy = append(healthy, s)
+		}
+	}
+	return healthy
+}
+
+func rendezvousScore(sender, recipient string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(sender))
+	h.Write([]byte{0})
+	h.Write([]byte(recipient))
+
+	// FNV spreads similar inputs poorly, so mix the bits of the sum.
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
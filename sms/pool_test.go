@@ -0,0 +1,84 @@
+package sms
+
+import (
+	"fmt"
+	"testing"
+)
+
+func poolRecipients(n int) []string {
+	recipients := make([]string, n)
+	for i := range recipients {
+		recipients[i] = fmt.Sprintf("316%08d", i)
+	}
+	return recipients
+}
+
+func TestSenderPoolSticky(t *testing.T) {
+	pool := NewSenderPool("31970102030", "31970102031", "31970102032")
+	recipients := poolRecipients(300)
+
+	groups, err := pool.Group(recipients)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, s := range pool.Healthy() {
+		if n := len(groups[s]); n < 50 {
+			t.Errorf("got %d recipients for %s, expected them to be spread", n, s)
+		}
+	}
+
+	again := NewSenderPool("31970102032", "31970102030", "31970102031")
+	for _, r := range recipients {
+		s1, _ := pool.Sender(r)
+		s2, _ := again.Sender(r)
+		if s1 != s2 {
+			t.Fatalf("got senders %s and %s for %s, expected the same regardless of order", s1, s2, r)
+		}
+	}
+}
+
+func TestSenderPoolUnhealthy(t *testing.T) {
+	pool := NewSenderPool("31970102030", "31970102031", "31970102032")
+	recipients := poolRecipients(300)
+
+	before := make(map[string]string)
+	for _, r := range recipients {
+		before[r], _ = pool.Sender(r)
+	}
+
+	pool.MarkUnhealthy("31970102031")
+	for _, r := range recipients {
+		s, err := pool.Sender(r)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if s == "31970102031" {
+			t.Fatalf("got unhealthy sender for %s", r)
+		}
+		if before[r] != "31970102031" && s != before[r] {
+			t.Errorf("got sender %s for %s, expected it to stay %s", s, r, before[r])
+		}
+	}
+
+	pool.MarkHealthy("31970102031")
+	for _, r := range recipients {
+		if s, _ := pool.Sender(r); s != before[r] {
+			t.Errorf("got sender %s for %s after recovery, expected %s", s, r, before[r])
+		}
+	}
+}
+
+func TestSenderPoolNoHealthySender(t *testing.T) {
+	pool := NewSenderPool("TestName")
+	pool.MarkUnhealthy("TestName")
+
+	if _, err := pool.Sender("31612345678"); err != ErrNoHealthySender {
+		t.Errorf("got %v, expected %v", err, ErrNoHealthySender)
+	}
+	if _, err := pool.Group([]string{"31612345678"}); err != ErrNoHealthySender {
+		t.Errorf("got %v, expected %v", err, ErrNoHealthySender)
+	}
+	if _, err := NewSenderPool().Sender("31612345678"); err != ErrNoHealthySender {
+		t.Errorf("got %v for an empty pool, expected %v", err, ErrNoHealthySender)
+	}
+}
@@ -0,0 +1,149 @@
+package sms
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PrefixRateLimiter limits the rate of recipients per second of messages
+// sent to each MSISDN prefix independently, e.g. for countries or carriers
+// that throttle below the account limit. It is applied by senders of
+// messages to many recipients, which group recipients with Prefix and call
+// Wait before sending to a group. It is safe for concurrent use.
+type PrefixRateLimiter struct {
+	rates map[string]int
+
+	mu      sync.Mutex
+	buckets map[string]*prefixBucket
+	now     func() time.Time
+}
+
+// prefixBucket is the token bucket of a prefix.
+type prefixBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// WithPerPrefixRate returns a PrefixRateLimiter that limits the recipients
+// per second of each MSISDN prefix, e.g. country calling codes:
+//
+//	limiter := sms.WithPerPrefixRate(map[string]int{
+//	    "91":  20, // India
+//	    "234": 5,  // Nigeria
+//	})
+//
+// Recipients are matched to the longest prefix, so "2348" takes precedence
+// over "234". Prefixes and recipients may start with "+" or "00", which is
+// ignored when matching; if several prefixes are the same without it, the
+// lowest rate applies. Each limit allows bursts of one second's worth of
+// recipients. Prefixes with a rate below 1 are ignored.
+func WithPerPrefixRate(rates map[string]int) *PrefixRateLimiter {
+	l := &PrefixRateLimiter{rates: make(map[string]int, len(rates))}
+	for prefix, rate := range rates {
+		prefix = normalizeMSISDN(prefix)
+		if prefix == "" || rate < 1 {
+			continue
+		}
+		if r, ok := l.rates[prefix]; !ok || rate < r {
+			l.rates[prefix] = rate
+		}
+	}
+	return l
+}
+
+// Prefix returns the longest prefix of l that msisdn starts with, without
+// "+" or "00", or "" if there is none.
+func (l *PrefixRateLimiter) Prefix(msisdn string) string {
+	if l == nil {
+		return ""
+	}
+	msisdn = normalizeMSISDN(msisdn)
+	match := ""
+	for prefix := range l.rates {
+		if len(prefix) > len(match) && strings.HasPrefix(msisdn, prefix) {
+			match = prefix
+		}
+	}
+	return match
+}
+
+// Rate returns the recipients per second of prefix, as returned by Prefix,
+// or 0 if it is not limited.
+func (l *PrefixRateLimiter) Rate(prefix string) int {
+	if l == nil {
+		return 0
+	}
+	return l.rates[prefix]
+}
+
+// Wait blocks until n recipients matching prefix, as returned by Prefix, may
+// be sent to, or ctx is done.
+func (l *PrefixRateLimiter) Wait(ctx context.Context, prefix string, n int) error {
+	rate := l.Rate(prefix)
+	if rate == 0 || n <= 0 {
+		return nil
+	}
+	d := l.reserve(prefix, rate, n)
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.buckets[prefix].tokens += float64(n)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// reserve takes n tokens from the bucket of prefix and returns how long to
+// wait before they are available.
+func (l *PrefixRateLimiter) reserve(prefix string, rate, n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.now != nil {
+		now = l.now()
+	}
+	if l.buckets == nil {
+		l.buckets = make(map[string]*prefixBucket)
+	}
+	b, ok := l.buckets[prefix]
+	if !ok {
+		b = &prefixBucket{tokens: float64(rate), last: now}
+		l.buckets[prefix] = b
+	}
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * float64(rate)
+		if b.tokens > float64(rate) {
+			b.tokens = float64(rate)
+		}
+		b.last = now
+	}
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / float64(rate) * float64(time.Second))
+}
+
+// normalizeMSISDN strips "+", "00" and separators from an MSISDN or prefix.
+func normalizeMSISDN(s string) string {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "+")
+	s = strings.TrimPrefix(s, "00")
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
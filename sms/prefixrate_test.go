@@ -0,0 +1,65 @@
+package sms
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithPerPrefixRate(t *testing.T) {
+	l := WithPerPrefixRate(map[string]int{"+91": 20, "234": 5, "2348": 2, "+44": 10, "447": 3, "0044": 8})
+
+	var cases = []struct {
+		name   string
+		msisdn string
+		e      string
+	}{
+		{name: "Country", msisdn: "919876543210", e: "91"},
+		{name: "Longest match", msisdn: "2348012345678", e: "2348"},
+		{name: "Shorter match", msisdn: "2347012345678", e: "234"},
+		{name: "International format", msisdn: "+234 701 234 5678", e: "234"},
+		{name: "Dialing prefix", msisdn: "002347012345678", e: "234"},
+		{name: "Longest match of plus key", msisdn: "447700900123", e: "447"},
+		{name: "Shorter match of plus key", msisdn: "442071838750", e: "44"},
+		{name: "No match", msisdn: "31612345678", e: ""},
+	}
+
+	for _, tt := range cases {
+		if p := l.Prefix(tt.msisdn); p != tt.e {
+			t.Errorf("got prefix %q, expected %q, test case: %s", p, tt.e, tt.name)
+		}
+	}
+	if rate := l.Rate("91"); rate != 20 {
+		t.Errorf("got rate %d, expected 20 per second", rate)
+	}
+	if rate := l.Rate("44"); rate != 8 {
+		t.Errorf("got rate %d, expected the lowest rate of +44 and 0044", rate)
+	}
+}
+
+func TestPrefixRateLimiterWait(t *testing.T) {
+	now := time.Unix(1544544948, 0)
+	l := WithPerPrefixRate(map[string]int{"234": 5})
+	l.now = func() time.Time { return now }
+
+	if d := l.reserve("234", 5, 5); d != 0 {
+		t.Errorf("got delay %s for the burst, expected none", d)
+	}
+	if d := l.reserve("234", 5, 5); d != time.Second {
+		t.Errorf("got delay %s, expected 1s for 5 more recipients", d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "234", 1); err != context.DeadlineExceeded {
+		t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if err := l.Wait(ctx, "", 100); err != nil {
+		t.Errorf("unexpected error for recipients without prefix limit: %s", err)
+	}
+
+	var nilLimiter *PrefixRateLimiter
+	if p := nilLimiter.Prefix("2348012345678"); p != "" {
+		t.Errorf("got prefix %q from a nil limiter, expected none", p)
+	}
+}
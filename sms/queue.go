@@ -0,0 +1,158 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// ErrQueueClosed is returned when enqueueing a message on a closed Queue.
+var ErrQueueClosed = errors.New("sms: queue is closed")
+
+// QueueOptions configure a Queue.
+type QueueOptions struct {
+	// Workers is the number of messages sent at the same time. It defaults
+	// to 4.
+	Workers int
+
+	// Size is the number of messages that can wait to be sent before
+	// Enqueue blocks. It defaults to 100.
+	Size int
+
+	// OnResult is called with the result of each message, from the worker
+	// that sent it. When it is nil, results are delivered on the channel
+	// returned by Results instead.
+	OnResult func(*QueueResult)
+}
+
+// QueueResult is the result of sending a message with a Queue. Either
+// Created or Err is set.
+type QueueResult struct {
+	Message *BatchMessage // The message as enqueued.
+	Created *Message
+	Err     error
+}
+
+// Queue sends messages in the background with a pool of workers, for
+// callers that should not block on the API:
+//
+//	client.RateLimiter = &messagebird.RateLimiter{Limit: messagebird.Limit{Rate: 10}}
+//	client.RetryPolicy = &messagebird.RetryPolicy{MaxAttempts: 3, RetryRateLimited: true}
+//	client.AutoIdempotencyKeys = true
+//
+//	q := sms.NewQueue(client, &sms.QueueOptions{
+//	    OnResult: func(r *sms.QueueResult) {
+//	        if r.Err != nil {
+//	            log.Printf("could not notify %v: %v", r.Message.Recipients, r.Err)
+//	        }
+//	    },
+//	})
+//	defer q.Close()
+//
+//	err := q.Enqueue(ctx, &sms.BatchMessage{Originator: "TestSender", Recipients: recipients, Body: body})
+//
+// Messages are sent with Create, so they are rate limited and retried as
+// configured on the client. Creating a message is only retried with an
+// idempotency key, hence AutoIdempotencyKeys. The context of the client
+// applies to all sends.
+type Queue struct {
+	c        *messagebird.Client
+	onResult func(*QueueResult)
+
+	in      chan *BatchMessage
+	results chan *QueueResult
+	wg      sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewQueue starts a queue sending messages with c. Options may be nil. The
+// caller must call Close to stop the workers.
+func NewQueue(c *messagebird.Client, options *QueueOptions) *Queue {
+	var o QueueOptions
+	if options != nil {
+		o = *options
+	}
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+	if o.Size <= 0 {
+		o.Size = 100
+	}
+
+	q := &Queue{
+		c:        c,
+		onResult: o.OnResult,
+		in:       make(chan *BatchMessage, o.Size),
+	}
+	if q.onResult == nil {
+		q.results = make(chan *QueueResult, o.Size)
+		q.onResult = func(r *QueueResult) { q.results <- r }
+	}
+
+	q.wg.Add(o.Workers)
+	for i := 0; i < o.Workers; i++ {
+		go q.work()
+	}
+	return q
+}
+
+// Enqueue adds m to the queue. It blocks while the queue is full, until ctx
+// is done. The message is validated before it is enqueued.
+func (q *Queue) Enqueue(ctx context.Context, m *BatchMessage) error {
+	if m == nil {
+		return errors.New("message is required")
+	}
+	if _, err := requestDataForMessage(m.Originator, m.Recipients, m.Body, m.Params); err != nil {
+		return err
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.in <- m:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Results returns the channel the results are delivered on when
+// QueueOptions.OnResult is nil, or nil otherwise. The channel must be
+// drained, as workers wait for it when it is full. It is closed by Close.
+func (q *Queue) Results() <-chan *QueueResult {
+	return q.results
+}
+
+// Close stops accepting messages and waits until the enqueued messages are
+// sent.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.in)
+	q.mu.Unlock()
+
+	q.wg.Wait()
+	if q.results != nil {
+		close(q.results)
+	}
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	for m := range q.in {
+		created, err := Create(q.c, m.Originator, m.Recipients, m.Body, m.Params)
+		q.onResult(&QueueResult{Message: m, Created: created, Err: err})
+	}
+}
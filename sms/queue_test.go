@@ -0,0 +1,130 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+func queueServer(t *testing.T) (*messagebird.Client, func()) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req messageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unexpected error decoding request: %s", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.Body == "fail" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"errors":[{"code":9,"description":"no (correct) recipients found","parameter":"recipients"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":"msg-%s","body":%q}`, req.Recipients[0], req.Body)
+	}))
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	return c, ts.Close
+}
+
+func TestQueueResultsChannel(t *testing.T) {
+	c, stop := queueServer(t)
+	defer stop()
+
+	q := NewQueue(c, &QueueOptions{Workers: 2, Size: 10})
+	for i, body := range []string{"Hello", "fail", "World"} {
+		m := &BatchMessage{Originator: "TestName", Recipients: []string{fmt.Sprintf("3161234567%d", i)}, Body: body}
+		if err := q.Enqueue(context.Background(), m); err != nil {
+			t.Fatalf("unexpected error enqueueing: %s", err)
+		}
+	}
+	go q.Close()
+
+	var created []string
+	var failed int
+	for r := range q.Results() {
+		if r.Err != nil {
+			failed++
+			if r.Message.Body != "fail" {
+				t.Errorf("got error for %s, expected only the failing message to fail", r.Message.Body)
+			}
+			continue
+		}
+		created = append(created, r.Created.ID)
+	}
+
+	sort.Strings(created)
+	if failed != 1 || len(created) != 2 || created[0] != "msg-31612345670" || created[1] != "msg-31612345672" {
+		t.Errorf("got created %v and %d failed, expected two created and one failed", created, failed)
+	}
+}
+
+func TestQueueOnResult(t *testing.T) {
+	c, stop := queueServer(t)
+	defer stop()
+
+	var mu sync.Mutex
+	var results []*QueueResult
+	q := NewQueue(c, &QueueOptions{OnResult: func(r *QueueResult) {
+		mu.Lock()
+		results = append(results, r)
+		mu.Unlock()
+	}})
+	if q.Results() != nil {
+		t.Errorf("expected no results channel with OnResult")
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := q.Enqueue(context.Background(), &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: "Hello"}); err != nil {
+			t.Fatalf("unexpected error enqueueing: %s", err)
+		}
+	}
+	q.Close()
+
+	if len(results) != 20 {
+		t.Errorf("got %d results, expected 20", len(results))
+	}
+	if err := q.Enqueue(context.Background(), &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: "Hello"}); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("got %v, expected %v", err, ErrQueueClosed)
+	}
+	q.Close()
+}
+
+func TestQueueEnqueueErrors(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"6fe65f90454aa61536e6a88b88972670"}`)
+	}))
+	defer ts.Close()
+
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	q := NewQueue(c, &QueueOptions{Workers: 1, Size: 1, OnResult: func(*QueueResult) {}})
+	defer q.Close()
+	defer close(release)
+
+	if err := q.Enqueue(context.Background(), &BatchMessage{Originator: "TestName", Body: "Hello"}); err == nil {
+		t.Errorf("expected error for a message without recipients, got nil")
+	}
+
+	m := &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: "Hello"}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// The first message is sent by the worker and the second one fills the
+	// queue, so the third one can't be enqueued.
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = q.Enqueue(ctx, m)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, expected %v", err, context.Canceled)
+	}
+}
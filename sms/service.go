@@ -0,0 +1,37 @@
+package sms
+
+import messagebird "github.com/messagebird/go-rest-api"
+
+// Service is the messages API. It is implemented by NewService, and by
+// fake.Messages for unit tests of code using the API.
+type Service interface {
+	Read(id string) (*Message, error)
+	Delete(id string) (*Message, error)
+	List(msgListParams *ListParams) (*MessageList, error)
+	Create(originator string, recipients []string, body string, msgParams *Params) (*Message, error)
+}
+
+// NewService returns a Service sending its requests with c.
+func NewService(c *messagebird.Client) Service {
+	return service{c: c}
+}
+
+type service struct {
+	c *messagebird.Client
+}
+
+func (s service) Read(id string) (*Message, error) {
+	return Read(s.c, id)
+}
+
+func (s service) Delete(id string) (*Message, error) {
+	return Delete(s.c, id)
+}
+
+func (s service) List(msgListParams *ListParams) (*MessageList, error) {
+	return List(s.c, msgListParams)
+}
+
+func (s service) Create(originator string, recipients []string, body string, msgParams *Params) (*Message, error) {
+	return Create(s.c, originator, recipients, body, msgParams)
+}
@@ -0,0 +1,339 @@
+package sms
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StoredMessage is a message waiting in a DurableQueue.
+type StoredMessage struct {
+	// ID identifies the message in the store. It is also used to derive the
+	// idempotency key of the message, so it is created once even if it is
+	// sent again after a crash.
+	ID         string
+	Message    *BatchMessage
+	EnqueuedAt time.Time
+}
+
+// QueueStore persists the messages of a DurableQueue until they are sent.
+// Stores must be safe for concurrent use.
+type QueueStore interface {
+	Put(ctx context.Context, m *StoredMessage) error
+
+	// List returns all stored messages, ordered by EnqueuedAt. Entries that
+	// can not be read are skipped and reported with a *CorruptMessagesError,
+	// which is returned together with the readable messages, so one corrupt
+	// entry does not stop the queue.
+	List(ctx context.Context) ([]*StoredMessage, error)
+
+	// Delete removes the message with id. Deleting a message that is not
+	// stored is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// CorruptMessagesError reports the entries of a QueueStore that could not be
+// read. They are not sent.
+type CorruptMessagesError struct {
+	// Errors holds the reason each entry could not be read, by its ID.
+	Errors map[string]error
+}
+
+func (e *CorruptMessagesError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for i, id := range ids {
+		ids[i] = fmt.Sprintf("%s: %v", id, e.Errors[id])
+	}
+	return fmt.Sprintf("sms: %d stored messages can not be read: %s", len(ids), strings.Join(ids, "; "))
+}
+
+// add records that the entry id could not be read, and returns e, which is
+// allocated if nil.
+func (e *CorruptMessagesError) add(id string, err error) *CorruptMessagesError {
+	if e == nil {
+		e = &CorruptMessagesError{Errors: make(map[string]error)}
+	}
+	e.Errors[id] = err
+	return e
+}
+
+// listResult returns the messages of List, sorted, and corrupt if entries
+// were skipped.
+func listResult(messages []*StoredMessage, corrupt *CorruptMessagesError) ([]*StoredMessage, error) {
+	sortStored(messages)
+	if corrupt != nil {
+		return messages, corrupt
+	}
+	return messages, nil
+}
+
+// sortStored orders messages by EnqueuedAt, and by ID for equal times.
+func sortStored(messages []*StoredMessage) {
+	sort.Slice(messages, func(i, j int) bool {
+		a, b := messages[i], messages[j]
+		if !a.EnqueuedAt.Equal(b.EnqueuedAt) {
+			return a.EnqueuedAt.Before(b.EnqueuedAt)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// FileStore is a QueueStore keeping each message in a JSON file in a
+// directory. Files that can not be decoded are moved to its "corrupt"
+// subdirectory, so they are reported once and kept for inspection.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a store keeping messages in dir, which is created if
+// it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Put implements QueueStore. The file is written atomically, so a crash does
+// not leave a partial message behind.
+func (s *FileStore) Put(_ context.Context, m *StoredMessage) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(s.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(m.ID))
+}
+
+// List implements QueueStore.
+func (s *FileStore) List(_ context.Context) ([]*StoredMessage, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*StoredMessage
+	var corrupt *CorruptMessagesError
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		id := strings.TrimSuffix(f.Name(), ".json")
+		b, err := ioutil.ReadFile(filepath.Join(s.dir, f.Name()))
+		if err != nil {
+			corrupt = corrupt.add(id, err)
+			continue
+		}
+		m := &StoredMessage{}
+		if err := json.Unmarshal(b, m); err != nil {
+			corrupt = corrupt.add(id, s.quarantine(f.Name(), err))
+			continue
+		}
+		messages = append(messages, m)
+	}
+	return listResult(messages, corrupt)
+}
+
+// quarantine moves the file name to the corrupt subdirectory, and returns
+// err, the reason it could not be decoded.
+func (s *FileStore) quarantine(name string, err error) error {
+	dir := filepath.Join(s.dir, "corrupt")
+	if mkErr := os.MkdirAll(dir, 0o700); mkErr != nil {
+		return err
+	}
+	if mvErr := os.Rename(filepath.Join(s.dir, name), filepath.Join(dir, name)); mvErr != nil {
+		return err
+	}
+	return fmt.Errorf("%v, moved to %s", err, dir)
+}
+
+// Delete implements QueueStore.
+func (s *FileStore) Delete(_ context.Context, id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// RedisHashClient is the subset of Redis commands used by the RedisStore.
+// This package does not depend on a Redis client; wrap the one you use, e.g.
+// for github.com/redis/go-redis:
+//
+//	type redisClient struct{ *redis.Client }
+//
+//	func (c redisClient) HSet(ctx context.Context, key, field, value string) error {
+//		return c.Client.HSet(ctx, key, field, value).Err()
+//	}
+//
+//	func (c redisClient) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+//		return c.Client.HGetAll(ctx, key).Result()
+//	}
+//
+//	func (c redisClient) HDel(ctx context.Context, key, field string) error {
+//		return c.Client.HDel(ctx, key, field).Err()
+//	}
+type RedisHashClient interface {
+	HSet(ctx context.Context, key, field, value string) error
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	HDel(ctx context.Context, key, field string) error
+}
+
+// RedisStore is a QueueStore keeping messages in a Redis hash.
+type RedisStore struct {
+	client RedisHashClient
+	key    string
+}
+
+// NewRedisStore returns a store keeping messages in the hash key.
+func NewRedisStore(client RedisHashClient, key string) *RedisStore {
+	return &RedisStore{client: client, key: key}
+}
+
+// Put implements QueueStore.
+func (s *RedisStore) Put(ctx context.Context, m *StoredMessage) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.key, m.ID, string(b))
+}
+
+// List implements QueueStore.
+func (s *RedisStore) List(ctx context.Context) ([]*StoredMessage, error) {
+	values, err := s.client.HGetAll(ctx, s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*StoredMessage, 0, len(values))
+	var corrupt *CorruptMessagesError
+	for id, v := range values {
+		m := &StoredMessage{}
+		if err := json.Unmarshal([]byte(v), m); err != nil {
+			corrupt = corrupt.add(id, s.quarantine(ctx, id, v, err))
+			continue
+		}
+		messages = append(messages, m)
+	}
+	return listResult(messages, corrupt)
+}
+
+// quarantine moves the field id with value v to the hash of the store's key
+// with the suffix ":corrupt", and returns err, the reason it could not be
+// decoded.
+func (s *RedisStore) quarantine(ctx context.Context, id, v string, err error) error {
+	if hErr := s.client.HSet(ctx, s.key+":corrupt", id, v); hErr != nil {
+		return err
+	}
+	if hErr := s.client.HDel(ctx, s.key, id); hErr != nil {
+		return err
+	}
+	return fmt.Errorf("%v, moved to %s:corrupt", err, s.key)
+}
+
+// Delete implements QueueStore.
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	return s.client.HDel(ctx, s.key, id)
+}
+
+// SQLStore is a QueueStore keeping messages in a database table, e.g. with
+// SQLite. The table must exist, with this schema:
+//
+//	CREATE TABLE sms_queue (
+//	    id          TEXT PRIMARY KEY,
+//	    enqueued_at BIGINT NOT NULL,
+//	    message     TEXT NOT NULL
+//	);
+//
+// Queries use ? placeholders, as SQLite and MySQL do. Rows that can not be
+// decoded are left in the table, so they are reported by every List until
+// they are fixed or deleted.
+type SQLStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSQLStore returns a store keeping messages in table of db.
+func NewSQLStore(db *sql.DB, table string) *SQLStore {
+	return &SQLStore{db: db, table: table}
+}
+
+// Put implements QueueStore.
+func (s *SQLStore) Put(ctx context.Context, m *StoredMessage) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, "INSERT INTO "+s.table+" (id, enqueued_at, message) VALUES (?, ?, ?)",
+		m.ID, m.EnqueuedAt.UnixNano(), string(b))
+	return err
+}
+
+// List implements QueueStore.
+func (s *SQLStore) List(ctx context.Context) ([]*StoredMessage, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, message FROM "+s.table+" ORDER BY enqueued_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*StoredMessage
+	var corrupt *CorruptMessagesError
+	for rows.Next() {
+		var id, v string
+		if err := rows.Scan(&id, &v); err != nil {
+			return nil, err
+		}
+		m := &StoredMessage{}
+		if err := json.Unmarshal([]byte(v), m); err != nil {
+			corrupt = corrupt.add(id, err)
+			continue
+		}
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if corrupt != nil {
+		return messages, corrupt
+	}
+	return messages, nil
+}
+
+// Delete implements QueueStore.
+func (s *SQLStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM "+s.table+" WHERE id = ?", id)
+	return err
+}
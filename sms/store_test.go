@@ -0,0 +1,240 @@
+package sms
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func init() {
+	sql.Register("smsqueuetest", fakeSQLDriver{})
+}
+
+// fakeSQLTables holds the rows of the fake database/sql driver by DSN, so
+// each test uses its own table.
+var fakeSQLTables = struct {
+	sync.Mutex
+	rows map[string]map[string]fakeSQLRow
+}{rows: make(map[string]map[string]fakeSQLRow)}
+
+type fakeSQLRow struct {
+	enqueuedAt int64
+	message    string
+}
+
+// fakeSQLDriver is a database/sql driver supporting the queries of the
+// SQLStore on the table sms_queue.
+type fakeSQLDriver struct{}
+
+func (fakeSQLDriver) Open(dsn string) (driver.Conn, error) {
+	return fakeSQLConn(dsn), nil
+}
+
+type fakeSQLConn string
+
+func (c fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeSQLStmt{dsn: string(c), query: query}, nil
+}
+
+func (fakeSQLConn) Close() error { return nil }
+
+func (fakeSQLConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeSQLStmt struct {
+	dsn, query string
+}
+
+func (fakeSQLStmt) Close() error  { return nil }
+func (fakeSQLStmt) NumInput() int { return -1 }
+
+func (s fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fakeSQLTables.Lock()
+	defer fakeSQLTables.Unlock()
+	table := fakeSQLTables.rows[s.dsn]
+	if table == nil {
+		table = make(map[string]fakeSQLRow)
+		fakeSQLTables.rows[s.dsn] = table
+	}
+
+	switch s.query {
+	case "INSERT INTO sms_queue (id, enqueued_at, message) VALUES (?, ?, ?)":
+		id := args[0].(string)
+		if _, ok := table[id]; ok {
+			return nil, fmt.Errorf("duplicate id %s", id)
+		}
+		table[id] = fakeSQLRow{enqueuedAt: args[1].(int64), message: args[2].(string)}
+	case "DELETE FROM sms_queue WHERE id = ?":
+		delete(table, args[0].(string))
+	default:
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.query != "SELECT id, message FROM sms_queue ORDER BY enqueued_at, id" {
+		return nil, fmt.Errorf("unexpected query %q", s.query)
+	}
+
+	fakeSQLTables.Lock()
+	defer fakeSQLTables.Unlock()
+	var ids []string
+	table := fakeSQLTables.rows[s.dsn]
+	for id := range table {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := table[ids[i]], table[ids[j]]
+		if a.enqueuedAt != b.enqueuedAt {
+			return a.enqueuedAt < b.enqueuedAt
+		}
+		return ids[i] < ids[j]
+	})
+
+	rows := &fakeSQLRows{}
+	for _, id := range ids {
+		rows.values = append(rows.values, []driver.Value{id, table[id].message})
+	}
+	return rows, nil
+}
+
+type fakeSQLRows struct {
+	values [][]driver.Value
+}
+
+func (*fakeSQLRows) Columns() []string { return []string{"id", "message"} }
+func (*fakeSQLRows) Close() error      { return nil }
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// sqlStore returns an SQLStore on a new table of the fake driver.
+func sqlStore(t *testing.T) (*SQLStore, func()) {
+	fakeSQLTables.Lock()
+	delete(fakeSQLTables.rows, t.Name())
+	fakeSQLTables.Unlock()
+
+	db, err := sql.Open("smsqueuetest", t.Name())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return NewSQLStore(db, "sms_queue"), func() { db.Close() }
+}
+
+func TestQueueStoresCorruptEntries(t *testing.T) {
+	fileStore, remove := tempStore(t)
+	defer remove()
+	if err := ioutil.WriteFile(filepath.Join(fileStore.dir, "corrupt.json"), []byte(`{"ID":"corr`), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	redis := fakeRedis{"sms": {"corrupt": `{"ID":"corr`}}
+
+	sqlStore, closeDB := sqlStore(t)
+	defer closeDB()
+	if _, err := sqlStore.db.Exec("INSERT INTO sms_queue (id, enqueued_at, message) VALUES (?, ?, ?)", "corrupt", int64(0), `{"ID":"corr`); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var cases = []struct {
+		name        string
+		store       QueueStore
+		quarantined func() bool
+	}{
+		{
+			name:  "file",
+			store: fileStore,
+			quarantined: func() bool {
+				_, err := os.Stat(filepath.Join(fileStore.dir, "corrupt", "corrupt.json"))
+				return err == nil
+			},
+		},
+		{
+			name:        "redis",
+			store:       NewRedisStore(redis, "sms"),
+			quarantined: func() bool { return redis["sms:corrupt"]["corrupt"] != "" },
+		},
+		{name: "sql", store: sqlStore},
+	}
+
+	for _, tt := range cases {
+		ctx := context.Background()
+		m := &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: "Alert"}
+		if err := tt.store.Put(ctx, &StoredMessage{ID: "valid", Message: m, EnqueuedAt: time.Now()}); err != nil {
+			t.Fatalf("unexpected error: %s, test case: %s", err, tt.name)
+		}
+
+		messages, err := tt.store.List(ctx)
+		var corrupt *CorruptMessagesError
+		if !errors.As(err, &corrupt) || len(corrupt.Errors) != 1 || corrupt.Errors["corrupt"] == nil {
+			t.Errorf("got %v, expected the corrupt entry to be reported, test case: %s", err, tt.name)
+		}
+		if len(messages) != 1 || messages[0].ID != "valid" {
+			t.Errorf("got %d messages, expected the valid one, test case: %s", len(messages), tt.name)
+		}
+
+		if tt.quarantined == nil {
+			continue
+		}
+		if !tt.quarantined() {
+			t.Errorf("expected the corrupt entry to be quarantined, test case: %s", tt.name)
+		}
+		if _, err := tt.store.List(ctx); err != nil {
+			t.Errorf("got %v after quarantining, expected no error, test case: %s", err, tt.name)
+		}
+	}
+}
+
+func TestDurableQueueCorruptEntry(t *testing.T) {
+	c, _, stop := durableServer(t, 0)
+	defer stop()
+	store, remove := tempStore(t)
+	defer remove()
+	if err := ioutil.WriteFile(filepath.Join(store.dir, "truncated.json"), []byte(`{"ID":"trunc`), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	results := make(chan *QueueResult, 1)
+	errs := make(chan error, 2)
+	q := NewDurableQueue(c, store, &DurableQueueOptions{
+		OnResult: func(r *QueueResult) { results <- r },
+		OnError:  func(err error) { errs <- err },
+	})
+	defer q.Close()
+
+	m := &BatchMessage{Originator: "TestName", Recipients: []string{"31612345678"}, Body: "Alert"}
+	if err := q.Enqueue(context.Background(), m); err != nil {
+		t.Fatalf("unexpected error enqueueing: %s", err)
+	}
+
+	select {
+	case r := <-results:
+		if r.Err != nil || r.Created.ID != "msg-Alert" {
+			t.Errorf("got %v, expected the message to be created", r.Err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the message to be sent despite the corrupt entry")
+	}
+	var corrupt *CorruptMessagesError
+	if err := <-errs; !errors.As(err, &corrupt) || corrupt.Errors["truncated"] == nil {
+		t.Errorf("got %v, expected the truncated entry to be reported", err)
+	}
+}
@@ -0,0 +1,123 @@
+package sms
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxOriginatorLength is the maximum length of alphanumeric originators.
+// Numeric originators are telephone numbers of up to 17 digits.
+const MaxOriginatorLength = 11
+
+// maxNumericOriginatorLength is the maximum number of digits of numeric
+// originators.
+const maxNumericOriginatorLength = 17
+
+// MaxParts is the maximum number of parts a message body is split into, as
+// counted by CountSegments.
+const MaxParts = 9
+
+// validateMessage checks a message locally, so it fails with a descriptive
+// error instead of an API error code.
+func validateMessage(originator string, recipients []string, body string, params *Params) error {
+	if len(recipients) > MaxRecipients {
+		return fmt.Errorf("at most %d recipients are allowed, got %d; use CreateChunked for more", MaxRecipients, len(recipients))
+	}
+	return validateContent(originator, recipients, body, params)
+}
+
+// validateContent checks a message like validateMessage, regardless of the
+// number of recipients. The recipients may also be group IDs, so only empty
+// ones are rejected.
+func validateContent(originator string, recipients []string, body string, params *Params) error {
+	if originator == "" {
+		return errors.New("originator is required")
+	}
+	if err := validateOriginator(originator); err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return errors.New("at least 1 recipient is required")
+	}
+	for i, r := range recipients {
+		if r == "" {
+			return fmt.Errorf("recipient %d is empty", i)
+		}
+	}
+	if body == "" {
+		return errors.New("body is required")
+	}
+
+	var p Params
+	if params != nil {
+		p = *params
+	}
+	if p.Validity < 0 {
+		return fmt.Errorf("validity can not be negative, got %d", p.Validity)
+	}
+	switch p.DataCoding {
+	case "", DataCodingPlain, DataCodingUnicode, DataCodingAuto:
+	default:
+		return fmt.Errorf("unknown data coding %q", p.DataCoding)
+	}
+
+	switch p.Type {
+	case "", TypeSMS, TypeFlash:
+		if len(p.TypeDetails) > 0 {
+			return fmt.Errorf("type details are only allowed for %s and %s messages", TypeBinary, TypePremium)
+		}
+	case TypeBinary:
+		// The body is hex encoded user data, which CountSegments does not
+		// apply to.
+		return nil
+	case TypePremium:
+		if isZero(p.TypeDetails["tariff"]) || isZero(p.TypeDetails["shortcode"]) || isZero(p.TypeDetails["keyword"]) {
+			return errors.New("premium messages require a tariff, shortcode and keyword")
+		}
+	default:
+		return fmt.Errorf("unknown message type %q", p.Type)
+	}
+
+	if s := CountSegments(body, p.DataCoding); s.Parts > MaxParts {
+		return fmt.Errorf("body is too long: it takes %d %s parts, at most %d are allowed", s.Parts, s.DataCoding, MaxParts)
+	}
+	return nil
+}
+
+// validateOriginator checks that originator is a telephone number or an
+// alphanumeric sender ID.
+func validateOriginator(originator string) error {
+	digits := originator
+	if digits[0] == '+' {
+		digits = digits[1:]
+	}
+	if isDigits(digits) && digits != "" {
+		if len(digits) > maxNumericOriginatorLength {
+			return fmt.Errorf("numeric originator %q is longer than %d digits", originator, maxNumericOriginatorLength)
+		}
+		return nil
+	}
+
+	if len(originator) > MaxOriginatorLength {
+		return fmt.Errorf("alphanumeric originator %q is longer than %d characters", originator, MaxOriginatorLength)
+	}
+	for _, r := range originator {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == ' ') {
+			return fmt.Errorf("originator %q may only contain letters, digits and spaces", originator)
+		}
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(v interface{}) bool {
+	return v == nil || v == 0 || v == ""
+}
@@ -0,0 +1,64 @@
+package sms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMessage(t *testing.T) {
+	recipients := make([]string, MaxRecipients+1)
+	for i := range recipients {
+		recipients[i] = "31612345678"
+	}
+
+	var cases = []struct {
+		name       string
+		originator string
+		recipients []string
+		body       string
+		params     *Params
+		e          string
+	}{
+		{name: "Valid", originator: "TestName", body: "Hello"},
+		{name: "Numeric originator", originator: "+31612345678", body: "Hello"},
+		{name: "Originator with space", originator: "Test Name", body: "Hello"},
+		{name: "Long numeric originator", originator: "316123456789012345", body: "Hello", e: "numeric originator"},
+		{name: "Long alphanumeric originator", originator: "TestSender12", body: "Hello", e: "longer than 11 characters"},
+		{name: "Invalid originator", originator: "Test-Name", body: "Hello", e: "letters, digits and spaces"},
+		{name: "Too many recipients", originator: "TestName", recipients: recipients, body: "Hello", e: "at most 50 recipients"},
+		{name: "Empty recipient", originator: "TestName", recipients: []string{"31612345678", ""}, body: "Hello", e: "recipient 1 is empty"},
+		{name: "Longest plain body", originator: "TestName", body: strings.Repeat("a", MaxParts*PlainConcatPartLength)},
+		{name: "Long plain body", originator: "TestName", body: strings.Repeat("a", MaxParts*PlainConcatPartLength+1), e: "takes 10 plain parts"},
+		{name: "Long unicode body", originator: "TestName", body: strings.Repeat("я", MaxParts*UnicodeConcatPartLength+1), e: "takes 10 unicode parts"},
+		{name: "Long binary body", originator: "TestName", body: strings.Repeat("a", 2000), params: &Params{Type: TypeBinary}},
+		{name: "Unknown type", originator: "TestName", body: "Hello", params: &Params{Type: "fax"}, e: `unknown message type "fax"`},
+		{name: "Unknown data coding", originator: "TestName", body: "Hello", params: &Params{DataCoding: "ascii"}, e: `unknown data coding "ascii"`},
+		{name: "Negative validity", originator: "TestName", body: "Hello", params: &Params{Validity: -1}, e: "validity can not be negative"},
+		{name: "Type details of SMS", originator: "TestName", body: "Hello", params: &Params{TypeDetails: TypeDetails{"udh": "00"}}, e: "type details are only allowed"},
+		{name: "Premium", originator: "TestName", body: "Hello", params: &Params{Type: TypePremium, TypeDetails: PremiumDetails{Tariff: 150, Shortcode: 1008, Keyword: "RESTAPI"}.TypeDetails()}},
+		{name: "Premium without keyword", originator: "TestName", body: "Hello", params: &Params{Type: TypePremium, TypeDetails: PremiumDetails{Tariff: 150, Shortcode: 1008}.TypeDetails()}, e: "premium messages require"},
+	}
+
+	for _, tt := range cases {
+		if tt.recipients == nil {
+			tt.recipients = []string{"31612345678"}
+		}
+		err := validateMessage(tt.originator, tt.recipients, tt.body, tt.params)
+		switch {
+		case tt.e == "" && err != nil:
+			t.Errorf("unexpected error: %s, test case: %s", err, tt.name)
+		case tt.e != "" && (err == nil || !strings.Contains(err.Error(), tt.e)):
+			t.Errorf("got error %v, expected %q, test case: %s", err, tt.e, tt.name)
+		}
+	}
+}
+
+func TestCreateChunkedValidatesContent(t *testing.T) {
+	recipients := make([]string, 2*MaxRecipients)
+	for i := range recipients {
+		recipients[i] = "31612345678"
+	}
+	if _, err := CreateChunked(nil, "Test-Name", recipients, "Hello", nil, nil); err == nil || !strings.Contains(err.Error(), "letters, digits and spaces") {
+		t.Errorf("got error %v, expected an invalid originator", err)
+	}
+}
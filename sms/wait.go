@@ -0,0 +1,86 @@
+package sms
+
+import (
+	"errors"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// The delays between reading the message in WaitForStatus, which double up to
+// the maximum.
+var (
+	waitMinDelay = time.Second
+	waitMaxDelay = 30 * time.Second
+)
+
+// WaitForStatus reads the message with id until all of its recipients have
+// one of statuses, and returns it. The statuses default to the final ones:
+// StatusDelivered, StatusDeliveryFailed and StatusExpired. The message is
+// read with a backoff, so bound the wait with a context:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+//	defer cancel()
+//	msg, err := sms.WaitForStatus(client.WithContext(ctx), id)
+//
+// When the context ends first, the last message read is returned with the
+// error of the context.
+func WaitForStatus(c *messagebird.Client, id string, statuses ...string) (*Message, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+	if len(statuses) == 0 {
+		statuses = []string{StatusDelivered, StatusDeliveryFailed, StatusExpired}
+	}
+
+	ctx := c.Context()
+	delay := waitMinDelay
+	var last *Message
+	for {
+		message, err := Read(c, id)
+		if err != nil {
+			if last != nil && ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return nil, err
+		}
+		last = message
+		if hasStatus(message, statuses) {
+			return message, nil
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return message, ctx.Err()
+		case <-t.C:
+		}
+
+		if delay *= 2; delay > waitMaxDelay {
+			delay = waitMaxDelay
+		}
+	}
+}
+
+// hasStatus reports whether all recipients of message have one of statuses.
+func hasStatus(message *Message, statuses []string) bool {
+	if len(message.Recipients.Items) == 0 {
+		return false
+	}
+	for _, r := range message.Recipients.Items {
+		if !contains(statuses, r.Status) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
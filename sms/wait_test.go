@@ -0,0 +1,79 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+func waitServer(t *testing.T, statuses ...string) (*messagebird.Client, *int, func()) {
+	waitMinDelay, waitMaxDelay = time.Millisecond, 2*time.Millisecond
+
+	reads := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/6fe65f90454aa61536e6a88b88972670" {
+			t.Errorf("got path %s, expected the message", r.URL.Path)
+		}
+		status := statuses[len(statuses)-1]
+		if reads < len(statuses) {
+			status = statuses[reads]
+		}
+		reads++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"6fe65f90454aa61536e6a88b88972670","recipients":{"items":[{"recipient":31612345678,"status":"delivered"},{"recipient":31687654321,"status":%q}]}}`, status)
+	}))
+
+	c := messagebird.New("test_gshuPaZoeEG6ovbc8M79w0QyM", messagebird.WithEndpoints(messagebird.Endpoints{REST: ts.URL}))
+	return c, &reads, func() {
+		ts.Close()
+		waitMinDelay, waitMaxDelay = time.Second, 30*time.Second
+	}
+}
+
+func TestWaitForStatus(t *testing.T) {
+	c, reads, stop := waitServer(t, StatusSent, StatusBuffered, StatusDeliveryFailed)
+	defer stop()
+
+	message, err := WaitForStatus(c, "6fe65f90454aa61536e6a88b88972670")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *reads != 3 || message.Recipients.Items[1].Status != StatusDeliveryFailed {
+		t.Errorf("got status %s after %d reads, expected delivery_failed after 3", message.Recipients.Items[1].Status, *reads)
+	}
+}
+
+func TestWaitForStatusCustom(t *testing.T) {
+	c, reads, stop := waitServer(t, StatusScheduled, StatusSent, StatusDelivered)
+	defer stop()
+
+	message, err := WaitForStatus(c, "6fe65f90454aa61536e6a88b88972670", StatusSent, StatusDelivered)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *reads != 2 || message.Recipients.Items[1].Status != StatusSent {
+		t.Errorf("got status %s after %d reads, expected sent after 2", message.Recipients.Items[1].Status, *reads)
+	}
+}
+
+func TestWaitForStatusContext(t *testing.T) {
+	c, _, stop := waitServer(t, StatusSent)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	message, err := WaitForStatus(c.WithContext(ctx), "6fe65f90454aa61536e6a88b88972670")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if message == nil || message.Recipients.Items[1].Status != StatusSent {
+		t.Errorf("got %+v, expected the last message read", message)
+	}
+}
@@ -0,0 +1,79 @@
+package messagebird
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// tokenExpiryLeeway is how long before their expiry tokens are refreshed.
+const tokenExpiryLeeway = 10 * time.Second
+
+// TokenSource provides OAuth2 access tokens for clients that authenticate
+// with bearer tokens instead of an access key. A token with a zero expiry
+// does not expire.
+//
+// A TokenSource from golang.org/x/oauth2 can be adapted as follows:
+//
+//	type oauth2Source struct{ oauth2.TokenSource }
+//
+//	func (s oauth2Source) Token(ctx context.Context) (string, time.Time, error) {
+//	    t, err := s.TokenSource.Token()
+//	    if err != nil {
+//	        return "", time.Time{}, err
+//	    }
+//	    return t.AccessToken, t.Expiry, nil
+//	}
+type TokenSource interface {
+	Token(ctx context.Context) (token string, expiry time.Time, err error)
+}
+
+// TokenSourceFunc is an adapter to allow the use of ordinary functions as
+// TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, time.Time, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (string, time.Time, error) {
+	return f(ctx)
+}
+
+// WithTokenSource makes the client authenticate with bearer tokens from src
+// instead of its access key. Tokens are reused until shortly before they
+// expire, so src is only asked for a token when one is needed.
+func WithTokenSource(src TokenSource) Option {
+	return func(c *Client) {
+		c.TokenSource = ReuseTokenSource(src)
+	}
+}
+
+// ReuseTokenSource returns a TokenSource that returns the last token of src
+// until shortly before it expires.
+func ReuseTokenSource(src TokenSource) TokenSource {
+	if r, ok := src.(*reuseTokenSource); ok {
+		return r
+	}
+	return &reuseTokenSource{src: src}
+}
+
+type reuseTokenSource struct {
+	src TokenSource
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (r *reuseTokenSource) Token(ctx context.Context) (string, time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.token != "" && (r.expiry.IsZero() || time.Now().Add(tokenExpiryLeeway).Before(r.expiry)) {
+		return r.token, r.expiry, nil
+	}
+	token, expiry, err := r.src.Token(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	r.token, r.expiry = token, expiry
+	return token, expiry, nil
+}
@@ -0,0 +1,60 @@
+package messagebird
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithTokenSource(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	var calls int
+	src := TokenSourceFunc(func(ctx context.Context) (string, time.Time, error) {
+		calls++
+		if calls == 1 {
+			// Expires within the leeway, so it is refreshed for the next
+			// request.
+			return "first", time.Now().Add(time.Second), nil
+		}
+		return "second", time.Now().Add(time.Hour), nil
+	})
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithTokenSource(src))
+	for i := 0; i < 3; i++ {
+		if err := c.Request(nil, http.MethodGet, ts.URL, nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	expected := []string{"Bearer first", "Bearer second", "Bearer second"}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("got Authorization headers %q, expected %q", got, expected)
+			break
+		}
+	}
+	if calls != 2 {
+		t.Errorf("got %d token requests, expected 2", calls)
+	}
+}
+
+func TestTokenSourceError(t *testing.T) {
+	errToken := errors.New("token endpoint unavailable")
+	src := TokenSourceFunc(func(ctx context.Context) (string, time.Time, error) {
+		return "", time.Time{}, errToken
+	})
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithTokenSource(src))
+	if err := c.Request(nil, http.MethodGet, "balance", nil); !errors.Is(err, errToken) {
+		t.Errorf("got %v, expected %v", err, errToken)
+	}
+}
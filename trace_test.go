@@ -0,0 +1,59 @@
+package messagebird
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type spanKey struct{}
+
+func TestTraceRequest(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"code":20,"description":"message not found"}]}`))
+	}))
+	defer ts.Close()
+
+	var (
+		started, ended int
+		endStatus      int
+		endErr         error
+		attemptSpans   []interface{}
+	)
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM")
+	c.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	c.TraceRequest = func(ctx context.Context, method, endpoint string) (context.Context, func(int, error)) {
+		started++
+		return context.WithValue(ctx, spanKey{}, "span"), func(status int, err error) {
+			ended++
+			endStatus, endErr = status, err
+		}
+	}
+	c.ObserveRequest = func(ctx context.Context, _ RequestMetrics) {
+		attemptSpans = append(attemptSpans, ctx.Value(spanKey{}))
+	}
+
+	err := c.Request(nil, http.MethodGet, ts.URL+"/messages/6fe65f90454aa61536e6a88b88972670", nil)
+	if !IsNotFound(err) {
+		t.Fatalf("got %v, expected not found", err)
+	}
+
+	if started != 1 || ended != 1 {
+		t.Errorf("got %d started and %d ended spans, expected one span per call", started, ended)
+	}
+	if endStatus != http.StatusNotFound || !IsNotFound(endErr) {
+		t.Errorf("got status %d and error %v at the end of the span", endStatus, endErr)
+	}
+	if len(attemptSpans) != 2 || attemptSpans[0] != "span" || attemptSpans[1] != "span" {
+		t.Errorf("expected attempts to use the span context, got %v", attemptSpans)
+	}
+}
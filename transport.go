@@ -0,0 +1,34 @@
+package messagebird
+
+import "net/http"
+
+// Transport returns an http.RoundTripper that sets the client's
+// authentication and default headers on each request before sending it with
+// base, or http.DefaultTransport if base is nil. It can be composed into
+// other HTTP clients to call the API directly:
+//
+//	hc := &http.Client{Transport: client.Transport(recorder)}
+//	resp, err := hc.Get(messagebird.Endpoint + "/balance")
+func (c *Client) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{c: c, base: base}
+}
+
+type transport struct {
+	c    *Client
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	if err := t.c.SetHeaders(req); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
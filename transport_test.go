@@ -0,0 +1,40 @@
+package messagebird
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTransport(t *testing.T) {
+	var got *http.Request
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		}, nil
+	})
+
+	c := New("test_gshuPaZoeEG6ovbc8M79w0QyM", WithAppInfo("billing", "1.4.2"))
+	hc := &http.Client{Transport: c.Transport(base)}
+
+	req, _ := http.NewRequest(http.MethodGet, Endpoint+"/balance", nil)
+	resp, err := hc.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if h := got.Header.Get("Authorization"); h != "AccessKey test_gshuPaZoeEG6ovbc8M79w0QyM" {
+		t.Errorf("got Authorization header %q, expected the access key", h)
+	}
+	if ua := got.Header.Get("User-Agent"); !strings.HasSuffix(ua, " billing/1.4.2") {
+		t.Errorf("got User-Agent %q, expected the app info", ua)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Errorf("expected the original request not to be modified")
+	}
+}
@@ -1,7 +1,9 @@
 package verify
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"time"
@@ -19,34 +21,103 @@ type Verify struct {
 	CreatedDatetime    *time.Time
 	ValidUntilDatetime *time.Time
 	Recipient          int
+
+	// Email is the recipient of email verifications, for which Recipient
+	// is zero.
+	Email string
+
+	// Type is the type the token was last sent with, which is one of the
+	// fallback types if the requested type could not be delivered.
+	Type string
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The recipient is
+// a number, or a string for email verifications.
+func (v *Verify) UnmarshalJSON(data []byte) error {
+	type plain Verify
+	var raw struct {
+		plain
+		Recipient json.RawMessage
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*v = Verify(raw.plain)
+
+	if len(raw.Recipient) == 0 || string(raw.Recipient) == "null" {
+		return nil
+	}
+	if raw.Recipient[0] == '"' {
+		return json.Unmarshal(raw.Recipient, &v.Email)
+	}
+	return json.Unmarshal(raw.Recipient, &v.Recipient)
+}
+
+// EmailMessage is the email sent for an email verification.
+type EmailMessage struct {
+	ID     string
+	Status string
 }
 
+// The statuses of a Verify object.
+const (
+	StatusSent     = "sent"
+	StatusExpired  = "expired"
+	StatusFailed   = "failed"
+	StatusVerified = "verified"
+	StatusDeleted  = "deleted"
+)
+
+// The types of verification messages.
+const (
+	TypeSMS       = "sms"
+	TypeTTS       = "tts"
+	TypeEmail     = "email"
+	TypeWhatsApp  = "whatsapp"
+	TypeFlashCall = "flashcall" // The token is the end of the caller ID.
+)
+
 // Params handles optional verification parameters.
 type Params struct {
 	Originator  string
 	Reference   string
-	Type        string
-	Template    string
+	Type        string // TypeSMS by default.
+	Template    string // The message template, in which %token is replaced.
 	DataCoding  string
 	ReportURL   string
-	Voice       string
-	Language    string
-	Timeout     int
+	Voice       string // The voice of TypeTTS messages, "male" or "female".
+	Language    string // The language of TypeTTS messages, e.g. "en-gb".
+	Subject     string // The subject of TypeEmail messages.
+	Timeout     int    // The validity of the token in seconds.
 	TokenLength int
+
+	// ChannelID is the WhatsApp channel of the Conversations API that
+	// TypeWhatsApp messages are sent from. It defaults to the WhatsApp
+	// channel of the account.
+	ChannelID string
+
+	// Fallback are the types the token is sent with, in order, when it can't
+	// be delivered with Type, e.g. []string{TypeSMS, TypeTTS} for
+	// TypeWhatsApp or TypeFlashCall where these are unavailable. TypeEmail
+	// can't be a fallback.
+	Fallback []string
 }
 
 type verifyRequest struct {
-	Recipient   string `json:"recipient"`
-	Originator  string `json:"originator,omitempty"`
-	Reference   string `json:"reference,omitempty"`
-	Type        string `json:"type,omitempty"`
-	Template    string `json:"template,omitempty"`
-	DataCoding  string `json:"dataCoding,omitempty"`
-	ReportURL   string `json:"reportUrl,omitempty"`
-	Voice       string `json:"voice,omitempty"`
-	Language    string `json:"language,omitempty"`
-	Timeout     int    `json:"timeout,omitempty"`
-	TokenLength int    `json:"tokenLength,omitempty"`
+	Recipient   string   `json:"recipient"`
+	Originator  string   `json:"originator,omitempty"`
+	Reference   string   `json:"reference,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Template    string   `json:"template,omitempty"`
+	DataCoding  string   `json:"dataCoding,omitempty"`
+	ReportURL   string   `json:"reportUrl,omitempty"`
+	Voice       string   `json:"voice,omitempty"`
+	Language    string   `json:"language,omitempty"`
+	Subject     string   `json:"subject,omitempty"`
+	Timeout     int      `json:"timeout,omitempty"`
+	TokenLength int      `json:"tokenLength,omitempty"`
+	ChannelID   string   `json:"channelId,omitempty"`
+	Fallback    []string `json:"fallback,omitempty"`
 }
 
 // path represents the path to the Verify resource.
@@ -67,8 +138,55 @@ func Create(c *messagebird.Client, recipient string, params *Params) (*Verify, e
 	return verify, nil
 }
 
+// CreateWithEmail generates a new One-Time-Password and emails it to
+// recipient from originator, both email addresses, with the given subject.
+// The Type, Originator and Subject of params are ignored.
+func CreateWithEmail(c *messagebird.Client, recipient, originator, subject string, params *Params) (*Verify, error) {
+	if originator == "" {
+		return nil, errors.New("originator is required")
+	}
+
+	var p Params
+	if params != nil {
+		p = *params
+	}
+	p.Type = TypeEmail
+	p.Originator = originator
+	p.Subject = subject
+	return Create(c, recipient, &p)
+}
+
+// CreateWithWhatsApp generates a new One-Time-Password and sends it to
+// recipient over WhatsApp, with the approved authentication template of the
+// channel. The channel ID may be empty to use the WhatsApp channel of the
+// account. The Type and ChannelID of params are ignored.
+func CreateWithWhatsApp(c *messagebird.Client, recipient, channelID string, params *Params) (*Verify, error) {
+	var p Params
+	if params != nil {
+		p = *params
+	}
+	p.Type = TypeWhatsApp
+	p.ChannelID = channelID
+	return Create(c, recipient, &p)
+}
+
+// ReadEmailMessage retrieves the email sent for an email verification by its
+// ID, e.g. to check whether it was delivered.
+func ReadEmailMessage(c *messagebird.Client, id string) (*EmailMessage, error) {
+	message := &EmailMessage{}
+	if err := c.Request(message, http.MethodGet, path+"/messages/email/"+id, nil); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
 // Delete deletes an existing Verify object by its ID.
 func Delete(c *messagebird.Client, id string) error {
+	if id == "" {
+		return errors.New("id is required")
+	}
+
 	return c.Request(nil, http.MethodDelete, path+"/"+id, nil)
 }
 
@@ -110,6 +228,9 @@ func requestDataForVerify(recipient string, params *Params) (*verifyRequest, err
 	if params == nil {
 		return request, nil
 	}
+	if err := validateChannel(params); err != nil {
+		return nil, err
+	}
 
 	request.Originator = params.Originator
 	request.Reference = params.Reference
@@ -119,8 +240,43 @@ func requestDataForVerify(recipient string, params *Params) (*verifyRequest, err
 	request.ReportURL = params.ReportURL
 	request.Voice = params.Voice
 	request.Language = params.Language
+	request.Subject = params.Subject
 	request.Timeout = params.Timeout
 	request.TokenLength = params.TokenLength
+	request.ChannelID = params.ChannelID
+	request.Fallback = params.Fallback
 
 	return request, nil
 }
+
+// validateChannel checks the options specific to the type and fallback types
+// of params.
+func validateChannel(params *Params) error {
+	typ := params.Type
+	if typ == "" {
+		typ = TypeSMS
+	}
+	if params.ChannelID != "" && typ != TypeWhatsApp {
+		return errors.New("channelId is only used for whatsapp verifications")
+	}
+	if typ == TypeFlashCall && params.Template != "" {
+		return errors.New("flash call verifications have no template")
+	}
+	if len(params.Fallback) > 0 && typ == TypeEmail {
+		return errors.New("email verifications have no fallback")
+	}
+
+	seen := map[string]bool{typ: true}
+	for _, f := range params.Fallback {
+		switch f {
+		case TypeSMS, TypeTTS, TypeWhatsApp, TypeFlashCall:
+		default:
+			return fmt.Errorf("invalid fallback type %q", f)
+		}
+		if seen[f] {
+			return fmt.Errorf("fallback type %q is used more than once", f)
+		}
+		seen[f] = true
+	}
+	return nil
+}
@@ -77,6 +77,14 @@ func TestDelete(t *testing.T) {
 	mbtest.AssertEndpointCalled(t, http.MethodDelete, "/verify/15498233759288aaf929661v21936686")
 }
 
+func TestDeleteWithEmptyID(t *testing.T) {
+	client := mbtest.Client(t)
+
+	if err := Delete(client, ""); err == nil {
+		t.Fatalf("expected error deleting Verify without ID, got nil")
+	}
+}
+
 func TestRead(t *testing.T) {
 	mbtest.WillReturnTestdata(t, "verifyObject.json", http.StatusOK)
 	client := mbtest.Client(t)
@@ -197,3 +205,75 @@ func TestRequestDataForVerify(t *testing.T) {
 		t.Errorf("Unexpected token length: %d, expected 8", requestData.TokenLength)
 	}
 }
+
+func TestCreateWithEmail(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "verifyEmailObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	v, err := CreateWithEmail(client, "client@example.com", "noreply@example.com", "Your code", &Params{Type: TypeSMS})
+	if err != nil {
+		t.Fatalf("unexpected error creating email Verify: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/verify")
+	mbtest.AssertTestdata(t, "verifyEmailRequest.json", mbtest.Request.Body)
+
+	if v.Email != "client@example.com" || v.Recipient != 0 {
+		t.Errorf("Unexpected recipient %d and email %s, expected client@example.com", v.Recipient, v.Email)
+	}
+}
+
+func TestReadEmailMessage(t *testing.T) {
+	mbtest.WillReturn([]byte(`{"id":"a3f2edb23592d68163f6694v19803155","status":"delivered"}`), http.StatusOK)
+	client := mbtest.Client(t)
+
+	m, err := ReadEmailMessage(client, "a3f2edb23592d68163f6694v19803155")
+	if err != nil {
+		t.Fatalf("unexpected error reading email message: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodGet, "/verify/messages/email/a3f2edb23592d68163f6694v19803155")
+	if m.Status != "delivered" {
+		t.Errorf("Unexpected status: %s, expected delivered", m.Status)
+	}
+}
+
+func TestCreateWithWhatsApp(t *testing.T) {
+	mbtest.WillReturnTestdata(t, "verifyObject.json", http.StatusOK)
+	client := mbtest.Client(t)
+
+	params := &Params{Type: TypeTTS, TokenLength: 6, Fallback: []string{TypeSMS, TypeTTS}}
+	if _, err := CreateWithWhatsApp(client, "31612345678", "ch-whatsapp", params); err != nil {
+		t.Fatalf("unexpected error creating WhatsApp Verify: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/verify")
+	mbtest.AssertTestdata(t, "verifyWhatsAppRequest.json", mbtest.Request.Body)
+}
+
+func TestRequestDataForVerifyChannels(t *testing.T) {
+	var cases = []struct {
+		name   string
+		params *Params
+		valid  bool
+	}{
+		{name: "Flash call with fallback", params: &Params{Type: TypeFlashCall, Fallback: []string{TypeSMS}}, valid: true},
+		{name: "Default type with fallback", params: &Params{Fallback: []string{TypeTTS}}, valid: true},
+		{name: "Channel for SMS", params: &Params{ChannelID: "ch-whatsapp"}},
+		{name: "Flash call with template", params: &Params{Type: TypeFlashCall, Template: "Your code is %token"}},
+		{name: "Email with fallback", params: &Params{Type: TypeEmail, Fallback: []string{TypeSMS}}},
+		{name: "Email fallback", params: &Params{Type: TypeWhatsApp, Fallback: []string{TypeEmail}}},
+		{name: "Fallback to type", params: &Params{Type: TypeWhatsApp, Fallback: []string{TypeSMS, TypeWhatsApp}}},
+		{name: "Repeated fallback", params: &Params{Fallback: []string{TypeTTS, TypeTTS}}},
+	}
+
+	for _, tt := range cases {
+		_, err := requestDataForVerify("31612345678", tt.params)
+		if tt.valid && err != nil {
+			t.Errorf("unexpected error: %s, test case: %s", err, tt.name)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("expected an error, got nil, test case: %s", tt.name)
+		}
+	}
+}
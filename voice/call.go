@@ -113,7 +113,7 @@ func CallByID(client *messagebird.Client, id string) (*Call, error) {
 	if err := client.Request(&resp, http.MethodGet, apiRoot+"/calls/"+id, nil); err != nil {
 		return nil, err
 	}
-	return &resp.Data[0], nil
+	return first(resp.Data)
 }
 
 // Calls returns a Paginator which iterates over all Calls.
@@ -131,18 +131,20 @@ func InitiateCall(client *messagebird.Client, source, destination string, callfl
 		Source      string   `json:"source"`
 		Destination string   `json:"destination"`
 		Callflow    CallFlow `json:"callflow"`
-		Webhook     struct {
+		Webhook     *struct {
 			URL   string `json:"url,omitempty"`
 			Token string `json:"token,omitempty"`
-		}
+		} `json:"webhook,omitempty"`
 	}{
 		Source:      source,
 		Destination: destination,
 		Callflow:    callflow,
 	}
 	if webhook != nil {
-		body.Webhook.URL = webhook.URL
-		body.Webhook.Token = webhook.Token
+		body.Webhook = &struct {
+			URL   string `json:"url,omitempty"`
+			Token string `json:"token,omitempty"`
+		}{webhook.URL, webhook.Token}
 	}
 	var resp struct {
 		Data []Call `json:"data"`
@@ -150,7 +152,7 @@ func InitiateCall(client *messagebird.Client, source, destination string, callfl
 	if err := client.Request(&resp, http.MethodPost, apiRoot+"/calls", body); err != nil {
 		return nil, err
 	}
-	return &resp.Data[0], nil
+	return first(resp.Data)
 }
 
 // Delete deletes the Call.
@@ -1,6 +1,7 @@
 package voice
 
 import (
+	"net/http"
 	"testing"
 	"time"
 )
@@ -67,3 +68,12 @@ func TestCallByID(t *testing.T) {
 		t.Fatalf("mismatched source: exp %q, got %q", call.Source, fetchedCall.Source)
 	}
 }
+
+func TestCallByIDEmptyData(t *testing.T) {
+	mbClient, stop := testRequest(http.StatusOK, []byte(`{"data":[]}`))
+	defer stop()
+
+	if _, err := CallByID(mbClient, "call-id"); err == nil {
+		t.Fatal("expected error for response without call, got nil")
+	}
+}
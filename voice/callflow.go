@@ -120,7 +120,7 @@ func CallFlowByID(client *messagebird.Client, id string) (*CallFlow, error) {
 	if err := client.Request(&data, http.MethodGet, apiRoot+"/call-flows/"+id, nil); err != nil {
 		return nil, err
 	}
-	return &data.Data[0], nil
+	return first(data.Data)
 }
 
 // CallFlows returns a Paginator which iterates over all CallFlows.
@@ -138,7 +138,11 @@ func (callflow *CallFlow) Create(client *messagebird.Client) error {
 	if err := client.Request(&data, http.MethodPost, apiRoot+"/call-flows/", callflow); err != nil {
 		return err
 	}
-	*callflow = data.Data[0]
+	created, err := first(data.Data)
+	if err != nil {
+		return err
+	}
+	*callflow = *created
 	return nil
 }
 
@@ -152,7 +156,11 @@ func (callflow *CallFlow) Update(client *messagebird.Client) error {
 	if err := client.Request(&data, http.MethodPut, apiRoot+"/call-flows/"+callflow.ID, callflow); err != nil {
 		return err
 	}
-	*callflow = data.Data[0]
+	updated, err := first(data.Data)
+	if err != nil {
+		return err
+	}
+	*callflow = *updated
 	return nil
 }
 
@@ -161,6 +169,15 @@ func (callflow *CallFlow) Delete(client *messagebird.Client) error {
 	return client.Request(nil, http.MethodDelete, apiRoot+"/call-flows/"+callflow.ID, nil)
 }
 
+// AssignNumbers assigns purchased numbers to the call flow, so calls to them
+// are handled by it. The numbers replace those assigned before.
+func (callflow *CallFlow) AssignNumbers(client *messagebird.Client, numbers ...string) error {
+	request := struct {
+		Numbers []string `json:"numbers"`
+	}{numbers}
+	return client.Request(nil, http.MethodPost, apiRoot+"/call-flows/"+callflow.ID+"/numbers", &request)
+}
+
 // A CallFlowStep is a single step that can be taken in a callflow.
 //
 // It can be any of CallflowTransferStep, CallFlowSayStep, CallFlowPlayStep,
@@ -289,7 +306,7 @@ type jsonCallFlowSayStep struct {
 		Payload        string `json:"payload"`
 		Voice          string `json:"voice,omitempty"`
 		Language       string `json:"language,omitempty"`
-		Repeat         int    `json:"Repeat,omitempty"`
+		Repeat         int    `json:"repeat,omitempty"`
 		IfMachine      string `json:"ifMachine,omitempty"`
 		MachineTimeout int    `json:"machineTimeout,omitempty"`
 	} `json:"options"`
@@ -446,9 +463,9 @@ type jsonCallFlowRecordStep struct {
 	Action  string `json:"action"`
 	Options struct {
 		MaxLength          int    `json:"maxLength"`
-		Timeout            int    `json:"timeout"`
-		FinishOnKey        string `json:"finishOnKey"`
-		TranscribeLanguage string `json:"transcribeLanguage"`
+		Timeout            int    `json:"timeout,omitempty"`
+		FinishOnKey        string `json:"finishOnKey,omitempty"`
+		TranscribeLanguage string `json:"transcribeLanguage,omitempty"`
 	} `json:"options"`
 }
 
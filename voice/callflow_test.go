@@ -2,9 +2,12 @@ package voice
 
 import (
 	"encoding/json"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/messagebird/go-rest-api/internal/mbtest"
 )
 
 func ExampleCallFlow() {
@@ -213,3 +216,29 @@ func TestCallFlowList(t *testing.T) {
 		t.Fatal("no callflows were fetched")
 	}
 }
+
+func TestCreateCallFlowEmptyData(t *testing.T) {
+	mbClient, stop := testRequest(http.StatusOK, []byte(`{"data":[]}`))
+	defer stop()
+
+	callflow := CallFlow{Title: "Empty", Steps: []CallFlowStep{&CallFlowHangupStep{}}}
+	if err := callflow.Create(mbClient); err == nil {
+		t.Fatal("expected error for response without call flow, got nil")
+	}
+	if callflow.Title != "Empty" {
+		t.Errorf("expected the call flow to be unmodified, got title %q", callflow.Title)
+	}
+}
+
+func TestCallFlowAssignNumbers(t *testing.T) {
+	mbtest.WillReturn([]byte(""), http.StatusNoContent)
+	client := mbtest.Client(t)
+
+	callflow := CallFlow{ID: "de3ed163-d5fc-45f4-b8c4-7eea7458c635"}
+	if err := callflow.AssignNumbers(client, "31612345670", "31612345671"); err != nil {
+		t.Fatalf("unexpected error assigning numbers: %s", err)
+	}
+
+	mbtest.AssertEndpointCalled(t, http.MethodPost, "/call-flows/de3ed163-d5fc-45f4-b8c4-7eea7458c635/numbers")
+	mbtest.AssertTestdata(t, "callFlowAssignNumbersRequest.json", mbtest.Request.Body)
+}
@@ -0,0 +1,443 @@
+package voice
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/messagebird/go-rest-api"
+)
+
+// CallFlowBuilder composes a call flow step by step, as an alternative to
+// writing the steps by hand:
+//
+//	callflow, err := voice.NewCallFlowBuilder("Support").
+//		Say("Press 1 for sales, or 2 for support.").InLanguage("en-GB").WithVoice("female").
+//		GatherKeypress("choice").
+//		When("choice", "1", func(b *voice.CallFlowBuilder) {
+//			b.Transfer("31612345670")
+//		}).
+//		When("choice", "2", func(b *voice.CallFlowBuilder) {
+//			b.Play("https://example.com/hold.wav").Transfer("31612345671")
+//		}).
+//		Hangup().
+//		Create(client)
+//
+// Steps are validated by Build and Create, before a request is made. Options
+// that apply to any step, such as WithStepID and GatherKeypress, apply to the
+// step added last.
+type CallFlowBuilder struct {
+	callflow CallFlow
+	outer    *CallFlowBuilder // The builder of the flow a branch is part of.
+	err      error            // The first invalid option.
+}
+
+// NewCallFlowBuilder starts building a call flow with title.
+func NewCallFlowBuilder(title string) *CallFlowBuilder {
+	return &CallFlowBuilder{callflow: CallFlow{Title: title}}
+}
+
+// RecordCall records the entire call.
+func (b *CallFlowBuilder) RecordCall() *CallFlowBuilder {
+	b.callflow.Record = true
+	return b
+}
+
+// Say adds a step pronouncing text.
+func (b *CallFlowBuilder) Say(text string) *SayStepBuilder {
+	step := &CallFlowSayStep{Payload: text}
+	b.add(step)
+	return &SayStepBuilder{b, step}
+}
+
+// Play adds a step playing back the WAV file at mediaURL.
+func (b *CallFlowBuilder) Play(mediaURL string) *CallFlowBuilder {
+	return b.add(&CallFlowPlayStep{Media: mediaURL})
+}
+
+// Pause adds a step pausing silently for length, in whole seconds.
+func (b *CallFlowBuilder) Pause(length time.Duration) *CallFlowBuilder {
+	return b.add(&CallFlowPauseStep{Length: length})
+}
+
+// Record adds a step recording a message of the caller.
+func (b *CallFlowBuilder) Record() *RecordStepBuilder {
+	step := &CallFlowRecordStep{}
+	b.add(step)
+	return &RecordStepBuilder{b, step}
+}
+
+// Transfer adds a step transferring the call to destination, an E.164
+// number or SIP URI.
+func (b *CallFlowBuilder) Transfer(destination string) *TransferStepBuilder {
+	step := &CallFlowTransferStep{Destination: destination}
+	b.add(step)
+	return &TransferStepBuilder{b, step}
+}
+
+// Fetch adds a step continuing the call with the call flow fetched from
+// flowURL. It must be the last step.
+func (b *CallFlowBuilder) Fetch(flowURL string) *CallFlowBuilder {
+	return b.add(&CallFlowFetchStep{URL: flowURL})
+}
+
+// Hangup adds a step ending the call.
+func (b *CallFlowBuilder) Hangup() *CallFlowBuilder {
+	return b.add(&CallFlowHangupStep{})
+}
+
+// WithStepID sets the ID of the last step, e.g. to jump to it with
+// GotoOnKeypress.
+func (b *CallFlowBuilder) WithStepID(id string) *CallFlowBuilder {
+	if base := b.last("WithStepID"); base != nil {
+		base.ID = id
+	}
+	return b
+}
+
+// GatherKeypress stores the key the caller presses during the last step in
+// variable, so the following steps can branch on it with When.
+func (b *CallFlowBuilder) GatherKeypress(variable string) *CallFlowBuilder {
+	if variable == "" {
+		b.fail(errors.New("keypress variable is required"))
+		return b
+	}
+	if base := b.last("GatherKeypress"); base != nil {
+		base.OnKeypressVar = variable
+	}
+	return b
+}
+
+// GotoOnKeypress jumps to the step with stepID when the caller presses a
+// key during the last step.
+func (b *CallFlowBuilder) GotoOnKeypress(stepID string) *CallFlowBuilder {
+	if base := b.last("GotoOnKeypress"); base != nil {
+		base.OnKeypressGoto = stepID
+	}
+	return b
+}
+
+// When adds the steps added by branch, which are only executed when variable
+// has value, i.e. when the caller pressed the key value during the step that
+// gathered variable. Branches can be nested.
+func (b *CallFlowBuilder) When(variable, value string, branch func(*CallFlowBuilder)) *CallFlowBuilder {
+	if !b.gathers(variable) {
+		b.fail(fmt.Errorf("no step before the branch on %q gathers it", variable))
+		return b
+	}
+
+	sub := &CallFlowBuilder{outer: b}
+	branch(sub)
+	if sub.err != nil {
+		b.fail(sub.err)
+		return b
+	}
+	for _, step := range sub.callflow.Steps {
+		base := stepBase(step)
+		base.Conditions = append(base.Conditions, struct {
+			Variable string `json:"variable"`
+			Operator string `json:"operator"`
+			Value    string `json:"value"`
+		}{variable, "==", value})
+		b.callflow.Steps = append(b.callflow.Steps, step)
+	}
+	return b
+}
+
+// Build validates the call flow and returns it.
+func (b *CallFlowBuilder) Build() (*CallFlow, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.callflow.Validate(); err != nil {
+		return nil, err
+	}
+
+	callflow := b.callflow
+	callflow.Steps = append([]CallFlowStep(nil), b.callflow.Steps...)
+	return &callflow, nil
+}
+
+// Create validates and creates the call flow.
+func (b *CallFlowBuilder) Create(client *messagebird.Client) (*CallFlow, error) {
+	callflow, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := callflow.Create(client); err != nil {
+		return nil, err
+	}
+	return callflow, nil
+}
+
+func (b *CallFlowBuilder) add(step CallFlowStep) *CallFlowBuilder {
+	b.callflow.Steps = append(b.callflow.Steps, step)
+	return b
+}
+
+// last returns the base of the last step, or records an error if there are
+// no steps yet.
+func (b *CallFlowBuilder) last(option string) *CallFlowStepBase {
+	if len(b.callflow.Steps) == 0 {
+		b.fail(fmt.Errorf("%s requires a step", option))
+		return nil
+	}
+	return stepBase(b.callflow.Steps[len(b.callflow.Steps)-1])
+}
+
+// gathers reports whether a step gathers the keypress in variable, including
+// the steps before a branch.
+func (b *CallFlowBuilder) gathers(variable string) bool {
+	for _, step := range b.callflow.Steps {
+		if stepBase(step).OnKeypressVar == variable {
+			return true
+		}
+	}
+	return b.outer != nil && b.outer.gathers(variable)
+}
+
+// fail records err, unless an earlier option was invalid.
+func (b *CallFlowBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// SayStepBuilder sets the options of a say step. Its other methods continue
+// building the call flow.
+type SayStepBuilder struct {
+	*CallFlowBuilder
+	step *CallFlowSayStep
+}
+
+// InLanguage sets the language of the text, e.g. en-GB.
+func (b *SayStepBuilder) InLanguage(language string) *SayStepBuilder {
+	b.step.Language = language
+	return b
+}
+
+// WithVoice sets the voice pronouncing the text: male or female.
+func (b *SayStepBuilder) WithVoice(voice string) *SayStepBuilder {
+	b.step.Voice = voice
+	return b
+}
+
+// Repeat pronounces the text n times, up to 10.
+func (b *SayStepBuilder) Repeat(n int) *SayStepBuilder {
+	b.step.Repeat = n
+	return b
+}
+
+// IfMachine sets what happens when a machine answers the call: continue,
+// delay or hangup. Machines are detected for timeout, if it is not 0.
+func (b *SayStepBuilder) IfMachine(action string, timeout time.Duration) *SayStepBuilder {
+	b.step.IfMachine = action
+	b.step.MachineTimeout = timeout
+	return b
+}
+
+// RecordStepBuilder sets the options of a record step. Its other methods
+// continue building the call flow.
+type RecordStepBuilder struct {
+	*CallFlowBuilder
+	step *CallFlowRecordStep
+}
+
+// MaxLength limits the duration of the recording.
+func (b *RecordStepBuilder) MaxLength(d time.Duration) *RecordStepBuilder {
+	b.step.MaxLength = d
+	return b
+}
+
+// StopOnSilence stops the recording after silence of d.
+func (b *RecordStepBuilder) StopOnSilence(d time.Duration) *RecordStepBuilder {
+	b.step.Timeout = d
+	return b
+}
+
+// FinishOnKey stops the recording when the caller presses key: any, # or *.
+func (b *RecordStepBuilder) FinishOnKey(key string) *RecordStepBuilder {
+	b.step.FinishOnKey = key
+	return b
+}
+
+// Transcribe transcribes the recording in language, e.g. en-US.
+func (b *RecordStepBuilder) Transcribe(language string) *RecordStepBuilder {
+	b.step.TranscribeLanguage = language
+	return b
+}
+
+// TransferStepBuilder sets the options of a transfer step. Its other methods
+// continue building the call flow.
+type TransferStepBuilder struct {
+	*CallFlowBuilder
+	step *CallFlowTransferStep
+}
+
+// WithRecording records side of the transferred call: in, out or both.
+func (b *TransferStepBuilder) WithRecording(side string) *TransferStepBuilder {
+	b.step.Record = side
+	return b
+}
+
+var (
+	languageRe    = regexp.MustCompile(`^[a-z]{2}-[A-Z]{2}(-[A-Z]{3})?$`)
+	destinationRe = regexp.MustCompile(`^\+?[0-9]+$`)
+
+	transcribeLanguages = []string{"de-DE", "en-AU", "en-UK", "en-US", "es-ES", "es-LA", "fr-FR", "it-IT", "nl-NL", "pt-BR"}
+)
+
+// Validate checks the options of the steps, and that no step follows one
+// that always ends the flow, i.e. a hangup or fetch step without conditions.
+// Steps jumped to on keypresses must exist. The first invalid step is
+// reported.
+func (callflow *CallFlow) Validate() error {
+	if len(callflow.Steps) == 0 {
+		return errors.New("call flow has no steps")
+	}
+
+	ids := make(map[string]bool)
+	for i, step := range callflow.Steps {
+		if step == nil {
+			return fmt.Errorf("step %d is nil", i+1)
+		}
+		if id := stepBase(step).ID; id != "" {
+			if ids[id] {
+				return fmt.Errorf("step %d: duplicate step ID %q", i+1, id)
+			}
+			ids[id] = true
+		}
+	}
+
+	for i, step := range callflow.Steps {
+		if err := validateStep(step); err != nil {
+			return fmt.Errorf("step %d (%s): %v", i+1, stepAction(step), err)
+		}
+		base := stepBase(step)
+		if base.OnKeypressGoto != "" && !ids[base.OnKeypressGoto] {
+			return fmt.Errorf("step %d (%s): no step with ID %q to go to", i+1, stepAction(step), base.OnKeypressGoto)
+		}
+		if i+1 < len(callflow.Steps) && len(base.Conditions) == 0 {
+			switch step.(type) {
+			case *CallFlowHangupStep, *CallFlowFetchStep:
+				return fmt.Errorf("step %d (%s): steps after it are never executed", i+1, stepAction(step))
+			}
+		}
+	}
+	return nil
+}
+
+func validateStep(step CallFlowStep) error {
+	switch s := step.(type) {
+	case *CallFlowSayStep:
+		if s.Payload == "" {
+			return errors.New("text is required")
+		}
+		if s.Language != "" && !languageRe.MatchString(s.Language) {
+			return fmt.Errorf("invalid language %q", s.Language)
+		}
+		if !oneOf(s.Voice, "", "male", "female") {
+			return fmt.Errorf("invalid voice %q", s.Voice)
+		}
+		if s.Repeat < 0 || s.Repeat > 10 {
+			return fmt.Errorf("repeat must be between 1 and 10, got %d", s.Repeat)
+		}
+		if !oneOf(s.IfMachine, "", "continue", "delay", "hangup") {
+			return fmt.Errorf("invalid ifMachine %q", s.IfMachine)
+		}
+		if s.MachineTimeout != 0 && (s.MachineTimeout < 400*time.Millisecond || s.MachineTimeout > 10*time.Second) {
+			return fmt.Errorf("machine timeout must be between 400ms and 10s, got %s", s.MachineTimeout)
+		}
+	case *CallFlowPlayStep:
+		return validateURL("media", s.Media)
+	case *CallFlowPauseStep:
+		if s.Length < time.Second {
+			return fmt.Errorf("length must be at least 1s, got %s", s.Length)
+		}
+	case *CallFlowRecordStep:
+		if s.MaxLength < 0 || s.Timeout < 0 {
+			return errors.New("max length and timeout can not be negative")
+		}
+		if !oneOf(s.FinishOnKey, "", "any", "#", "*", "none") {
+			return fmt.Errorf("invalid finishOnKey %q", s.FinishOnKey)
+		}
+		if s.TranscribeLanguage != "" && !oneOf(s.TranscribeLanguage, transcribeLanguages...) {
+			return fmt.Errorf("invalid transcribe language %q", s.TranscribeLanguage)
+		}
+	case *CallFlowTransferStep:
+		if !destinationRe.MatchString(s.Destination) && !strings.HasPrefix(s.Destination, "sip:") {
+			return fmt.Errorf("destination must be a number or SIP URI, got %q", s.Destination)
+		}
+		if !oneOf(s.Record, "", "in", "out", "both") {
+			return fmt.Errorf("invalid record %q", s.Record)
+		}
+	case *CallFlowFetchStep:
+		return validateURL("url", s.URL)
+	case *CallFlowHangupStep:
+	default:
+		return fmt.Errorf("unknown step type %T", step)
+	}
+	return nil
+}
+
+func validateURL(name, s string) error {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an HTTP(S) URL, got %q", name, s)
+	}
+	return nil
+}
+
+func oneOf(s string, values ...string) bool {
+	for _, v := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// stepBase returns the common properties of step.
+func stepBase(step CallFlowStep) *CallFlowStepBase {
+	switch s := step.(type) {
+	case *CallFlowTransferStep:
+		return &s.CallFlowStepBase
+	case *CallFlowSayStep:
+		return &s.CallFlowStepBase
+	case *CallFlowPlayStep:
+		return &s.CallFlowStepBase
+	case *CallFlowPauseStep:
+		return &s.CallFlowStepBase
+	case *CallFlowRecordStep:
+		return &s.CallFlowStepBase
+	case *CallFlowFetchStep:
+		return &s.CallFlowStepBase
+	case *CallFlowHangupStep:
+		return &s.CallFlowStepBase
+	}
+	return &CallFlowStepBase{}
+}
+
+// stepAction returns the action of step in the API.
+func stepAction(step CallFlowStep) string {
+	switch step.(type) {
+	case *CallFlowTransferStep:
+		return "transfer"
+	case *CallFlowSayStep:
+		return "say"
+	case *CallFlowPlayStep:
+		return "play"
+	case *CallFlowPauseStep:
+		return "pause"
+	case *CallFlowRecordStep:
+		return "record"
+	case *CallFlowFetchStep:
+		return "fetchCallFlow"
+	case *CallFlowHangupStep:
+		return "hangup"
+	}
+	return fmt.Sprintf("%T", step)
+}
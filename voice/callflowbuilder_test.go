@@ -0,0 +1,94 @@
+package voice
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCallFlowBuilder(t *testing.T) {
+	callflow, err := NewCallFlowBuilder("Support").
+		RecordCall().
+		Say("Press 1 for sales, or 2 for support.").InLanguage("en-GB").WithVoice("female").Repeat(2).
+		GatherKeypress("choice").
+		When("choice", "1", func(b *CallFlowBuilder) {
+			b.Transfer("31612345670").WithRecording("both")
+		}).
+		When("choice", "2", func(b *CallFlowBuilder) {
+			b.Say("Please leave a message.").InLanguage("en-GB").
+				Record().MaxLength(time.Minute).FinishOnKey("#").Transcribe("en-UK").
+				Hangup()
+		}).
+		Pause(2 * time.Second).
+		Hangup().
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	b, err := json.Marshal(struct {
+		Record bool           `json:"record"`
+		Steps  []CallFlowStep `json:"steps"`
+	}{callflow.Record, callflow.Steps})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `{"record":true,"steps":[` +
+		`{"onKeypressVar":"choice","action":"say","options":{"payload":"Press 1 for sales, or 2 for support.","voice":"female","language":"en-GB","repeat":2}},` +
+		`{"conditions":[{"variable":"choice","operator":"==","value":"1"}],"action":"transfer","options":{"destination":"31612345670","record":"both"}},` +
+		`{"conditions":[{"variable":"choice","operator":"==","value":"2"}],"action":"say","options":{"payload":"Please leave a message.","language":"en-GB"}},` +
+		`{"conditions":[{"variable":"choice","operator":"==","value":"2"}],"action":"record","options":{"maxLength":60,"finishOnKey":"#","transcribeLanguage":"en-UK"}},` +
+		`{"conditions":[{"variable":"choice","operator":"==","value":"2"}],"action":"hangup"},` +
+		`{"action":"pause","options":{"length":2}},` +
+		`{"action":"hangup"}]}`
+	if string(b) != expected {
+		t.Errorf("got %s, expected %s", b, expected)
+	}
+}
+
+func TestCallFlowBuilderInvalid(t *testing.T) {
+	tt := []struct {
+		name string
+		b    *CallFlowBuilder
+		e    string
+	}{
+		{"empty", NewCallFlowBuilder("t"), "no steps"},
+		{"say without text", NewCallFlowBuilder("t").Say("").CallFlowBuilder, "step 1 (say): text is required"},
+		{"language", NewCallFlowBuilder("t").Say("Hello").InLanguage("english").CallFlowBuilder, "invalid language"},
+		{"repeat", NewCallFlowBuilder("t").Say("Hello").Repeat(11).CallFlowBuilder, "repeat must be between"},
+		{"machine timeout", NewCallFlowBuilder("t").Say("Hello").IfMachine("hangup", time.Minute).CallFlowBuilder, "machine timeout"},
+		{"media", NewCallFlowBuilder("t").Play("hold.wav"), "media must be an HTTP(S) URL"},
+		{"pause", NewCallFlowBuilder("t").Pause(time.Millisecond), "length must be at least 1s"},
+		{"finish key", NewCallFlowBuilder("t").Record().FinishOnKey("1").CallFlowBuilder, "invalid finishOnKey"},
+		{"destination", NewCallFlowBuilder("t").Transfer("support").CallFlowBuilder, "destination must be"},
+		{"after hangup", NewCallFlowBuilder("t").Hangup().Say("Bye").CallFlowBuilder, "step 1 (hangup): steps after it are never executed"},
+		{"after fetch", NewCallFlowBuilder("t").Fetch("https://example.com/flow").Hangup(), "step 1 (fetchCallFlow)"},
+		{"option without step", NewCallFlowBuilder("t").WithStepID("start").Hangup(), "WithStepID requires a step"},
+		{"duplicate ID", NewCallFlowBuilder("t").Say("a").WithStepID("x").Say("b").WithStepID("x"), "duplicate step ID"},
+		{"unknown goto", NewCallFlowBuilder("t").Say("a").GotoOnKeypress("missing"), `no step with ID "missing"`},
+		{"branch without gather", NewCallFlowBuilder("t").Say("a").When("choice", "1", func(b *CallFlowBuilder) { b.Hangup() }), `branch on "choice"`},
+		{"option without branch step", NewCallFlowBuilder("t").Say("a").GatherKeypress("k").When("k", "1", func(b *CallFlowBuilder) { b.WithStepID("x") }), "WithStepID requires a step"},
+		{"invalid branch step", NewCallFlowBuilder("t").Say("a").GatherKeypress("k").When("k", "1", func(b *CallFlowBuilder) { b.Pause(0) }), "step 2 (pause)"},
+	}
+
+	for _, tc := range tt {
+		_, err := tc.b.Build()
+		if err == nil || !strings.Contains(err.Error(), tc.e) {
+			t.Errorf("got %v, expected an error containing %q, test case: %s", err, tc.e, tc.name)
+		}
+	}
+}
+
+func TestCallFlowBuilderGoto(t *testing.T) {
+	callflow, err := NewCallFlowBuilder("t").
+		Say("Welcome").WithStepID("welcome").
+		Say("Press any key to hear this again.").GotoOnKeypress("welcome").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if base := stepBase(callflow.Steps[1]); base.OnKeypressGoto != "welcome" {
+		t.Errorf("got goto %q, expected welcome", base.OnKeypressGoto)
+	}
+}
@@ -3,6 +3,7 @@ package voice
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
 	"time"
 
@@ -66,7 +67,7 @@ type Leg struct {
 	// outgoing.
 	Direction LegDirection
 	// The cost of the leg. The amount relates to the currency parameter.
-	Cost float64
+	Cost messagebird.Decimal
 	// The three-letter currency code (ISO 4217) related to the cost of the
 	// leg.
 	Currency string
@@ -85,19 +86,19 @@ type Leg struct {
 }
 
 type jsonLeg struct {
-	ID          string  `json:"id"`
-	CallID      string  `json:"callID"`
-	Source      string  `json:"source"`
-	Destination string  `json:"destination"`
-	Status      string  `json:"status"`
-	Direction   string  `json:"direction"`
-	Cost        float64 `json:"cost"`
-	Currency    string  `json:"currency"`
-	Duration    int     `json:"duration"`
-	CreatedAt   string  `json:"createdAt"`
-	UpdatedAt   string  `json:"updatedAt"`
-	AnsweredAt  string  `json:"answeredAt,omitempty"`
-	EndedAt     string  `json:"endedAt,omitempty"`
+	ID          string              `json:"id"`
+	CallID      string              `json:"callID"`
+	Source      string              `json:"source"`
+	Destination string              `json:"destination"`
+	Status      string              `json:"status"`
+	Direction   string              `json:"direction"`
+	Cost        messagebird.Decimal `json:"cost"`
+	Currency    string              `json:"currency"`
+	Duration    int                 `json:"duration"`
+	CreatedAt   string              `json:"createdAt"`
+	UpdatedAt   string              `json:"updatedAt"`
+	AnsweredAt  string              `json:"answeredAt,omitempty"`
+	EndedAt     string              `json:"endedAt,omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -115,8 +116,8 @@ func (leg *Leg) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("unable to parse Leg UpdatedAt: %v", err)
 	}
 	var answeredAt *time.Time
-	if raw.EndedAt != "" {
-		aat, err := time.Parse(time.RFC3339, raw.EndedAt)
+	if raw.AnsweredAt != "" {
+		aat, err := time.Parse(time.RFC3339, raw.AnsweredAt)
 		if err != nil {
 			return fmt.Errorf("unable to parse Leg AnsweredAt: %v", err)
 		}
@@ -148,6 +149,19 @@ func (leg *Leg) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// LegByID fetches a leg of a call by its ID.
+//
+// An error is returned if no such leg exists or is accessible.
+func LegByID(client *messagebird.Client, callID, id string) (*Leg, error) {
+	var resp struct {
+		Data []Leg `json:"data"`
+	}
+	if err := client.Request(&resp, http.MethodGet, fmt.Sprintf("%s/calls/%s/legs/%s", apiRoot, callID, id), nil); err != nil {
+		return nil, err
+	}
+	return first(resp.Data)
+}
+
 // Recordings retrieves the Recording objects associated with a leg.
 func (leg *Leg) Recordings(client *messagebird.Client) *Paginator {
 	return newPaginator(client, fmt.Sprintf("%s/calls/%s/legs/%s/recordings", apiRoot, leg.CallID, leg.ID), reflect.TypeOf(Recording{}))
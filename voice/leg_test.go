@@ -0,0 +1,39 @@
+package voice
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLegByID(t *testing.T) {
+	mbClient, stop := testRequest(http.StatusOK, []byte(`{
+		"data": [{
+			"id": "d4f07ab3-b17c-44a8-bcef-2b351311c28f",
+			"callID": "f1aa71c0-8f2a-4fe8-b5ef-9a330454ef58",
+			"source": "31644556677",
+			"destination": "31612345678",
+			"status": "hangup",
+			"direction": "outgoing",
+			"cost": 0.000754,
+			"currency": "USD",
+			"duration": 12,
+			"createdAt": "2017-03-06T13:34:14Z",
+			"updatedAt": "2017-03-06T13:34:28Z",
+			"answeredAt": "2017-03-06T13:34:16Z",
+			"endedAt": "2017-03-06T13:34:28Z"
+		}]
+	}`))
+	defer stop()
+
+	leg, err := LegByID(mbClient, "f1aa71c0-8f2a-4fe8-b5ef-9a330454ef58", "d4f07ab3-b17c-44a8-bcef-2b351311c28f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leg.Direction != LegDirectionOutgoing || leg.Status != LegStatusHangup || leg.Duration != 12*time.Second {
+		t.Errorf("unexpected leg: %+v", leg)
+	}
+	if exp := time.Date(2017, 3, 6, 13, 34, 16, 0, time.UTC); leg.AnsweredAt == nil || !leg.AnsweredAt.Equal(exp) {
+		t.Errorf("got answered at %v, expected %s", leg.AnsweredAt, exp)
+	}
+}
@@ -10,8 +10,13 @@ import (
 	"testing"
 
 	"github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/internal/mbtest"
 )
 
+func TestMain(m *testing.M) {
+	mbtest.EnableServer(m)
+}
+
 func testRequest(status int, body []byte) (*messagebird.Client, func()) {
 	mbServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"reflect"
-	"runtime"
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api"
@@ -93,28 +93,72 @@ func (rec *Recording) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// RecordingByID fetches a recording of a leg by its ID.
+//
+// An error is returned if no such recording exists or is accessible.
+func RecordingByID(client *messagebird.Client, callID, legID, id string) (*Recording, error) {
+	var resp struct {
+		Data []Recording `json:"data"`
+	}
+	path := fmt.Sprintf("%s/calls/%s/legs/%s/recordings/%s", apiRoot, callID, legID, id)
+	if err := client.Request(&resp, http.MethodGet, path, nil); err != nil {
+		return nil, err
+	}
+	return first(resp.Data)
+}
+
 // Transcriptions returns a paginator for retrieving all Transcription objects.
 func (rec *Recording) Transcriptions(client *messagebird.Client) *Paginator {
-	path := rec.links["self"] + "/transcriptions"
+	path := apiRoot + rec.links["self"] + "/transcriptions"
 	return newPaginator(client, path, reflect.TypeOf(Transcription{}))
 }
 
+// CreateTranscription requests an automated transcription of the recording
+// in the given language, e.g. "en-US". The transcription is available for
+// download once it is done.
+func (rec *Recording) CreateTranscription(client *messagebird.Client, language string) (*Transcription, error) {
+	body := struct {
+		Language string `json:"language"`
+	}{language}
+	var resp struct {
+		Data []Transcription `json:"data"`
+	}
+	if err := client.Request(&resp, http.MethodPost, apiRoot+rec.links["self"]+"/transcriptions", body); err != nil {
+		return nil, err
+	}
+	return first(resp.Data)
+}
+
 // DownloadFile streams the recorded WAV file.
 func (rec *Recording) DownloadFile(client *messagebird.Client) (io.ReadCloser, error) {
-	req, err := http.NewRequest(http.MethodGet, apiRoot+rec.links["file"], nil)
+	req, err := http.NewRequestWithContext(client.Context(), http.MethodGet, client.ResolveURL(apiRoot+rec.links["file"]), nil)
 	if err != nil {
 		return nil, err
 	}
+	if err := client.SetHeaders(req); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Accept", "audio/*")
-	req.Header.Set("Authorization", "AccessKey "+client.AccessKey)
-	req.Header.Set("User-Agent", "MessageBird/ApiClient/"+messagebird.ClientVersion+" Go/"+runtime.Version())
 
-	resp, err := client.HTTPClient.Do(req)
+	resp, err := client.Doer().Do(req)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bad HTTP status: %d", resp.StatusCode)
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, messagebird.ResponseError(resp, b)
 	}
 	return resp.Body, nil
 }
+
+// WriteFile writes the recorded WAV file to w, without buffering it in
+// memory, and returns the number of bytes written.
+func (rec *Recording) WriteFile(client *messagebird.Client, w io.Writer) (int64, error) {
+	r, err := rec.DownloadFile(client)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(w, r)
+}
@@ -1,9 +1,13 @@
 package voice
 
 import (
+	"bytes"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"testing"
+
+	messagebird "github.com/messagebird/go-rest-api"
 )
 
 func TestRecordingGetFile(t *testing.T) {
@@ -31,3 +35,62 @@ func TestRecordingGetFile(t *testing.T) {
 		t.Fatalf("mismatched downloaded contents")
 	}
 }
+
+func TestRecordingWriteFile(t *testing.T) {
+	fileContents := []byte("this is not really a WAV file")
+	mbClient, stop := testRequest(http.StatusOK, fileContents)
+	defer stop()
+
+	rec := &Recording{
+		ID: "1337",
+		links: map[string]string{
+			"file": "/yolo/swag.wav",
+		},
+	}
+	var buf bytes.Buffer
+	n, err := rec.WriteFile(mbClient, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(fileContents)) || buf.String() != string(fileContents) {
+		t.Fatalf("mismatched written contents: %q (%d bytes)", buf.String(), n)
+	}
+}
+
+func TestRecordingCreateTranscription(t *testing.T) {
+	mbClient, stop := testRequest(http.StatusCreated, []byte(`{
+		"data": [{
+			"id": "87c377ce-1629-48b6-ad01-4b76fec2ec4b",
+			"recordingID": "1337",
+			"error": null,
+			"createdAt": "2018-11-28T11:04:09Z",
+			"updatedAt": "2018-11-28T11:04:09Z",
+			"_links": {"file": "/calls/c/legs/l/recordings/1337/transcriptions/87c377ce-1629-48b6-ad01-4b76fec2ec4b.txt"}
+		}]
+	}`))
+	defer stop()
+
+	rec := &Recording{
+		ID: "1337",
+		links: map[string]string{
+			"self": "/calls/c/legs/l/recordings/1337",
+		},
+	}
+	trans, err := rec.CreateTranscription(mbClient, "en-US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trans.RecordingID != "1337" {
+		t.Fatalf("got recording ID %q, expected 1337", trans.RecordingID)
+	}
+}
+
+func TestRecordingGetFileError(t *testing.T) {
+	mbClient, stop := testRequest(http.StatusServiceUnavailable, []byte("<html>Service Unavailable</html>"))
+	defer stop()
+
+	rec := &Recording{ID: "1337", links: map[string]string{"file": "/yolo/swag.wav"}}
+	if _, err := rec.DownloadFile(mbClient); !errors.Is(err, messagebird.ErrUnexpectedResponse) {
+		t.Errorf("got %v, expected %v", err, messagebird.ErrUnexpectedResponse)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"runtime"
 	"time"
 
 	messagebird "github.com/messagebird/go-rest-api"
@@ -72,20 +71,23 @@ func (trans *Transcription) UnmarshalJSON(data []byte) error {
 //
 // This is a plain text file.
 func (trans *Transcription) Contents(client *messagebird.Client) (string, error) {
-	req, err := http.NewRequest(http.MethodGet, apiRoot+trans.links["file"], nil)
+	req, err := http.NewRequestWithContext(client.Context(), http.MethodGet, client.ResolveURL(apiRoot+trans.links["file"]), nil)
 	if err != nil {
 		return "", err
 	}
+	if err := client.SetHeaders(req); err != nil {
+		return "", err
+	}
 	req.Header.Set("Accept", "text/plain")
-	req.Header.Set("Authorization", "AccessKey "+client.AccessKey)
-	req.Header.Set("User-Agent", "MessageBird/ApiClient/"+messagebird.ClientVersion+" Go/"+runtime.Version())
 
-	resp, err := client.HTTPClient.Do(req)
+	resp, err := client.Doer().Do(req)
 	if err != nil {
 		return "", err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("bad HTTP status: %d", resp.StatusCode)
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return "", messagebird.ResponseError(resp, b)
 	}
 
 	defer resp.Body.Close()
@@ -1,3 +1,18 @@
 package voice
 
-const apiRoot = "https://voice.messagebird.com"
+import (
+	"errors"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+const apiRoot = messagebird.VoiceEndpoint
+
+// first returns the first object of a response's data, which holds the
+// object for requests about a single one.
+func first[T any](data []T) (*T, error) {
+	if len(data) == 0 {
+		return nil, errors.New("response contains no data")
+	}
+	return &data[0], nil
+}
@@ -11,7 +11,8 @@ import (
 )
 
 // A Webhook is an HTTP callback to your platform. They are sent when calls are
-// created and updated.
+// created and updated. The Voice API sends all call events to each webhook;
+// the Token is used to sign them.
 type Webhook struct {
 	ID        string
 	URL       string
@@ -64,6 +65,19 @@ func (wh *Webhook) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// WebhookByID fetches a webhook by its ID.
+//
+// An error is returned if no such webhook exists or is accessible.
+func WebhookByID(client *messagebird.Client, id string) (*Webhook, error) {
+	var resp struct {
+		Data []Webhook `json:"data"`
+	}
+	if err := client.Request(&resp, http.MethodGet, apiRoot+"/webhooks/"+id, nil); err != nil {
+		return nil, err
+	}
+	return first(resp.Data)
+}
+
 // Webhooks returns a paginator over all webhooks.
 func Webhooks(client *messagebird.Client) *Paginator {
 	return newPaginator(client, apiRoot+"/webhooks/", reflect.TypeOf(Webhook{}))
@@ -85,10 +99,10 @@ func CreateWebHook(client *messagebird.Client, url, token string) (*Webhook, err
 	if err := client.Request(&resp, http.MethodPost, apiRoot+"/webhooks/", data); err != nil {
 		return nil, err
 	}
-	return &resp.Data[0], nil
+	return first(resp.Data)
 }
 
-// Update syncs hte local state of a webhook to the MessageBird API.
+// Update syncs the local state of a webhook to the MessageBird API.
 func (wh *Webhook) Update(client *messagebird.Client) error {
 	var data struct {
 		Data []Webhook `json:"data"`
@@ -96,7 +110,11 @@ func (wh *Webhook) Update(client *messagebird.Client) error {
 	if err := client.Request(&data, http.MethodPut, apiRoot+"/webhooks/"+wh.ID, wh); err != nil {
 		return err
 	}
-	*wh = data.Data[0]
+	updated, err := first(data.Data)
+	if err != nil {
+		return err
+	}
+	*wh = *updated
 	return nil
 }
 
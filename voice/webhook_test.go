@@ -1,6 +1,7 @@
 package voice
 
 import (
+	"net/http"
 	"testing"
 )
 
@@ -43,3 +44,25 @@ func TestWebhookList(t *testing.T) {
 		t.Fatal("no webhooks were fetched")
 	}
 }
+
+func TestWebhookByID(t *testing.T) {
+	mbClient, stop := testRequest(http.StatusOK, []byte(`{"data":[{"id":"whid","url":"https://example.com/voice-webhook","token":"token","createdAt":"2019-03-28T13:00:00Z","updatedAt":"2019-03-28T13:00:00Z"}]}`))
+	defer stop()
+
+	wh, err := WebhookByID(mbClient, "whid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wh.ID != "whid" || wh.Token != "token" {
+		t.Fatalf("Unexpected webhook: %+v", wh)
+	}
+}
+
+func TestCreateWebhookEmptyData(t *testing.T) {
+	mbClient, stop := testRequest(http.StatusOK, []byte(`{"data":[]}`))
+	defer stop()
+
+	if _, err := CreateWebHook(mbClient, "https://example.com/voice-webhook", "token"); err == nil {
+		t.Fatal("expected error for response without webhook, got nil")
+	}
+}
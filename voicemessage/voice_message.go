@@ -39,13 +39,26 @@ type VoiceMessageList struct {
 type Params struct {
 	Originator        string
 	Reference         string
-	Language          string
-	Voice             string
-	Repeat            int
-	IfMachine         string
+	Language          string // The language of the body, e.g. "en-gb".
+	Voice             string // VoiceMale or VoiceFemale.
+	Repeat            int    // The number of times the body is repeated.
+	IfMachine         string // IfMachineContinue, IfMachineDelay or IfMachineHangup.
 	ScheduledDatetime time.Time
 }
 
+// The voices used to read out the body.
+const (
+	VoiceMale   = "male"
+	VoiceFemale = "female"
+)
+
+// The behaviors when an answering machine picks up the call.
+const (
+	IfMachineContinue = "continue" // Read out the body right away.
+	IfMachineDelay    = "delay"    // Wait for the greeting to end.
+	IfMachineHangup   = "hangup"   // Do not leave a message.
+)
+
 type voiceMessageRequest struct {
 	Recipients        []string `json:"recipients"`
 	Body              string   `json:"body"`
@@ -119,7 +132,9 @@ func requestDataForVoiceMessage(recipients []string, body string, params *Params
 	request.Voice = params.Voice
 	request.Repeat = params.Repeat
 	request.IfMachine = params.IfMachine
-	request.ScheduledDatetime = params.ScheduledDatetime.Format(time.RFC3339)
+	if !params.ScheduledDatetime.IsZero() {
+		request.ScheduledDatetime = params.ScheduledDatetime.Format(time.RFC3339)
+	}
 
 	return request, nil
 }
@@ -230,3 +230,14 @@ func TestRequestDataForVoiceMessage(t *testing.T) {
 		t.Errorf("Unexpected scheduled date time: %s, expected: %s", request.ScheduledDatetime, voiceParams.ScheduledDatetime.Format(time.RFC3339))
 	}
 }
+
+func TestRequestDataForVoiceMessageWithoutSchedule(t *testing.T) {
+	request, err := requestDataForVoiceMessage([]string{"31612345678"}, "MyBody", &Params{Voice: VoiceFemale})
+	if err != nil {
+		t.Fatalf("Didn't expect error while getting request data for voice message: %s", err)
+	}
+
+	if request.ScheduledDatetime != "" {
+		t.Errorf("Unexpected scheduled date time: %s, expected none", request.ScheduledDatetime)
+	}
+}
@@ -0,0 +1,204 @@
+package webhookrouter
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/messagebird/go-rest-api/hlr"
+	"github.com/messagebird/go-rest-api/webhooks"
+)
+
+// Store records the keys of the webhook events that have been processed, so
+// a Router with deduplication enabled skips events MessageBird delivers
+// again, e.g. because an earlier response did not arrive in time. Stores must
+// be safe for concurrent use.
+type Store interface {
+	// Add records key and reports whether it was not recorded yet. Adding a
+	// key must be atomic: only one of concurrent calls with the same key may
+	// return true.
+	Add(ctx context.Context, key string) (bool, error)
+
+	// Remove forgets key, so the event is processed when it is delivered
+	// again.
+	Remove(ctx context.Context, key string) error
+}
+
+// Deduplicate makes the router call handlers at most once for each event,
+// using store to record the processed events. The key of an event is built
+// from the ID of the message, call or other resource, its status and the
+// time of the event. If a handler returns an error the key is removed again,
+// so the event is processed when MessageBird retries it.
+//
+// A webhook is answered with 500 Internal Server Error when store fails.
+func (rt *Router) Deduplicate(store Store) {
+	rt.store = store
+}
+
+// claim adds key to the store, if any, and reports whether the event must be
+// processed.
+func (rt *Router) claim(ctx context.Context, key string) (bool, error) {
+	if rt.store == nil {
+		return true, nil
+	}
+	return rt.store.Add(ctx, key)
+}
+
+// release removes key from the store, if any.
+func (rt *Router) release(ctx context.Context, key string) error {
+	if rt.store == nil {
+		return nil
+	}
+	return rt.store.Remove(ctx, key)
+}
+
+// statusReportKey returns the deduplication key of a status report. A message
+// has a status report for each status of each recipient.
+func statusReportKey(r *webhooks.StatusReport) string {
+	return joinKey("sms", r.ID, r.Recipient, string(r.Status), formatTime(&r.StatusDatetime))
+}
+
+// hlrKey returns the deduplication key of an HLR result.
+func hlrKey(h *hlr.HLR) string {
+	return joinKey("hlr", h.ID, h.Status, formatTime(h.StatusDatetime))
+}
+
+// conversationEventKey returns the deduplication key of a Conversations
+// event.
+func conversationEventKey(e *webhooks.ConversationEvent) string {
+	if e.Message != nil {
+		return joinKey("conversation", string(e.Type), e.Message.ID, string(e.Message.Status), formatTime(e.Message.UpdatedDatetime))
+	}
+	var id, updated string
+	if e.Conversation != nil {
+		id, updated = e.Conversation.ID, formatTime(e.Conversation.UpdatedDatetime)
+	}
+	return joinKey("conversation", string(e.Type), id, updated)
+}
+
+// voiceEventKey returns the deduplication key of a voice event.
+func voiceEventKey(e *webhooks.VoiceEvent) string {
+	var id, status string
+	switch {
+	case e.Call != nil:
+		id, status = e.Call.ID, string(e.Call.Status)
+	case e.Leg != nil:
+		id, status = e.Leg.ID, string(e.Leg.Status)
+	case e.Recording != nil:
+		id, status = e.Recording.ID, string(e.Recording.Status)
+	case e.Transcription != nil:
+		id = e.Transcription.ID
+	}
+	return joinKey("voice", e.Event, id, status, formatTime(&e.Timestamp))
+}
+
+func joinKey(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// MemoryStore is a Store that keeps the most recently added keys in memory.
+// It only deduplicates webhooks received by the same process; use a shared
+// store such as the RedisStore when running multiple instances.
+type MemoryStore struct {
+	size int
+
+	mu    sync.Mutex
+	order *list.List
+	keys  map[string]*list.Element
+}
+
+// NewMemoryStore returns a store that remembers up to size keys. When it is
+// full, the least recently added key is evicted.
+func NewMemoryStore(size int) *MemoryStore {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryStore{
+		size:  size,
+		order: list.New(),
+		keys:  make(map[string]*list.Element),
+	}
+}
+
+// Add implements Store.
+func (s *MemoryStore) Add(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[key]; ok {
+		return false, nil
+	}
+	s.keys[key] = s.order.PushFront(key)
+	if s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.keys, oldest.Value.(string))
+	}
+	return true, nil
+}
+
+// Remove implements Store.
+func (s *MemoryStore) Remove(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.keys[key]; ok {
+		s.order.Remove(e)
+		delete(s.keys, key)
+	}
+	return nil
+}
+
+// RedisClient is the subset of Redis commands used by the RedisStore. This
+// package does not depend on a Redis client; wrap the one you use, e.g. for
+// github.com/redis/go-redis:
+//
+//	type redisClient struct{ *redis.Client }
+//
+//	func (c redisClient) SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+//		return c.Client.SetNX(ctx, key, 1, ttl).Result()
+//	}
+//
+//	func (c redisClient) Del(ctx context.Context, key string) error {
+//		return c.Client.Del(ctx, key).Err()
+//	}
+type RedisClient interface {
+	// SetNX sets key if it does not exist, expiring it after ttl, and
+	// reports whether it was set.
+	SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Del(ctx context.Context, key string) error
+}
+
+// RedisStore is a Store that records keys in Redis, so multiple instances
+// receiving webhooks share them.
+type RedisStore struct {
+	client RedisClient
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisStore returns a store that records keys with client, prefixed with
+// prefix, for ttl. The ttl must exceed the period over which MessageBird
+// retries webhooks.
+func NewRedisStore(client RedisClient, prefix string, ttl time.Duration) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix, ttl: ttl}
+}
+
+// Add implements Store.
+func (s *RedisStore) Add(ctx context.Context, key string) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+key, s.ttl)
+}
+
+// Remove implements Store.
+func (s *RedisStore) Remove(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key)
+}
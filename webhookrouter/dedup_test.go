@@ -0,0 +1,125 @@
+package webhookrouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/signature"
+	"github.com/messagebird/go-rest-api/webhooks"
+)
+
+func TestRouterDeduplicate(t *testing.T) {
+	var calls int
+	fail := true
+	rt := New(signature.NewValidator(testKey))
+	rt.Deduplicate(NewMemoryStore(10))
+	rt.OnMessageStatus(func(context.Context, *webhooks.StatusReport) error {
+		calls++
+		if fail {
+			fail = false
+			return errors.New("database down")
+		}
+		return nil
+	})
+
+	target := "/webhooks?" + testStatusReportQuery
+	var statuses []int
+	for i := 0; i < 3; i++ {
+		statuses = append(statuses, serve(t, rt, http.MethodGet, target, ""))
+	}
+
+	if statuses[0] != http.StatusInternalServerError || statuses[1] != http.StatusOK || statuses[2] != http.StatusOK {
+		t.Errorf("got statuses %v, expected [500 200 200]", statuses)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, expected 2: the failed and the retried delivery", calls)
+	}
+
+	// Another status of the same message is a different event.
+	serve(t, rt, http.MethodGet, "/webhooks?id=efa6405d518d4c0c88cce11f7db775fb&recipient=31612345678&status=sent&statusDatetime=2020-03-08T12%3A29%3A00%2B00%3A00", "")
+	if calls != 3 {
+		t.Errorf("got %d calls, expected 3 after a new status", calls)
+	}
+}
+
+func TestRouterDeduplicateStoreError(t *testing.T) {
+	rt := New(signature.NewValidator(testKey))
+	rt.Deduplicate(NewRedisStore(&fakeRedis{err: errors.New("connection refused")}, "mb:", time.Hour))
+
+	if code := serve(t, rt, http.MethodGet, "/webhooks?"+testStatusReportQuery, ""); code != http.StatusInternalServerError {
+		t.Errorf("got status %d, expected 500", code)
+	}
+}
+
+func TestMemoryStoreEvicts(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(2)
+
+	for _, key := range []string{"a", "b", "c"} {
+		if ok, _ := s.Add(ctx, key); !ok {
+			t.Errorf("expected %s to be added", key)
+		}
+	}
+	if ok, _ := s.Add(ctx, "c"); ok {
+		t.Errorf("expected c to be recorded")
+	}
+	if ok, _ := s.Add(ctx, "a"); !ok {
+		t.Errorf("expected a to be evicted")
+	}
+
+	s.Remove(ctx, "a")
+	if ok, _ := s.Add(ctx, "a"); !ok {
+		t.Errorf("expected a to be removed")
+	}
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeRedis{}
+	s := NewRedisStore(fake, "mb:", time.Hour)
+
+	if ok, err := s.Add(ctx, "key"); !ok || err != nil {
+		t.Fatalf("got %v and %v, expected key to be added", ok, err)
+	}
+	if ok, _ := s.Add(ctx, "key"); ok {
+		t.Errorf("expected key to be recorded")
+	}
+	if _, ok := fake.keys["mb:key"]; !ok || fake.ttl != time.Hour {
+		t.Errorf("got keys %v with ttl %s, expected mb:key with ttl 1h", fake.keys, fake.ttl)
+	}
+
+	if err := s.Remove(ctx, "key"); err != nil {
+		t.Fatalf("unexpected error removing key: %s", err)
+	}
+	if len(fake.keys) != 0 {
+		t.Errorf("got keys %v, expected none", fake.keys)
+	}
+}
+
+type fakeRedis struct {
+	err  error
+	keys map[string]bool
+	ttl  time.Duration
+}
+
+func (f *fakeRedis) SetNX(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	if f.keys == nil {
+		f.keys = make(map[string]bool)
+	}
+	if f.keys[key] {
+		return false, nil
+	}
+	f.keys[key], f.ttl = true, ttl
+	return true, nil
+}
+
+func (f *fakeRedis) Del(_ context.Context, key string) error {
+	delete(f.keys, key)
+	return f.err
+}
@@ -0,0 +1,242 @@
+/*
+Package webhookrouter receives all MessageBird webhooks on a single endpoint.
+It validates their signatures, decodes them with the webhooks package and
+dispatches them to the handlers registered for their events:
+
+	router := webhookrouter.New(signature.NewValidator("your signing key"))
+	router.OnMessageStatus(func(ctx context.Context, r *webhooks.StatusReport) error {
+		return store.SetStatus(ctx, r.ID, r.Recipient, r.Status)
+	})
+	router.OnHLR(func(ctx context.Context, h *hlr.HLR) error {
+		return store.SetReachable(ctx, h.MSISDN, h.Status == hlr.StatusActive)
+	})
+	router.OnInboundMessage(func(ctx context.Context, e *webhooks.ConversationEvent) error {
+		return inbox.Add(ctx, e.Message)
+	})
+	http.Handle("/webhooks", router)
+
+Requests with invalid signatures are rejected by the validator. Malformed
+webhooks are answered with 400 Bad Request, and webhooks for which a handler
+returns an error with 500 Internal Server Error, so MessageBird retries them.
+Other webhooks are acknowledged with 200 OK, also when no handler is
+registered for them.
+
+MessageBird retries webhooks that are not acknowledged, so handlers may be
+called more than once for the same event. Call Deduplicate with a Store to
+skip events that were processed before:
+
+	router.Deduplicate(webhookrouter.NewMemoryStore(10000))
+*/
+package webhookrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/messagebird/go-rest-api/conversation"
+	"github.com/messagebird/go-rest-api/hlr"
+	"github.com/messagebird/go-rest-api/voice"
+	"github.com/messagebird/go-rest-api/webhooks"
+)
+
+// Validator validates the signature of webhooks, such as the validators of
+// the signature package.
+type Validator interface {
+	Validate(h http.Handler) http.Handler
+}
+
+// Router dispatches webhooks to the registered handlers. Handlers must be
+// registered before the router serves requests. A handler is only called for
+// the events it is registered for, and all handlers registered for an event
+// are called in the order they were registered.
+type Router struct {
+	handler http.Handler
+	store   Store
+
+	statusHandlers       []func(context.Context, *webhooks.StatusReport) error
+	hlrHandlers          []func(context.Context, *hlr.HLR) error
+	conversationHandlers []func(context.Context, *webhooks.ConversationEvent) error
+	voiceHandlers        []func(context.Context, *webhooks.VoiceEvent) error
+}
+
+// New returns a router that validates webhooks with v.
+func New(v Validator) *Router {
+	rt := &Router{}
+	rt.handler = v.Validate(http.HandlerFunc(rt.dispatch))
+	return rt
+}
+
+// OnMessageStatus registers fn for the status reports of SMS messages.
+func (rt *Router) OnMessageStatus(fn func(context.Context, *webhooks.StatusReport) error) {
+	rt.statusHandlers = append(rt.statusHandlers, fn)
+}
+
+// OnHLR registers fn for the results of HLR lookups.
+func (rt *Router) OnHLR(fn func(context.Context, *hlr.HLR) error) {
+	rt.hlrHandlers = append(rt.hlrHandlers, fn)
+}
+
+// OnConversationEvent registers fn for all Conversations events.
+func (rt *Router) OnConversationEvent(fn func(context.Context, *webhooks.ConversationEvent) error) {
+	rt.conversationHandlers = append(rt.conversationHandlers, fn)
+}
+
+// OnInboundMessage registers fn for messages received from contacts through
+// the Conversations API.
+func (rt *Router) OnInboundMessage(fn func(context.Context, *webhooks.ConversationEvent) error) {
+	rt.OnConversationEvent(func(ctx context.Context, e *webhooks.ConversationEvent) error {
+		if e.Type != conversation.WebhookEventMessageCreated || !e.Inbound() {
+			return nil
+		}
+		return fn(ctx, e)
+	})
+}
+
+// OnVoiceEvent registers fn for all voice events.
+func (rt *Router) OnVoiceEvent(fn func(context.Context, *webhooks.VoiceEvent) error) {
+	rt.voiceHandlers = append(rt.voiceHandlers, fn)
+}
+
+// OnCallEnded registers fn for calls that ended.
+func (rt *Router) OnCallEnded(fn func(context.Context, *voice.Call) error) {
+	rt.onVoiceEvent(webhooks.VoiceEventCallEnded, func(ctx context.Context, e *webhooks.VoiceEvent) error {
+		return fn(ctx, e.Call)
+	})
+}
+
+// OnRecordingAvailable registers fn for recordings that can be downloaded.
+func (rt *Router) OnRecordingAvailable(fn func(context.Context, *voice.Recording) error) {
+	rt.onVoiceEvent(webhooks.VoiceEventRecordingAvailable, func(ctx context.Context, e *webhooks.VoiceEvent) error {
+		return fn(ctx, e.Recording)
+	})
+}
+
+// onVoiceEvent registers fn for voice events of kind.
+func (rt *Router) onVoiceEvent(kind webhooks.VoiceEventKind, fn func(context.Context, *webhooks.VoiceEvent) error) {
+	rt.OnVoiceEvent(func(ctx context.Context, e *webhooks.VoiceEvent) error {
+		if e.Kind != kind {
+			return nil
+		}
+		return fn(ctx, e)
+	})
+}
+
+// ServeHTTP implements http.Handler.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.handler.ServeHTTP(w, r)
+}
+
+// dispatch decodes a validated webhook and calls its handlers. Status
+// reports and HLR results are sent as query or form parameters, told apart
+// by their recipient or MSISDN, while Conversations and voice webhooks have
+// a JSON body, told apart by their top-level properties.
+func (rt *Router) dispatch(w http.ResponseWriter, r *http.Request) {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	switch {
+	case isVoice(b):
+		events, err := webhooks.DecodeVoiceEvents(bytes.NewReader(b))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, e := range events {
+			if !call(rt, w, r.Context(), voiceEventKey(e), rt.voiceHandlers, e) {
+				return
+			}
+		}
+	case isJSON(b):
+		e, err := webhooks.DecodeConversationEvent(bytes.NewReader(b))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !call(rt, w, r.Context(), conversationEventKey(e), rt.conversationHandlers, e) {
+			return
+		}
+	case isHLR(r):
+		h, err := webhooks.ParseHLRReport(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !call(rt, w, r.Context(), hlrKey(h), rt.hlrHandlers, h) {
+			return
+		}
+	default:
+		report, err := webhooks.ParseStatusReport(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !call(rt, w, r.Context(), statusReportKey(report), rt.statusHandlers, report) {
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// call calls the handlers with e, unless the router deduplicates events and
+// the event with key was processed before. If one of them fails, it responds
+// with 500 Internal Server Error and returns false.
+func call[E any](rt *Router, w http.ResponseWriter, ctx context.Context, key string, handlers []func(context.Context, E) error, e E) bool {
+	process, err := rt.claim(ctx, key)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return false
+	}
+	if !process {
+		return true
+	}
+
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			// The event is retried, so it must be processed again. If the
+			// key can't be removed, the event is lost either way.
+			rt.release(ctx, key)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return false
+		}
+	}
+	return true
+}
+
+// isJSON reports whether b is a JSON object rather than form parameters.
+func isJSON(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) > 0 && b[0] == '{'
+}
+
+// isHLR reports whether the parameters of r are an HLR result rather than a
+// status report.
+func isHLR(r *http.Request) bool {
+	v := r.URL.Query()
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			return false
+		}
+		v = r.Form
+	}
+	return v.Get("msisdn") != "" && v.Get("recipient") == ""
+}
+
+// isVoice reports whether b is a voice webhook, which contains a list of
+// items rather than a single event.
+func isVoice(b []byte) bool {
+	if !isJSON(b) {
+		return false
+	}
+	var probe struct {
+		Items *json.RawMessage `json:"items"`
+	}
+	return json.Unmarshal(b, &probe) == nil && probe.Items != nil
+}
@@ -0,0 +1,131 @@
+package webhookrouter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/hlr"
+	"github.com/messagebird/go-rest-api/signature"
+	"github.com/messagebird/go-rest-api/voice"
+	"github.com/messagebird/go-rest-api/webhooks"
+)
+
+const testKey = "PlLrKaqvZNRR5zAjm42ZT6q1SQxgbbGd"
+
+const testStatusReportQuery = "id=efa6405d518d4c0c88cce11f7db775fb&recipient=31612345678&status=delivered&statusDatetime=2020-03-08T12%3A30%3A00%2B00%3A00"
+
+const testHLRQuery = "id=27978c50354a93ca0ca8de6h54340177&msisdn=31612345678&network=20406&status=active&details%5Bported%5D=1"
+
+func testdata(t *testing.T, name string) string {
+	b, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// serve sends a webhook signed with testKey to rt and returns the response
+// status.
+func serve(t *testing.T, rt *Router, method, target, body string) int {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if err := signature.NewSigner(testKey).Sign(r); err != nil {
+		t.Fatalf("unexpected error signing request: %s", err)
+	}
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestRouterDispatch(t *testing.T) {
+	var got []string
+	rt := New(signature.NewValidator(testKey))
+	rt.OnMessageStatus(func(_ context.Context, r *webhooks.StatusReport) error {
+		got = append(got, "status "+string(r.Status))
+		return nil
+	})
+	rt.OnHLR(func(_ context.Context, h *hlr.HLR) error {
+		got = append(got, "hlr "+h.Status)
+		return nil
+	})
+	rt.OnInboundMessage(func(_ context.Context, e *webhooks.ConversationEvent) error {
+		got = append(got, "inbound "+e.Message.ID)
+		return nil
+	})
+	rt.OnCallEnded(func(_ context.Context, c *voice.Call) error {
+		got = append(got, "ended "+c.ID)
+		return nil
+	})
+	rt.OnRecordingAvailable(func(_ context.Context, r *voice.Recording) error {
+		got = append(got, "recording "+r.ID)
+		return nil
+	})
+
+	var cases = []struct {
+		name   string
+		method string
+		target string
+		body   string
+		e      string
+	}{
+		{name: "Status report", method: http.MethodGet, target: "/webhooks?" + testStatusReportQuery, e: "status delivered"},
+		{name: "HLR", method: http.MethodGet, target: "/webhooks?" + testHLRQuery, e: "hlr active"},
+		{name: "Conversation message", method: http.MethodPost, target: "/webhooks", body: testdata(t, "conversationMessageCreated.json"), e: "inbound mesid"},
+		{name: "Voice events", method: http.MethodPost, target: "/webhooks", body: testdata(t, "voiceEvents.json"), e: "ended f1aa71c0-8f2a-4fe8-b5ef-9a330454ef58,recording 3b4ac358-9467-4f7a-a6c8-6157ad181123"},
+	}
+
+	for _, tt := range cases {
+		got = nil
+		if code := serve(t, rt, tt.method, tt.target, tt.body); code != http.StatusOK {
+			t.Errorf("got status %d, expected 200, test case: %s", code, tt.name)
+		}
+		if s := strings.Join(got, ","); s != tt.e {
+			t.Errorf("got handled %q, expected %q, test case: %s", s, tt.e, tt.name)
+		}
+	}
+}
+
+func TestRouterStatuses(t *testing.T) {
+	rt := New(signature.NewValidator(testKey))
+	rt.OnConversationEvent(func(context.Context, *webhooks.ConversationEvent) error {
+		return errors.New("database down")
+	})
+
+	var cases = []struct {
+		name   string
+		target string
+		body   string
+		e      int
+	}{
+		{name: "Malformed status report", target: "/webhooks?id=abc", e: http.StatusBadRequest},
+		{name: "Malformed JSON", target: "/webhooks", body: `{"type":`, e: http.StatusBadRequest},
+		{name: "Handler error", target: "/webhooks", body: testdata(t, "conversationMessageCreated.json"), e: http.StatusInternalServerError},
+		{name: "Unhandled event", target: "/webhooks", body: testdata(t, "voiceEvents.json"), e: http.StatusOK},
+	}
+
+	for _, tt := range cases {
+		if code := serve(t, rt, http.MethodPost, tt.target, tt.body); code != tt.e {
+			t.Errorf("got status %d, expected %d, test case: %s", code, tt.e, tt.name)
+		}
+	}
+}
+
+func TestRouterRejectsUnsigned(t *testing.T) {
+	var called bool
+	rt := New(signature.NewValidator(testKey))
+	rt.OnMessageStatus(func(context.Context, *webhooks.StatusReport) error {
+		called = true
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks?"+testStatusReportQuery, nil))
+
+	if w.Code != http.StatusUnauthorized || called {
+		t.Errorf("got status %d and called %v, expected 401 without calling the handler", w.Code, called)
+	}
+}
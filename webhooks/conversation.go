@@ -0,0 +1,69 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/messagebird/go-rest-api/conversation"
+)
+
+// ConversationEvent is an event sent to a Conversations webhook. Message is
+// only set for message events.
+type ConversationEvent struct {
+	Type         conversation.WebhookEvent
+	Contact      *conversation.Contact
+	Conversation *conversation.Conversation
+	Message      *conversation.Message
+}
+
+// Inbound reports whether the event is about a message received from the
+// contact.
+func (e *ConversationEvent) Inbound() bool {
+	return e.Message != nil && e.Message.Direction == conversation.MessageDirectionReceived
+}
+
+// DecodeConversationEvent decodes the body of a Conversations webhook. The
+// content of the message is decoded into the field of
+// conversation.MessageContent matching its type, e.g. Image for images.
+func DecodeConversationEvent(r io.Reader) (*ConversationEvent, error) {
+	e := &ConversationEvent{}
+	if err := json.NewDecoder(r).Decode(e); err != nil {
+		return nil, fmt.Errorf("malformed conversation webhook: %v", err)
+	}
+
+	switch e.Type {
+	case conversation.WebhookEventMessageCreated, conversation.WebhookEventMessageUpdated:
+		if e.Message == nil {
+			return nil, fmt.Errorf("%s event without message", e.Type)
+		}
+	case conversation.WebhookEventConversationCreated, conversation.WebhookEventConversationUpdated:
+		if e.Conversation == nil {
+			return nil, fmt.Errorf("%s event without conversation", e.Type)
+		}
+	case "":
+		return nil, errors.New("conversation webhook without type")
+	}
+
+	return e, nil
+}
+
+// ConversationEventHandler returns a handler that decodes Conversations
+// webhooks and passes their event to fn. It responds like
+// StatusReportHandler.
+func ConversationEventHandler(fn func(*ConversationEvent) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, err := DecodeConversationEvent(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := fn(e); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
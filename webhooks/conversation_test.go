@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/conversation"
+)
+
+func TestDecodeConversationEvent(t *testing.T) {
+	f, err := os.Open("testdata/conversationMessageCreated.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	e, err := DecodeConversationEvent(f)
+	if err != nil {
+		t.Fatalf("unexpected error decoding conversation event: %s", err)
+	}
+
+	if e.Type != conversation.WebhookEventMessageCreated || !e.Inbound() {
+		t.Errorf("got %s event, inbound %v, expected an inbound message.created event", e.Type, e.Inbound())
+	}
+	if e.Contact == nil || e.Contact.MSISDN != "31612345678" {
+		t.Errorf("got contact %+v, expected 31612345678", e.Contact)
+	}
+	if e.Conversation == nil || e.Conversation.ID != "convid" {
+		t.Errorf("got conversation %+v, expected convid", e.Conversation)
+	}
+
+	m := e.Message
+	if m.Type != conversation.MessageTypeInteractive || m.Platform != "whatsapp" || m.From != "31612345678" {
+		t.Errorf("got message %+v, expected an interactive WhatsApp message from 31612345678", m)
+	}
+	if i := m.Content.Interactive; i == nil || i.Type != conversation.InteractiveTypeButtonReply || i.Reply == nil || i.Reply.ID != "track" {
+		t.Errorf("got interactive content %+v, expected a reply to the track button", i)
+	}
+}
+
+func TestDecodeConversationEventInvalid(t *testing.T) {
+	var cases = []struct {
+		name string
+		body string
+	}{
+		{name: "Malformed JSON", body: `{"type":`},
+		{name: "Missing type", body: `{"conversation":{"id":"convid"}}`},
+		{name: "Message event without message", body: `{"type":"message.updated","conversation":{"id":"convid"}}`},
+		{name: "Conversation event without conversation", body: `{"type":"conversation.updated"}`},
+	}
+
+	for _, tt := range cases {
+		if _, err := DecodeConversationEvent(strings.NewReader(tt.body)); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
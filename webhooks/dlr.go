@@ -0,0 +1,159 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+)
+
+// Status is the delivery status of a message to a recipient.
+type Status string
+
+// The statuses reported by status reports.
+const (
+	StatusScheduled      Status = "scheduled"
+	StatusSent           Status = "sent"
+	StatusBuffered       Status = "buffered"
+	StatusDelivered      Status = "delivered"
+	StatusExpired        Status = "expired"
+	StatusDeliveryFailed Status = "delivery_failed"
+)
+
+// Valid reports whether s is one of the known statuses.
+func (s Status) Valid() bool {
+	switch s {
+	case StatusScheduled, StatusSent, StatusBuffered, StatusDelivered, StatusExpired, StatusDeliveryFailed:
+		return true
+	}
+	return false
+}
+
+// Final reports whether no further status reports follow s for the
+// recipient.
+func (s Status) Final() bool {
+	return s == StatusDelivered || s == StatusExpired || s == StatusDeliveryFailed
+}
+
+// StatusReport is the delivery report (DLR) of an SMS to a single recipient,
+// sent to the report URL of the message.
+type StatusReport struct {
+	ID        string // The ID of the message.
+	Reference string // The client reference of the message, if set.
+	Recipient string
+
+	Status         Status
+	StatusReason   string // E.g. "successfully delivered".
+	StatusDatetime time.Time
+
+	// StatusErrorCode is the error code of the operator, or 0 if none was
+	// reported.
+	StatusErrorCode int
+
+	Price            *Price // Nil if not reported.
+	MCCMNC           string
+	Ported           bool
+	MessageLength    int
+	MessagePartCount int
+}
+
+// Price is the price of a message.
+type Price struct {
+	Amount   messagebird.Decimal
+	Currency string
+}
+
+// ParseStatusReport parses the status report in the query of r, or its form
+// for POST requests.
+func ParseStatusReport(r *http.Request) (*StatusReport, error) {
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		return ParseStatusReportValues(r.Form)
+	}
+	return ParseStatusReportValues(r.URL.Query())
+}
+
+// ParseStatusReportValues parses the status report in the parameters v. An
+// error is returned if the ID, recipient or status is missing or if a
+// parameter is malformed. Unknown statuses are returned as is, so check
+// Status.Valid if you depend on them.
+func ParseStatusReportValues(v url.Values) (*StatusReport, error) {
+	report := &StatusReport{
+		ID:           v.Get("id"),
+		Reference:    v.Get("reference"),
+		Recipient:    v.Get("recipient"),
+		Status:       Status(v.Get("status")),
+		StatusReason: v.Get("statusReason"),
+		MCCMNC:       v.Get("mccmnc"),
+	}
+	if report.ID == "" || report.Recipient == "" || report.Status == "" {
+		return nil, errors.New("id, recipient and status are required")
+	}
+
+	var err error
+	if s := v.Get("statusDatetime"); s != "" {
+		if report.StatusDatetime, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, fmt.Errorf("invalid statusDatetime: %v", err)
+		}
+	}
+	if report.StatusErrorCode, err = intParam(v, "statusErrorCode"); err != nil {
+		return nil, err
+	}
+	if report.MessageLength, err = intParam(v, "messageLength"); err != nil {
+		return nil, err
+	}
+	if report.MessagePartCount, err = intParam(v, "messagePartCount"); err != nil {
+		return nil, err
+	}
+	if s := v.Get("ported"); s != "" {
+		if report.Ported, err = strconv.ParseBool(s); err != nil {
+			return nil, fmt.Errorf("invalid ported: %v", err)
+		}
+	}
+	if s := v.Get("price[amount]"); s != "" {
+		amount, err := messagebird.ParseDecimal(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price[amount]: %v", err)
+		}
+		report.Price = &Price{Amount: amount, Currency: v.Get("price[currency]")}
+	}
+
+	return report, nil
+}
+
+// StatusReportHandler returns a handler that parses status reports and
+// passes them to fn. It responds with 400 Bad Request to malformed reports
+// and 500 Internal Server Error if fn returns an error, so MessageBird
+// retries the report later, or 200 OK otherwise.
+func StatusReportHandler(fn func(*StatusReport) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report, err := ParseStatusReport(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := fn(report); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func intParam(v url.Values, key string) (int, error) {
+	s := v.Get(key)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %v", key, err)
+	}
+	return n, nil
+}
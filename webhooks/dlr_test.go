@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testStatusReportQuery = "id=efa6405d518d4c0c88cce11f7db775fb&reference=the-reference&recipient=31612345678&status=delivered&statusReason=successfully+delivered&statusDatetime=2020-03-08T12%3A30%3A00%2B00%3A00&statusErrorCode=0&price%5Bamount%5D=0.07&price%5Bcurrency%5D=EUR&mccmnc=20408&ported=1&messageLength=12&messagePartCount=1"
+
+func TestParseStatusReport(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/dlr?"+testStatusReportQuery, nil)
+
+	report, err := ParseStatusReport(r)
+	if err != nil {
+		t.Fatalf("unexpected error parsing status report: %s", err)
+	}
+
+	if report.ID != "efa6405d518d4c0c88cce11f7db775fb" || report.Reference != "the-reference" || report.Recipient != "31612345678" {
+		t.Errorf("got report %+v", report)
+	}
+	if report.Status != StatusDelivered || !report.Status.Final() {
+		t.Errorf("got status %s, expected final status delivered", report.Status)
+	}
+	if !report.StatusDatetime.Equal(time.Date(2020, 3, 8, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf("got status datetime %s, expected 2020-03-08T12:30:00Z", report.StatusDatetime)
+	}
+	if report.Price == nil || report.Price.Amount.String() != "0.07" || report.Price.Currency != "EUR" {
+		t.Errorf("got price %+v, expected 0.07 EUR", report.Price)
+	}
+	if !report.Ported || report.MCCMNC != "20408" || report.MessagePartCount != 1 || report.MessageLength != 12 {
+		t.Errorf("got report %+v", report)
+	}
+}
+
+func TestParseStatusReportForm(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/dlr", strings.NewReader(testStatusReportQuery))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	report, err := ParseStatusReport(r)
+	if err != nil {
+		t.Fatalf("unexpected error parsing status report: %s", err)
+	}
+	if report.Status != StatusDelivered {
+		t.Errorf("got status %s, expected delivered", report.Status)
+	}
+}
+
+func TestParseStatusReportValuesInvalid(t *testing.T) {
+	var cases = []struct {
+		name  string
+		query string
+	}{
+		{name: "Missing ID", query: "recipient=31612345678&status=sent"},
+		{name: "Missing status", query: "id=abc&recipient=31612345678"},
+		{name: "Invalid datetime", query: "id=abc&recipient=31612345678&status=sent&statusDatetime=yesterday"},
+		{name: "Invalid error code", query: "id=abc&recipient=31612345678&status=sent&statusErrorCode=x"},
+		{name: "Invalid price", query: "id=abc&recipient=31612345678&status=sent&price%5Bamount%5D=free"},
+	}
+
+	for _, tt := range cases {
+		v, _ := url.ParseQuery(tt.query)
+		if _, err := ParseStatusReportValues(v); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
+
+func TestStatusValid(t *testing.T) {
+	if !StatusDeliveryFailed.Valid() || Status("failed").Valid() {
+		t.Error("expected delivery_failed to be valid and failed not to be")
+	}
+	if StatusBuffered.Final() {
+		t.Error("expected buffered not to be final")
+	}
+}
+
+func TestStatusReportHandler(t *testing.T) {
+	var cases = []struct {
+		name  string
+		query string
+		err   error
+		e     int
+	}{
+		{name: "Succesful", query: testStatusReportQuery, e: http.StatusOK},
+		{name: "Malformed report", query: "id=abc", e: http.StatusBadRequest},
+		{name: "Handler error", query: testStatusReportQuery, err: errors.New("database down"), e: http.StatusInternalServerError},
+	}
+
+	for _, tt := range cases {
+		h := StatusReportHandler(func(*StatusReport) error { return tt.err })
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dlr?"+tt.query, nil))
+		if w.Code != tt.e {
+			t.Errorf("got status %d, expected %d, test case: %s", w.Code, tt.e, tt.name)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+/*
+Package webhooks parses the webhooks MessageBird sends to your platform into
+typed structs.
+
+The parsers don't verify webhooks were sent by MessageBird. Wrap the handlers
+with a validator of the signature package to reject forged requests:
+
+	validator := signature.NewValidator("your signing key")
+	http.Handle("/dlr", validator.Validate(webhooks.StatusReportHandler(func(r *webhooks.StatusReport) error {
+		log.Printf("message %s to %s is %s", r.ID, r.Recipient, r.Status)
+		return nil
+	})))
+*/
+package webhooks
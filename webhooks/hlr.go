@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/messagebird/go-rest-api/hlr"
+)
+
+// ParseHLRReport parses the HLR result callback in the query of r, or its
+// form for POST requests. Callbacks are sent to the report URL of the
+// account when the status of a lookup created with hlr.Create changes, so
+// results can be processed without polling hlr.Read; see HLR.Done.
+func ParseHLRReport(r *http.Request) (*hlr.HLR, error) {
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		return ParseHLRReportValues(r.Form)
+	}
+	return ParseHLRReportValues(r.URL.Query())
+}
+
+// ParseHLRReportValues parses the HLR result in the parameters v. An error is
+// returned if the ID, MSISDN or status is missing or if a parameter is
+// malformed.
+//
+// The details are sent as details[key] parameters, which are strings, or as
+// a JSON object in the details parameter.
+func ParseHLRReportValues(v url.Values) (*hlr.HLR, error) {
+	h := &hlr.HLR{
+		ID:        v.Get("id"),
+		HRef:      v.Get("href"),
+		Reference: v.Get("reference"),
+		Status:    v.Get("status"),
+	}
+	if h.ID == "" || v.Get("msisdn") == "" || h.Status == "" {
+		return nil, errors.New("id, msisdn and status are required")
+	}
+
+	var err error
+	if h.MSISDN, err = intParam(v, "msisdn"); err != nil {
+		return nil, err
+	}
+	if h.Network, err = intParam(v, "network"); err != nil {
+		return nil, err
+	}
+	if h.CreatedDatetime, err = timeParam(v, "createdDatetime"); err != nil {
+		return nil, err
+	}
+	if h.StatusDatetime, err = timeParam(v, "statusDatetime"); err != nil {
+		return nil, err
+	}
+	if h.Details, err = detailsParams(v); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// HLRReportHandler returns a handler that parses HLR result callbacks and
+// passes them to fn. It responds like StatusReportHandler.
+func HLRReportHandler(fn func(*hlr.HLR) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, err := ParseHLRReport(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := fn(h); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func detailsParams(v url.Values) (map[string]interface{}, error) {
+	details := make(map[string]interface{})
+	if s := v.Get("details"); s != "" {
+		if err := json.Unmarshal([]byte(s), &details); err != nil {
+			return nil, fmt.Errorf("invalid details: %v", err)
+		}
+	}
+	for key := range v {
+		if !strings.HasPrefix(key, "details[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		details[key[len("details["):len(key)-1]] = v.Get(key)
+	}
+
+	if len(details) == 0 {
+		return nil, nil
+	}
+	return details, nil
+}
+
+func timeParam(v url.Values, key string) (*time.Time, error) {
+	s := v.Get(key)
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", key, err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,71 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/messagebird/go-rest-api/hlr"
+)
+
+const testHLRQuery = "id=27978c50354a93ca0ca8de6h54340177&reference=the-reference&msisdn=31612345678&network=20406&status=active&details%5Bported%5D=1&details%5Broaming%5D=false&details%5Bimsi%5D=204080123456789&createdDatetime=2020-03-08T12%3A29%3A58%2B00%3A00&statusDatetime=2020-03-08T12%3A30%3A00%2B00%3A00"
+
+func TestParseHLRReport(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/hlr?"+testHLRQuery, nil)
+
+	h, err := ParseHLRReport(r)
+	if err != nil {
+		t.Fatalf("unexpected error parsing HLR report: %s", err)
+	}
+
+	if h.ID != "27978c50354a93ca0ca8de6h54340177" || h.Reference != "the-reference" || h.MSISDN != 31612345678 || h.Network != 20406 {
+		t.Errorf("got HLR %+v", h)
+	}
+	if h.Status != hlr.StatusActive || !h.Done() {
+		t.Errorf("got status %s, expected the lookup to be done with status active", h.Status)
+	}
+	if h.StatusDatetime == nil || !h.StatusDatetime.Equal(time.Date(2020, 3, 8, 12, 30, 0, 0, time.UTC)) {
+		t.Errorf("got status datetime %v, expected 2020-03-08T12:30:00Z", h.StatusDatetime)
+	}
+	if !h.Ported() || h.Roaming() || h.Details["imsi"] != "204080123456789" {
+		t.Errorf("got details %v, expected ported, not roaming and the IMSI", h.Details)
+	}
+}
+
+func TestParseHLRReportForm(t *testing.T) {
+	body := "id=27978c50354a93ca0ca8de6h54340177&msisdn=31612345678&status=sent&details=%7B%22ported%22%3Atrue%7D"
+	r := httptest.NewRequest(http.MethodPost, "/hlr", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	h, err := ParseHLRReport(r)
+	if err != nil {
+		t.Fatalf("unexpected error parsing HLR report: %s", err)
+	}
+	if h.Done() || !h.Ported() {
+		t.Errorf("got HLR %+v, expected a ported number that is still being looked up", h)
+	}
+}
+
+func TestParseHLRReportValuesInvalid(t *testing.T) {
+	var cases = []struct {
+		name  string
+		query string
+	}{
+		{name: "Missing MSISDN", query: "id=abc&status=active"},
+		{name: "Missing status", query: "id=abc&msisdn=31612345678"},
+		{name: "Invalid MSISDN", query: "id=abc&msisdn=phone&status=active"},
+		{name: "Invalid network", query: "id=abc&msisdn=31612345678&status=active&network=kpn"},
+		{name: "Invalid datetime", query: "id=abc&msisdn=31612345678&status=active&statusDatetime=yesterday"},
+		{name: "Invalid details", query: "id=abc&msisdn=31612345678&status=active&details=%7B"},
+	}
+
+	for _, tt := range cases {
+		v, _ := url.ParseQuery(tt.query)
+		if _, err := ParseHLRReportValues(v); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/messagebird/go-rest-api/voice"
+)
+
+// VoiceEventKind discriminates the voice events, so they can be handled with
+// a switch statement.
+type VoiceEventKind string
+
+const (
+	VoiceEventCallCreated VoiceEventKind = "call.created"
+	VoiceEventCallUpdated VoiceEventKind = "call.updated"
+	VoiceEventCallEnded   VoiceEventKind = "call.ended"
+
+	VoiceEventLegCreated VoiceEventKind = "leg.created"
+	VoiceEventLegUpdated VoiceEventKind = "leg.updated"
+
+	// VoiceEventRecordingAvailable is sent when a recording is done and can
+	// be downloaded.
+	VoiceEventRecordingAvailable VoiceEventKind = "recording.available"
+	VoiceEventRecordingUpdated   VoiceEventKind = "recording.updated"
+
+	VoiceEventTranscriptionDone   VoiceEventKind = "transcription.done"
+	VoiceEventTranscriptionFailed VoiceEventKind = "transcription.failed"
+
+	// VoiceEventUnknown is the kind of events of types this package does not
+	// know. Their payload is available in Raw.
+	VoiceEventUnknown VoiceEventKind = "unknown"
+)
+
+// VoiceEvent is an event sent to a voice webhook. Only the field matching its
+// Kind is set: Call for call events, Leg for leg events, and so on.
+type VoiceEvent struct {
+	Kind      VoiceEventKind
+	Timestamp time.Time
+
+	// Type and Event are the type of the payload and the event as sent by the
+	// Voice API, e.g. "call" and "callUpdated".
+	Type  string
+	Event string
+
+	Call          *voice.Call
+	Leg           *voice.Leg
+	Recording     *voice.Recording
+	Transcription *voice.Transcription
+
+	Raw json.RawMessage // The payload of the event.
+}
+
+type voiceWebhook struct {
+	Timestamp time.Time `json:"timestamp"`
+	Items     []struct {
+		Type    string          `json:"type"`
+		Event   string          `json:"event"`
+		Payload json.RawMessage `json:"payload"`
+	} `json:"items"`
+}
+
+// DecodeVoiceEvents decodes the events in the body of a voice webhook. A
+// single webhook may contain several events.
+func DecodeVoiceEvents(r io.Reader) ([]*VoiceEvent, error) {
+	var wh voiceWebhook
+	if err := json.NewDecoder(r).Decode(&wh); err != nil {
+		return nil, fmt.Errorf("malformed voice webhook: %v", err)
+	}
+	if len(wh.Items) == 0 {
+		return nil, errors.New("voice webhook contains no events")
+	}
+
+	events := make([]*VoiceEvent, len(wh.Items))
+	for i, item := range wh.Items {
+		e := &VoiceEvent{
+			Timestamp: wh.Timestamp,
+			Type:      item.Type,
+			Event:     item.Event,
+			Raw:       item.Payload,
+		}
+		if err := e.decodePayload(); err != nil {
+			return nil, fmt.Errorf("malformed %s event: %v", item.Type, err)
+		}
+		events[i] = e
+	}
+
+	return events, nil
+}
+
+// decodePayload decodes Raw into the field for the event's type, and sets its
+// Kind.
+func (e *VoiceEvent) decodePayload() error {
+	switch e.Type {
+	case "call":
+		e.Call = &voice.Call{}
+		if err := json.Unmarshal(e.Raw, e.Call); err != nil {
+			return err
+		}
+		switch {
+		case e.Call.Status == voice.CallStatusEnded:
+			e.Kind = VoiceEventCallEnded
+		case e.Event == "callCreated":
+			e.Kind = VoiceEventCallCreated
+		default:
+			e.Kind = VoiceEventCallUpdated
+		}
+	case "leg":
+		e.Leg = &voice.Leg{}
+		if err := json.Unmarshal(e.Raw, e.Leg); err != nil {
+			return err
+		}
+		e.Kind = VoiceEventLegUpdated
+		if e.Event == "legCreated" {
+			e.Kind = VoiceEventLegCreated
+		}
+	case "recording":
+		e.Recording = &voice.Recording{}
+		if err := json.Unmarshal(e.Raw, e.Recording); err != nil {
+			return err
+		}
+		e.Kind = VoiceEventRecordingUpdated
+		if e.Recording.Status == voice.RecordingStatusDone {
+			e.Kind = VoiceEventRecordingAvailable
+		}
+	case "transcription":
+		e.Transcription = &voice.Transcription{}
+		if err := json.Unmarshal(e.Raw, e.Transcription); err != nil {
+			return err
+		}
+		e.Kind = VoiceEventTranscriptionDone
+		if e.Transcription.Error != "" {
+			e.Kind = VoiceEventTranscriptionFailed
+		}
+	default:
+		e.Kind = VoiceEventUnknown
+	}
+	return nil
+}
+
+// VoiceEventHandler returns a handler that decodes voice webhooks and passes
+// each of their events to fn. It responds like StatusReportHandler.
+func VoiceEventHandler(fn func(*VoiceEvent) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events, err := DecodeVoiceEvents(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, e := range events {
+			if err := fn(e); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,87 @@
+package webhooks
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/messagebird/go-rest-api/voice"
+)
+
+func TestDecodeVoiceEvents(t *testing.T) {
+	f, err := os.Open("testdata/voiceEvents.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	events, err := DecodeVoiceEvents(f)
+	if err != nil {
+		t.Fatalf("unexpected error decoding voice events: %s", err)
+	}
+
+	kinds := []VoiceEventKind{VoiceEventCallEnded, VoiceEventLegCreated, VoiceEventRecordingAvailable, VoiceEventTranscriptionDone, VoiceEventUnknown}
+	if len(events) != len(kinds) {
+		t.Fatalf("got %d events, expected %d", len(events), len(kinds))
+	}
+	for i, e := range events {
+		if e.Kind != kinds[i] {
+			t.Errorf("got kind %s for event %d, expected %s", e.Kind, i, kinds[i])
+		}
+	}
+
+	if c := events[0].Call; c == nil || c.EndedAt == nil || c.Destination != "31612345678" {
+		t.Errorf("got call %+v, expected an ended call to 31612345678", c)
+	}
+	if l := events[1].Leg; l == nil || l.Status != voice.LegStatusRinging {
+		t.Errorf("got leg %+v, expected a ringing leg", l)
+	}
+	if r := events[2].Recording; r == nil || r.LegID != "d4f07ab3-b17c-44a8-bcef-2b351311c28f" {
+		t.Errorf("got recording %+v", r)
+	}
+	if e := events[4]; e.Type != "number" || len(e.Raw) == 0 {
+		t.Errorf("got unknown event %+v, expected its type and payload", e)
+	}
+	if events[0].Timestamp.IsZero() {
+		t.Error("expected the webhook timestamp to be set on the events")
+	}
+}
+
+func TestDecodeVoiceEventsInvalid(t *testing.T) {
+	var cases = []struct {
+		name string
+		body string
+	}{
+		{name: "Malformed JSON", body: `{"items":`},
+		{name: "No events", body: `{"timestamp":"2017-08-30T07:35:37Z","items":[]}`},
+		{name: "Malformed payload", body: `{"items":[{"type":"call","event":"callCreated","payload":{"createdAt":"yesterday"}}]}`},
+	}
+
+	for _, tt := range cases {
+		if _, err := DecodeVoiceEvents(strings.NewReader(tt.body)); err == nil {
+			t.Errorf("expected error, got nil, test case: %s", tt.name)
+		}
+	}
+}
+
+func TestVoiceEventHandler(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/voiceEvents.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	h := VoiceEventHandler(func(*VoiceEvent) error {
+		n++
+		return nil
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/voice", strings.NewReader(string(b))))
+
+	if w.Code != http.StatusOK || n != 5 {
+		t.Errorf("got status %d after %d events, expected 200 after 5", w.Code, n)
+	}
+}
@@ -0,0 +1,197 @@
+package webhooktest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/messagebird/go-rest-api/conversation"
+	"github.com/messagebird/go-rest-api/webhooks"
+)
+
+// StatusReport returns the webhook MessageBird sends to the report URL of an
+// SMS with the status report r. The status datetime defaults to the current
+// time.
+func StatusReport(r *webhooks.StatusReport) *Event {
+	v := url.Values{}
+	v.Set("id", r.ID)
+	v.Set("recipient", r.Recipient)
+	v.Set("status", string(r.Status))
+	set(v, "reference", r.Reference)
+	set(v, "statusReason", r.StatusReason)
+	set(v, "mccmnc", r.MCCMNC)
+
+	ts := r.StatusDatetime
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	v.Set("statusDatetime", ts.UTC().Format(time.RFC3339))
+
+	if r.StatusErrorCode != 0 {
+		v.Set("statusErrorCode", strconv.Itoa(r.StatusErrorCode))
+	}
+	if r.MessageLength != 0 {
+		v.Set("messageLength", strconv.Itoa(r.MessageLength))
+	}
+	if r.MessagePartCount != 0 {
+		v.Set("messagePartCount", strconv.Itoa(r.MessagePartCount))
+	}
+	if r.Ported {
+		v.Set("ported", "1")
+	}
+	if r.Price != nil {
+		v.Set("price[amount]", r.Price.Amount.String())
+		v.Set("price[currency]", r.Price.Currency)
+	}
+
+	return &Event{Method: http.MethodGet, Query: v.Encode()}
+}
+
+// InboundSMS returns the webhook MessageBird sends when an SMS with body is
+// received from originator on the number recipient.
+func InboundSMS(originator, recipient, body string) *Event {
+	v := url.Values{}
+	v.Set("id", randomID())
+	v.Set("originator", originator)
+	v.Set("recipient", recipient)
+	v.Set("body", body)
+	v.Set("createdDatetime", time.Now().UTC().Format(time.RFC3339))
+
+	return &Event{Method: http.MethodGet, Query: v.Encode()}
+}
+
+// ConversationMessage returns the message.created webhook of the
+// Conversations API for m. Unset fields default to those of a text message
+// received now from the contact with MSISDN m.From.
+func ConversationMessage(m *conversation.Message) *Event {
+	now := time.Now().UTC()
+	msg := messageJSON{
+		ID:              m.ID,
+		ConversationID:  m.ConversationID,
+		ChannelID:       m.ChannelID,
+		Platform:        m.Platform,
+		To:              m.To,
+		From:            m.From,
+		Direction:       m.Direction,
+		Status:          m.Status,
+		Type:            m.Type,
+		Content:         m.Content,
+		CreatedDatetime: now,
+		UpdatedDatetime: now,
+	}
+	if m.CreatedDatetime != nil {
+		msg.CreatedDatetime = *m.CreatedDatetime
+	}
+	if m.UpdatedDatetime != nil {
+		msg.UpdatedDatetime = *m.UpdatedDatetime
+	}
+	if msg.ID == "" {
+		msg.ID = randomID()
+	}
+	if msg.ConversationID == "" {
+		msg.ConversationID = randomID()
+	}
+	if msg.Platform == "" {
+		msg.Platform = conversation.PlatformSMS
+	}
+	if msg.Direction == "" {
+		msg.Direction = conversation.MessageDirectionReceived
+	}
+	if msg.Status == "" {
+		msg.Status = conversation.MessageStatusReceived
+	}
+	if msg.Type == "" {
+		msg.Type = conversation.MessageTypeText
+	}
+
+	contactID := randomID()
+	b, err := json.Marshal(conversationWebhook{
+		Type: conversation.WebhookEventMessageCreated,
+		Contact: contactJSON{
+			ID:              contactID,
+			MSISDN:          msg.From,
+			CreatedDatetime: msg.CreatedDatetime,
+			UpdatedDatetime: msg.CreatedDatetime,
+		},
+		Conversation: conversationJSON{
+			ID:                   msg.ConversationID,
+			ContactID:            contactID,
+			Status:               conversation.ConversationStatusActive,
+			LastUsedChannelID:    msg.ChannelID,
+			CreatedDatetime:      msg.CreatedDatetime,
+			UpdatedDatetime:      msg.UpdatedDatetime,
+			LastReceivedDatetime: msg.CreatedDatetime,
+		},
+		Message: msg,
+	})
+	if err != nil {
+		// The webhook only consists of types that can be marshalled.
+		panic(err)
+	}
+
+	return &Event{
+		Method: http.MethodPost,
+		Header: http.Header{"Content-Type": {"application/json"}},
+		Body:   b,
+	}
+}
+
+// conversationWebhook is the body of Conversations webhooks, as the API
+// sends it.
+type conversationWebhook struct {
+	Type         conversation.WebhookEvent `json:"type"`
+	Contact      contactJSON               `json:"contact"`
+	Conversation conversationJSON          `json:"conversation"`
+	Message      messageJSON               `json:"message"`
+}
+
+type contactJSON struct {
+	ID              string    `json:"id"`
+	MSISDN          string    `json:"msisdn"`
+	CreatedDatetime time.Time `json:"createdDatetime"`
+	UpdatedDatetime time.Time `json:"updatedDatetime"`
+}
+
+type conversationJSON struct {
+	ID                   string                          `json:"id"`
+	ContactID            string                          `json:"contactId"`
+	Status               conversation.ConversationStatus `json:"status"`
+	LastUsedChannelID    string                          `json:"lastUsedChannelId,omitempty"`
+	CreatedDatetime      time.Time                       `json:"createdDatetime"`
+	UpdatedDatetime      time.Time                       `json:"updatedDatetime"`
+	LastReceivedDatetime time.Time                       `json:"lastReceivedDatetime"`
+}
+
+type messageJSON struct {
+	ID              string                        `json:"id"`
+	ConversationID  string                        `json:"conversationId"`
+	ChannelID       string                        `json:"channelId,omitempty"`
+	Platform        string                        `json:"platform"`
+	To              string                        `json:"to"`
+	From            string                        `json:"from"`
+	Direction       conversation.MessageDirection `json:"direction"`
+	Status          conversation.MessageStatus    `json:"status"`
+	Type            conversation.MessageType      `json:"type"`
+	Content         conversation.MessageContent   `json:"content"`
+	CreatedDatetime time.Time                     `json:"createdDatetime"`
+	UpdatedDatetime time.Time                     `json:"updatedDatetime"`
+}
+
+func set(v url.Values, key, value string) {
+	if value != "" {
+		v.Set(key, value)
+	}
+}
+
+// randomID returns an ID formatted like those of MessageBird resources.
+func randomID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,93 @@
+package webhooktest
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// Recorder returns a handler that writes a dump of each request to w before
+// passing it on to h. Wrap the handler receiving webhooks from MessageBird
+// with it to capture real webhooks for Replay:
+//
+//	f, err := os.OpenFile("webhooks.dump", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+//	...
+//	http.Handle("/webhooks", webhooktest.Recorder(f, yourHandler))
+//
+// Dumps contain the signature headers and payloads of the webhooks, so store
+// them with care.
+func Recorder(w io.Writer, h http.Handler) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		dump, err := httputil.DumpRequest(r, true)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		_, err = w.Write(dump)
+		mu.Unlock()
+		if err != nil {
+			http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		h.ServeHTTP(rw, r)
+	})
+}
+
+// ReadDumps reads the webhooks in dumps, the raw HTTP requests as written by
+// a Recorder. Their signature headers are dropped, as they are signed again
+// when sent.
+func ReadDumps(dumps io.Reader) ([]*Event, error) {
+	br := bufio.NewReader(dumps)
+
+	var events []*Event
+	for {
+		if err := skipSpace(br); err == io.EOF {
+			return events, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		r, err := http.ReadRequest(br)
+		if err != nil {
+			return nil, err
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		h := r.Header.Clone()
+		h.Del("MessageBird-Request-Timestamp")
+		h.Del("MessageBird-Signature")
+		h.Del("MessageBird-Signature-JWT")
+		h.Del("Content-Length")
+
+		events = append(events, &Event{
+			Method: r.Method,
+			Query:  r.URL.RawQuery,
+			Header: h,
+			Body:   b,
+		})
+	}
+}
+
+// skipSpace skips the line breaks between dumps.
+func skipSpace(br *bufio.Reader) error {
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if c != '\r' && c != '\n' {
+			return br.UnreadByte()
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Package webhooktest sends signed webhooks to the handlers of your platform, so
+webhook consumers can be tested without exposing them to MessageBird through
+a tunnel. The webhooks are signed as MessageBird signs them, so handlers
+wrapped with a validator of the signature package accept them:
+
+	srv := webhooktest.NewServer("your signing key", yourHandler)
+	defer srv.Close()
+
+	res, err := srv.Send(webhooktest.StatusReport(&webhooks.StatusReport{
+		ID:        "efa6405d518d4c0c88cce11f7db775fb",
+		Recipient: "31612345678",
+		Status:    webhooks.StatusDelivered,
+	}))
+
+Webhooks received from MessageBird can be captured with a Recorder and sent
+again with Replay. They are signed again when replayed, as the original
+signatures expire.
+*/
+package webhooktest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/messagebird/go-rest-api/signature"
+)
+
+// Event is a webhook request, without the URL it is sent to.
+type Event struct {
+	Method string
+	Query  string // The encoded query string, without '?'.
+	Header http.Header
+	Body   []byte
+}
+
+// Sender sends signed webhooks to URL.
+type Sender struct {
+	URL    string
+	Signer *signature.Signer
+
+	// Client sends the webhooks. It defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// NewSender returns a sender that signs webhooks with signingKey and sends
+// them to webhookURL.
+func NewSender(webhookURL, signingKey string) *Sender {
+	return &Sender{
+		URL:    webhookURL,
+		Signer: signature.NewSigner(signingKey),
+	}
+}
+
+// Send signs and sends e. The caller must close the body of the response.
+func (s *Sender) Send(e *Event) (*http.Response, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = e.Query
+
+	req, err := http.NewRequest(e.Method, u.String(), bytes.NewReader(e.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range e.Header {
+		req.Header[k] = v
+	}
+	if err := s.Signer.Sign(req); err != nil {
+		return nil, err
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// Replay sends the webhooks recorded in dumps, as written by a Recorder, in
+// order. It returns the status codes of the responses, and stops at the first
+// webhook that can not be sent.
+func (s *Sender) Replay(dumps io.Reader) ([]int, error) {
+	events, err := ReadDumps(dumps)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []int
+	for i, e := range events {
+		res, err := s.Send(e)
+		if err != nil {
+			return codes, fmt.Errorf("could not replay webhook %d: %v", i, err)
+		}
+		io.Copy(ioutil.Discard, res.Body)
+		res.Body.Close()
+		codes = append(codes, res.StatusCode)
+	}
+	return codes, nil
+}
+
+// Server is a Sender for a handler under test, served by a local HTTP
+// server.
+type Server struct {
+	*Sender
+
+	srv *httptest.Server
+}
+
+// NewServer starts a server for h and returns a Server that sends webhooks
+// signed with signingKey to it. The caller must call Close when finished.
+func NewServer(signingKey string, h http.Handler) *Server {
+	srv := httptest.NewServer(h)
+	s := NewSender(srv.URL, signingKey)
+	s.Client = srv.Client()
+	return &Server{Sender: s, srv: srv}
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
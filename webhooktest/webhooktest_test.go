@@ -0,0 +1,139 @@
+package webhooktest
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	messagebird "github.com/messagebird/go-rest-api"
+	"github.com/messagebird/go-rest-api/conversation"
+	"github.com/messagebird/go-rest-api/signature"
+	"github.com/messagebird/go-rest-api/webhooks"
+)
+
+const testKey = "PlLrKaqvZNRR5zAjm42ZT6q1SQxgbbGd"
+
+// capture returns a handler validating webhooks signed with key, which
+// stores the last request in *r.
+func capture(key string, r **http.Request) http.Handler {
+	return signature.NewValidator(key).Validate(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		*r = req
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func send(t *testing.T, s *Server, e *Event) {
+	res, err := s.Send(e)
+	if err != nil {
+		t.Fatalf("unexpected error sending webhook: %s", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("got status %s, expected 200 OK", res.Status)
+	}
+}
+
+func TestStatusReport(t *testing.T) {
+	var r *http.Request
+	s := NewServer(testKey, capture(testKey, &r))
+	defer s.Close()
+
+	ts := time.Date(2020, 3, 8, 12, 30, 0, 0, time.UTC)
+	send(t, s, StatusReport(&webhooks.StatusReport{
+		ID:             "efa6405d518d4c0c88cce11f7db775fb",
+		Recipient:      "31612345678",
+		Status:         webhooks.StatusDelivered,
+		StatusDatetime: ts,
+		Price:          &webhooks.Price{Amount: messagebird.NewDecimal(7, 2), Currency: "EUR"},
+	}))
+
+	report, err := webhooks.ParseStatusReport(r)
+	if err != nil {
+		t.Fatalf("unexpected error parsing status report: %s", err)
+	}
+	if report.ID != "efa6405d518d4c0c88cce11f7db775fb" || report.Status != webhooks.StatusDelivered || !report.StatusDatetime.Equal(ts) {
+		t.Errorf("got report %+v, expected the sent report", report)
+	}
+	if report.Price == nil || report.Price.Amount.String() != "0.07" || report.Price.Currency != "EUR" {
+		t.Errorf("got price %+v, expected 0.07 EUR", report.Price)
+	}
+}
+
+func TestInboundSMS(t *testing.T) {
+	var r *http.Request
+	s := NewServer(testKey, capture(testKey, &r))
+	defer s.Close()
+
+	send(t, s, InboundSMS("31612345678", "3197004499", "Hello & bye"))
+
+	q := r.URL.Query()
+	if q.Get("originator") != "31612345678" || q.Get("recipient") != "3197004499" || q.Get("body") != "Hello & bye" {
+		t.Errorf("got query %v, expected originator, recipient and body", q)
+	}
+	if q.Get("id") == "" || q.Get("createdDatetime") == "" {
+		t.Errorf("got query %v, expected id and createdDatetime", q)
+	}
+}
+
+func TestConversationMessage(t *testing.T) {
+	var r *http.Request
+	s := NewServer(testKey, capture(testKey, &r))
+	defer s.Close()
+
+	send(t, s, ConversationMessage(&conversation.Message{
+		From:    "31612345678",
+		Content: conversation.MessageContent{Text: "Hello"},
+	}))
+
+	e, err := webhooks.DecodeConversationEvent(r.Body)
+	if err != nil {
+		t.Fatalf("unexpected error decoding event: %s", err)
+	}
+	if e.Type != conversation.WebhookEventMessageCreated || !e.Inbound() {
+		t.Errorf("got event %s, expected inbound message.created", e.Type)
+	}
+	if e.Message.ID == "" || e.Message.Content.Text != "Hello" || e.Message.Type != conversation.MessageTypeText {
+		t.Errorf("got message %+v, expected a text message", e.Message)
+	}
+	if e.Contact.MSISDN != "31612345678" || e.Conversation.ID != e.Message.ConversationID {
+		t.Errorf("got contact %s and conversation %s, expected those of the message", e.Contact.MSISDN, e.Conversation.ID)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var dumps bytes.Buffer
+	var r *http.Request
+	recorded := NewServer("old-key", Recorder(&dumps, capture("old-key", &r)))
+	defer recorded.Close()
+
+	send(t, recorded, InboundSMS("31612345678", "3197004499", "Hello"))
+	send(t, recorded, ConversationMessage(&conversation.Message{From: "31612345678", Content: conversation.MessageContent{Text: "Hi"}}))
+
+	var received []*http.Request
+	replayed := NewServer(testKey, signature.NewValidator(testKey).Validate(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received = append(received, req)
+		w.WriteHeader(http.StatusOK)
+	})))
+	defer replayed.Close()
+
+	codes, err := replayed.Replay(&dumps)
+	if err != nil {
+		t.Fatalf("unexpected error replaying webhooks: %s", err)
+	}
+	if len(codes) != 2 || codes[0] != http.StatusOK || codes[1] != http.StatusOK {
+		t.Fatalf("got status codes %v, expected [200 200]", codes)
+	}
+	if received[0].URL.Query().Get("body") != "Hello" {
+		t.Errorf("got query %s, expected the recorded SMS", received[0].URL.RawQuery)
+	}
+	if e, err := webhooks.DecodeConversationEvent(received[1].Body); err != nil || e.Message.Content.Text != "Hi" {
+		t.Errorf("got %v, expected the recorded message", err)
+	}
+}
+
+func TestReadDumpsMalformed(t *testing.T) {
+	if _, err := ReadDumps(bytes.NewBufferString("not a request")); err == nil {
+		t.Errorf("expected error reading malformed dump, got nil")
+	}
+}